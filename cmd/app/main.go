@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -15,6 +16,8 @@ import (
 	http_inbound "github.com/dzhordano/urlshortener/internal/adapters/inbound/httpinbound"
 	"github.com/dzhordano/urlshortener/internal/core/application/usecases/commands"
 	"github.com/dzhordano/urlshortener/internal/core/application/usecases/queries"
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/auth"
 	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
 	"github.com/dzhordano/urlshortener/internal/pkg/logger"
 	"github.com/dzhordano/urlshortener/migrations"
@@ -103,6 +106,14 @@ func main() {
 
 	urlCache := cr.NewURLCache(rdb)
 	urlRepo := cr.NewURLRepository(pool)
+	tokenGen := cr.NewTokenGenerator(pool)
+	apiKeyRepo := cr.NewAPIKeyRepository(pool)
+	clickRecorder := cr.NewClickRecorder(pool)
+	clickCounter := cr.NewClickCounter(pool)
+	visitorCounter := cr.NewVisitorCounter(rdb)
+	passwordAttempts := cr.NewPasswordAttemptLimiter(rdb)
+	redirectMetrics := cr.NewRedirectMetrics()
+	geoLocator := cr.NewGeoLocator()
 
 	// Init otel tracer.
 	tp, err := initTracer(ctx, cfg.ServiceName, cfg.JaegerURL, cfg.Environment)
@@ -115,9 +126,23 @@ func main() {
 
 	e := newEchoWebServer(
 		cfg.ServiceName,
-		cr.NewShortenURLCommandHandler(urlCache, urlRepo),
-		cr.NewRedirectQueryHandler(urlCache, pool),
-		cr.NewGetURLInfoQueryHandler(pool),
+		cr.NewShortenURLCommandHandler(urlCache, urlRepo, tokenGen),
+		cr.NewShortenURLsBatchCommandHandler(urlCache, urlRepo, tokenGen),
+		cr.NewUpdateURLCommandHandler(urlCache, urlRepo),
+		cr.NewDeleteURLCommandHandler(urlCache, urlRepo, visitorCounter),
+		cr.NewRedirectQueryHandler(
+			urlCache, clickCounter, visitorCounter, clickRecorder, passwordAttempts, redirectMetrics,
+			geoLocator, pool,
+		),
+		cr.NewPreviewURLQueryHandler(urlCache, pool),
+		cr.NewGetURLInfoQueryHandler(visitorCounter, pool),
+		cr.NewGetURLStatsQueryHandler(pool),
+		cr.NewListURLsQueryHandler(pool),
+		cr.NewAuthenticateCommandHandler(apiKeyRepo),
+		cr.NewCreateAPIKeyCommandHandler(apiKeyRepo),
+		cr.NewRevokeAPIKeyCommandHandler(apiKeyRepo),
+		cr.NewListAPIKeysQueryHandler(pool),
+		cr.NewAuthorizer(),
 	)
 
 	cs, err := cr.NewCronScheduler()
@@ -128,7 +153,7 @@ func main() {
 		return cs.Stop(ctx)
 	})
 
-	cleanExpURLsTask, err := cr.NewCleanExpiredURLsCronTask(pool)
+	cleanExpURLsTask, err := cr.NewCleanExpiredURLsCronTask(pool, urlCache, visitorCounter)
 	if err != nil {
 		log.Fatalf("failed to create cron task for cleaning expired urls: %v", err)
 	}
@@ -142,6 +167,34 @@ func main() {
 		log.Fatalf("failed to schedule cron task for cleaning expired urls: %v", scErr)
 	}
 
+	flushClicksTask, err := cr.NewFlushClickCountsCronTask(clickCounter)
+	if err != nil {
+		log.Fatalf("failed to create cron task for flushing click counts: %v", err)
+	}
+
+	// Schedule so counted clicks are saved every 5 seconds. Remaining ones are saved on shutdown.
+	if scErr := cs.ScheduleInterval(
+		ctx,
+		flushClicksTask,
+		5*time.Second, //nolint:mnd // TODO Hardcoded, could move to config.
+	); scErr != nil {
+		log.Fatalf("failed to schedule cron task for flushing click counts: %v", scErr)
+	}
+
+	rollupClicksTask, err := cr.NewRollupClickEventsCronTask(pool)
+	if err != nil {
+		log.Fatalf("failed to create cron task for rolling up click events: %v", err)
+	}
+
+	// Schedule so stats lag behind clicks for at most a minute.
+	if scErr := cs.ScheduleInterval(
+		ctx,
+		rollupClicksTask,
+		time.Minute, // TODO Hardcoded, could move to config.
+	); scErr != nil {
+		log.Fatalf("failed to schedule cron task for rolling up click events: %v", scErr)
+	}
+
 	// Using run.Group handle startup and graceful shutdown. pretti usful.
 	var g run.Group
 
@@ -211,6 +264,45 @@ func mustLoadFromEnv() cmd.Config {
 		log.Fatalf("error parsing redis ttl: %v", err)
 	}
 
+	tokenLength, err := strconv.Atoi(os.Getenv("TOKEN_LENGTH"))
+	if err != nil {
+		log.Fatalf("error parsing token length: %v", err)
+	}
+
+	linkDefaultTTL, err := time.ParseDuration(os.Getenv("LINK_DEFAULT_TTL"))
+	if err != nil {
+		log.Fatalf("error parsing link default ttl: %v", err)
+	}
+
+	linkMaxTTL, err := time.ParseDuration(os.Getenv("LINK_MAX_TTL"))
+	if err != nil {
+		log.Fatalf("error parsing link max ttl: %v", err)
+	}
+
+	linkExpiredRetention, err := time.ParseDuration(os.Getenv("LINK_EXPIRED_RETENTION"))
+	if err != nil {
+		log.Fatalf("error parsing link expired retention: %v", err)
+	}
+
+	if linkExpiredRetention < 0 {
+		log.Fatalf("link expired retention must not be negative: %v", linkExpiredRetention)
+	}
+
+	linkRedirectCode, err := strconv.Atoi(os.Getenv("LINK_REDIRECT_CODE"))
+	if err != nil {
+		log.Fatalf("error parsing link redirect code: %v", err)
+	}
+
+	redirectCode, err := model.ParseRedirectCode(linkRedirectCode)
+	if err != nil {
+		log.Fatalf("error parsing link redirect code: %v", err)
+	}
+
+	bootstrapAdminKey := os.Getenv("ADMIN_API_KEY")
+	if err = commands.ValidateBootstrapAdminKey(bootstrapAdminKey); err != nil {
+		log.Fatalf("error parsing admin api key: %v", err)
+	}
+
 	return cmd.Config{
 		Environment: os.Getenv("ENVIRONMENT"),
 		ServiceName: os.Getenv("SERVICE_NAME"),
@@ -231,6 +323,23 @@ func mustLoadFromEnv() cmd.Config {
 			Password: os.Getenv("REDIS_PASSWORD"),
 			TTL:      rdbttl,
 		},
+		Token: cmd.TokenConfig{
+			Strategy: os.Getenv("TOKEN_STRATEGY"),
+			Length:   tokenLength,
+		},
+		Link: cmd.LinkConfig{
+			DefaultTTL:       linkDefaultTTL,
+			MaxTTL:           linkMaxTTL,
+			RedirectCode:     redirectCode,
+			FallbackURL:      os.Getenv("LINK_FALLBACK_URL"),
+			ExpiredRetention: linkExpiredRetention,
+		},
+		Auth: cmd.AuthConfig{
+			BootstrapAdminKey: bootstrapAdminKey,
+		},
+		GeoIP: cmd.GeoIPConfig{
+			DBPath: os.Getenv("GEOIP_DB_PATH"),
+		},
 		JaegerURL: os.Getenv("JAEGER_URL"),
 	}
 }
@@ -351,8 +460,19 @@ func initTracer(
 func newEchoWebServer(
 	tracerServerName string,
 	shortenCHandler commands.ShortenURLCommandHandler,
+	shortenBatchCHandler commands.ShortenURLsBatchCommandHandler,
+	updateCHandler commands.UpdateURLCommandHandler,
+	deleteCHandler commands.DeleteURLCommandHandler,
 	redirectQHandler queries.RedirectQueryHandler,
+	previewURLQHandler queries.PreviewURLQueryHandler,
 	getURLInfoQHandler queries.GetURLInfoQueryHandler,
+	getURLStatsQHandler queries.GetURLStatsQueryHandler,
+	listURLsQHandler queries.ListURLsQueryHandler,
+	authenticateCHandler commands.AuthenticateCommandHandler,
+	createAPIKeyCHandler commands.CreateAPIKeyCommandHandler,
+	revokeAPIKeyCHandler commands.RevokeAPIKeyCommandHandler,
+	listAPIKeysQHandler queries.ListAPIKeysQueryHandler,
+	authorizer auth.Authorizer,
 ) *echo.Echo {
 	e := echo.New()
 
@@ -379,13 +499,35 @@ func newEchoWebServer(
 
 	handlers, err := http_inbound.NewServer(
 		shortenCHandler,
+		shortenBatchCHandler,
+		updateCHandler,
+		deleteCHandler,
 		redirectQHandler,
+		previewURLQHandler,
 		getURLInfoQHandler,
+		getURLStatsQHandler,
+		listURLsQHandler,
+		createAPIKeyCHandler,
+		revokeAPIKeyCHandler,
+		listAPIKeysQHandler,
+		authorizer,
 	)
 	if err != nil {
 		log.Fatalf("error creating server: %v", err)
 	}
 
+	spec, err := servers.GetSwagger()
+	if err != nil {
+		log.Fatalf("error loading openapi spec: %v", err)
+	}
+
+	// Authenticate api keys according to spec security requirements.
+	authMiddleware, err := http_inbound.NewAuthMiddleware(authenticateCHandler, spec)
+	if err != nil {
+		log.Fatalf("error creating auth middleware: %v", err)
+	}
+	e.Use(authMiddleware)
+
 	registerMetrics(e)
 	registerSwagOpenAPI(e)
 	registerSwagUI(e)
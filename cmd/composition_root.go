@@ -5,16 +5,27 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/dzhordano/urlshortener/internal/adapters/outbound/geoip"
+	"github.com/dzhordano/urlshortener/internal/adapters/outbound/metrics/redirectmetrics"
+	"github.com/dzhordano/urlshortener/internal/adapters/outbound/pg/apikeyrepo"
+	"github.com/dzhordano/urlshortener/internal/adapters/outbound/pg/clickcounter"
+	"github.com/dzhordano/urlshortener/internal/adapters/outbound/pg/clickrecorder"
+	"github.com/dzhordano/urlshortener/internal/adapters/outbound/pg/tokenseq"
 	"github.com/dzhordano/urlshortener/internal/adapters/outbound/pg/urlrepo"
+	"github.com/dzhordano/urlshortener/internal/adapters/outbound/redis/passwordattempts"
 	"github.com/dzhordano/urlshortener/internal/adapters/outbound/redis/urlcache"
+	"github.com/dzhordano/urlshortener/internal/adapters/outbound/redis/visitorcounter"
+	"github.com/dzhordano/urlshortener/internal/adapters/outbound/tokengen"
 	"github.com/dzhordano/urlshortener/internal/core/application/usecases/commands"
 	"github.com/dzhordano/urlshortener/internal/core/application/usecases/queries"
 	"github.com/dzhordano/urlshortener/internal/core/ports"
+	"github.com/dzhordano/urlshortener/internal/pkg/auth"
 	"github.com/dzhordano/urlshortener/internal/pkg/logger"
 	"github.com/dzhordano/urlshortener/internal/pkg/scheduler"
 	"github.com/dzhordano/urlshortener/internal/pkg/scheduler/cron"
 	"github.com/dzhordano/urlshortener/internal/pkg/scheduler/tasks"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -80,6 +91,14 @@ func (cr *CompositionRoot) NewURLRepository(db *pgxpool.Pool) ports.URLRepositor
 	return urlRepo
 }
 
+func (cr *CompositionRoot) NewAPIKeyRepository(db *pgxpool.Pool) ports.APIKeyRepository {
+	apiKeyRepo, err := apikeyrepo.NewRepository(db)
+	if err != nil {
+		cr.log.Error("error creating api key repo", "error", err)
+	}
+	return apiKeyRepo
+}
+
 func (cr *CompositionRoot) NewURLCache(rdb *redis.Client) ports.URLCache {
 	cache, err := urlcache.NewRedisCache(
 		rdb,
@@ -91,14 +110,119 @@ func (cr *CompositionRoot) NewURLCache(rdb *redis.Client) ports.URLCache {
 	return cache
 }
 
+func (cr *CompositionRoot) NewVisitorCounter(rdb *redis.Client) ports.VisitorCounter {
+	counter, err := visitorcounter.NewRedisVisitorCounter(rdb)
+	if err != nil {
+		cr.log.Error("error creating visitor counter", "error", err)
+	}
+	return counter
+}
+
+func (cr *CompositionRoot) NewPasswordAttemptLimiter(rdb *redis.Client) ports.PasswordAttemptLimiter {
+	limiter, err := passwordattempts.NewRedisPasswordAttemptLimiter(
+		rdb,
+		5,              //nolint:mnd // TODO Could move to config.
+		15*time.Minute, //nolint:mnd // TODO Could move to config.
+	)
+	if err != nil {
+		cr.log.Error("error creating password attempt limiter", "error", err)
+	}
+	return limiter
+}
+
+// NewClickRecorder returns click recorder flushing queued events on close.
+func (cr *CompositionRoot) NewClickRecorder(db *pgxpool.Pool) ports.ClickRecorder {
+	recorder, err := clickrecorder.NewRecorder(cr.log, db, clickrecorder.Config{
+		QueueSize:     10000,       //nolint:mnd // TODO Could move to config.
+		BatchSize:     500,         //nolint:mnd // TODO Could move to config.
+		FlushInterval: time.Second, // TODO Could move to config.
+	})
+	if err != nil {
+		cr.log.Error("error creating click recorder", "error", err)
+		return recorder
+	}
+	cr.RegisterCloseFn(recorder.Close)
+
+	return recorder
+}
+
+// NewClickCounter returns click counter flushing counted clicks on close.
+func (cr *CompositionRoot) NewClickCounter(db *pgxpool.Pool) ports.ClickCounter {
+	counter, err := clickcounter.NewCounter(cr.log, db, prometheus.DefaultRegisterer)
+	if err != nil {
+		cr.log.Error("error creating click counter", "error", err)
+		return counter
+	}
+	cr.RegisterCloseFn(counter.Flush)
+
+	return counter
+}
+
+func (cr *CompositionRoot) NewRedirectMetrics() ports.RedirectMetrics {
+	metrics, err := redirectmetrics.NewMetrics(prometheus.DefaultRegisterer)
+	if err != nil {
+		cr.log.Error("error creating redirect metrics", "error", err)
+	}
+
+	return metrics
+}
+
+// NewGeoLocator returns locator reading configured geoip database, or one locating no ips if there is none.
+func (cr *CompositionRoot) NewGeoLocator() ports.GeoLocator {
+	if cr.cfg.GeoIP.DBPath == "" {
+		cr.log.Info("geoip database is not set, visitors' countries are unknown")
+		return geoip.NewNoopLocator()
+	}
+
+	locator, err := geoip.NewMMDBLocator(cr.cfg.GeoIP.DBPath)
+	if err != nil {
+		cr.log.Error("error creating geo locator", "error", err)
+		return locator
+	}
+	cr.RegisterCloseFn(locator.Close)
+
+	return locator
+}
+
+func (cr *CompositionRoot) NewTokenGenerator(db *pgxpool.Pool) ports.TokenGenerator {
+	var (
+		tokenGen ports.TokenGenerator
+		err      error
+	)
+
+	switch cr.cfg.Token.Strategy {
+	case TokenStrategySequence:
+		tokenGen, err = tokenseq.NewGenerator(db, cr.cfg.Token.Length)
+	case TokenStrategyHash:
+		tokenGen, err = tokengen.NewHashGenerator(cr.cfg.Token.Length)
+	case TokenStrategyRandom:
+		tokenGen, err = tokengen.NewRandomGenerator(cr.cfg.Token.Length)
+	default:
+		cr.log.Warn("unknown token strategy, using random", "strategy", cr.cfg.Token.Strategy)
+		tokenGen, err = tokengen.NewRandomGenerator(cr.cfg.Token.Length)
+	}
+	if err != nil {
+		cr.log.Error("error creating token generator", "error", err)
+	}
+
+	return tokenGen
+}
+
 func (cr *CompositionRoot) NewShortenURLCommandHandler(
 	urlCache ports.URLCache,
 	urlRepo ports.URLRepository,
+	tokenGen ports.TokenGenerator,
 ) commands.ShortenURLCommandHandler {
 	handler, err := commands.NewShortenURLCommandHandler(
 		cr.log,
 		urlCache,
 		urlRepo,
+		tokenGen,
+		commands.ExpirationPolicy{
+			DefaultTTL: cr.cfg.Link.DefaultTTL,
+			MaxTTL:     cr.cfg.Link.MaxTTL,
+		},
+		cr.cfg.Link.RedirectCode,
 	)
 	if err != nil {
 		cr.log.Error("error creating shorten url command handler", "error", err)
@@ -107,11 +231,76 @@ func (cr *CompositionRoot) NewShortenURLCommandHandler(
 	return handler
 }
 
+func (cr *CompositionRoot) NewShortenURLsBatchCommandHandler(
+	urlCache ports.URLCache,
+	urlRepo ports.URLRepository,
+	tokenGen ports.TokenGenerator,
+) commands.ShortenURLsBatchCommandHandler {
+	handler, err := commands.NewShortenURLsBatchCommandHandler(
+		cr.log,
+		urlCache,
+		urlRepo,
+		tokenGen,
+		commands.ExpirationPolicy{
+			DefaultTTL: cr.cfg.Link.DefaultTTL,
+			MaxTTL:     cr.cfg.Link.MaxTTL,
+		},
+		cr.cfg.Link.RedirectCode,
+	)
+	if err != nil {
+		cr.log.Error("error creating shorten urls batch command handler", "error", err)
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewUpdateURLCommandHandler(
+	urlCache ports.URLCache,
+	urlRepo ports.URLRepository,
+) commands.UpdateURLCommandHandler {
+	handler, err := commands.NewUpdateURLCommandHandler(
+		cr.log,
+		urlCache,
+		urlRepo,
+		commands.ExpirationPolicy{
+			DefaultTTL: cr.cfg.Link.DefaultTTL,
+			MaxTTL:     cr.cfg.Link.MaxTTL,
+		},
+	)
+	if err != nil {
+		cr.log.Error("error creating update url command handler", "error", err)
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewDeleteURLCommandHandler(
+	urlCache ports.URLCache,
+	urlRepo ports.URLRepository,
+	visitorCounter ports.VisitorCounter,
+) commands.DeleteURLCommandHandler {
+	handler, err := commands.NewDeleteURLCommandHandler(cr.log, urlCache, urlRepo, visitorCounter)
+	if err != nil {
+		cr.log.Error("error creating delete url command handler", "error", err)
+	}
+
+	return handler
+}
+
 func (cr *CompositionRoot) NewRedirectQueryHandler(
 	urlCache ports.URLCache,
+	clickCounter ports.ClickCounter,
+	visitorCounter ports.VisitorCounter,
+	clickRecorder ports.ClickRecorder,
+	passwordAttempts ports.PasswordAttemptLimiter,
+	redirectMetrics ports.RedirectMetrics,
+	geoLocator ports.GeoLocator,
 	db *pgxpool.Pool,
 ) queries.RedirectQueryHandler {
-	handler, err := queries.NewRedirectQueryHandler(cr.log, urlCache, db)
+	handler, err := queries.NewRedirectQueryHandler(
+		cr.log, urlCache, clickCounter, visitorCounter, clickRecorder, passwordAttempts, redirectMetrics, geoLocator,
+		db, cr.cfg.Link.FallbackURL,
+	)
 	if err != nil {
 		cr.log.Error("error creating redirect query handler", "error", err)
 	}
@@ -119,16 +308,107 @@ func (cr *CompositionRoot) NewRedirectQueryHandler(
 	return handler
 }
 
+func (cr *CompositionRoot) NewPreviewURLQueryHandler(
+	urlCache ports.URLCache,
+	db *pgxpool.Pool,
+) queries.PreviewURLQueryHandler {
+	handler, err := queries.NewPreviewURLQueryHandler(cr.log, urlCache, db)
+	if err != nil {
+		cr.log.Error("error creating preview url query handler", "error", err)
+	}
+
+	return handler
+}
+
 func (cr *CompositionRoot) NewGetURLInfoQueryHandler(
+	visitorCounter ports.VisitorCounter,
 	db *pgxpool.Pool,
 ) queries.GetURLInfoQueryHandler {
-	handler, err := queries.NewGetURLInfoQueryHandler(cr.log, db)
+	handler, err := queries.NewGetURLInfoQueryHandler(cr.log, visitorCounter, db)
 	if err != nil {
 		cr.log.Error("error creating get url info query handler", "error", err)
 	}
 	return handler
 }
 
+func (cr *CompositionRoot) NewGetURLStatsQueryHandler(
+	db *pgxpool.Pool,
+) queries.GetURLStatsQueryHandler {
+	handler, err := queries.NewGetURLStatsQueryHandler(cr.log, db)
+	if err != nil {
+		cr.log.Error("error creating get url stats query handler", "error", err)
+	}
+	return handler
+}
+
+func (cr *CompositionRoot) NewListURLsQueryHandler(
+	db *pgxpool.Pool,
+) queries.ListURLsQueryHandler {
+	handler, err := queries.NewListURLsQueryHandler(cr.log, db)
+	if err != nil {
+		cr.log.Error("error creating list urls query handler", "error", err)
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewAuthenticateCommandHandler(
+	apiKeyRepo ports.APIKeyRepository,
+) commands.AuthenticateCommandHandler {
+	if cr.cfg.Auth.BootstrapAdminKey != "" {
+		cr.log.Warn("bootstrap admin api key is enabled, consider disabling it once stored admin key is created")
+	}
+
+	handler, err := commands.NewAuthenticateCommandHandler(cr.log, apiKeyRepo, cr.cfg.Auth.BootstrapAdminKey)
+	if err != nil {
+		cr.log.Error("error creating authenticate command handler", "error", err)
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewCreateAPIKeyCommandHandler(
+	apiKeyRepo ports.APIKeyRepository,
+) commands.CreateAPIKeyCommandHandler {
+	handler, err := commands.NewCreateAPIKeyCommandHandler(cr.log, apiKeyRepo)
+	if err != nil {
+		cr.log.Error("error creating create api key command handler", "error", err)
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewRevokeAPIKeyCommandHandler(
+	apiKeyRepo ports.APIKeyRepository,
+) commands.RevokeAPIKeyCommandHandler {
+	handler, err := commands.NewRevokeAPIKeyCommandHandler(cr.log, apiKeyRepo)
+	if err != nil {
+		cr.log.Error("error creating revoke api key command handler", "error", err)
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewListAPIKeysQueryHandler(
+	db *pgxpool.Pool,
+) queries.ListAPIKeysQueryHandler {
+	handler, err := queries.NewListAPIKeysQueryHandler(cr.log, db)
+	if err != nil {
+		cr.log.Error("error creating list api keys query handler", "error", err)
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewAuthorizer() auth.Authorizer {
+	authorizer, err := auth.NewAuthorizer(cr.log)
+	if err != nil {
+		cr.log.Error("error creating authorizer", "error", err)
+	}
+
+	return authorizer
+}
+
 func (cr *CompositionRoot) NewCronScheduler() (scheduler.Scheduler, error) {
 	cs := cron.NewCronScheduler(cr.log)
 	return cs, nil
@@ -136,7 +416,23 @@ func (cr *CompositionRoot) NewCronScheduler() (scheduler.Scheduler, error) {
 
 func (cr *CompositionRoot) NewCleanExpiredURLsCronTask(
 	db *pgxpool.Pool,
+	cache ports.URLCache,
+	visitorCounter ports.VisitorCounter,
 ) (scheduler.Task, error) {
-	cj := tasks.NewCleanupExpiredURLsTask(db)
+	cj := tasks.NewCleanupExpiredURLsTask(db, cache, visitorCounter, cr.cfg.Link.ExpiredRetention)
 	return cj, nil
 }
+
+func (cr *CompositionRoot) NewFlushClickCountsCronTask(
+	counter ports.ClickCounter,
+) (scheduler.Task, error) {
+	ft := tasks.NewFlushClickCountsTask(counter)
+	return ft, nil
+}
+
+func (cr *CompositionRoot) NewRollupClickEventsCronTask(
+	db *pgxpool.Pool,
+) (scheduler.Task, error) {
+	rt := tasks.NewRollupClickEventsTask(db)
+	return rt, nil
+}
@@ -3,6 +3,8 @@ package cmd
 import (
 	"fmt"
 	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
 )
 
 const (
@@ -10,12 +12,23 @@ const (
 	EnvironmentDevelopment = "development"
 )
 
+// Token generation strategies.
+const (
+	TokenStrategyRandom   = "random"
+	TokenStrategySequence = "sequence"
+	TokenStrategyHash     = "hash"
+)
+
 type Config struct {
 	Environment string
 	ServiceName string
 	HTTP        HTTPConfig
 	DB          DBConfig
 	RDB         RedisConfig
+	Token       TokenConfig
+	Link        LinkConfig
+	Auth        AuthConfig
+	GeoIP       GeoIPConfig
 	JaegerURL   string
 }
 
@@ -52,3 +65,35 @@ type RedisConfig struct {
 func (c *RedisConfig) Addr() string {
 	return fmt.Sprintf("%s:%s", c.Host, c.Port)
 }
+
+type TokenConfig struct {
+	// Strategy is one of TokenStrategy* values.
+	Strategy string
+	Length   int
+}
+
+type LinkConfig struct {
+	// DefaultTTL is lifetime of links created without explicit expiration.
+	DefaultTTL time.Duration
+	// MaxTTL bounds lifetime requested on link creation. Zero means no bound.
+	MaxTTL time.Duration
+	// RedirectCode is redirect status code of links created without one.
+	RedirectCode model.RedirectCode
+	// FallbackURL is redirected to when expired, disabled or used up link has no fallback url of its own.
+	// Empty disables it.
+	FallbackURL string
+	// ExpiredRetention is how long expired links are kept before being deleted.
+	ExpiredRetention time.Duration
+}
+
+type GeoIPConfig struct {
+	// DBPath is path to MaxMind DB format database visitors' countries are located with.
+	// Empty disables locating, visitors' countries are unknown then.
+	DBPath string
+}
+
+type AuthConfig struct {
+	// BootstrapAdminKey is api key granted admin scope without being stored.
+	// Meant for creating the first stored keys. Empty disables it.
+	BootstrapAdminKey string
+}
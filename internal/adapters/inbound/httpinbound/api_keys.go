@@ -0,0 +1,138 @@
+package httpinbound
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/commands"
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/queries"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
+	"github.com/labstack/echo/v4"
+)
+
+// listAPIKeysResponse is 200 response body of ListAPIKeys.
+type listAPIKeysResponse struct {
+	Items []servers.APIKey `json:"items"`
+}
+
+// Lists api keys
+// (GET /api/v1/admin/keys)
+
+func (s *Server) ListAPIKeys(ctx echo.Context, params servers.ListAPIKeysParams) error {
+	if _, err := s.authorize(ctx); err != nil {
+		return err
+	}
+
+	q, err := queries.NewListAPIKeysQuery(params.IncludeRevoked != nil && *params.IncludeRevoked)
+	if err != nil {
+		return newBadRequestError(err)
+	}
+
+	resp, err := s.listAPIKeysQueryHandler.Handle(ctx.Request().Context(), q)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+	}
+
+	body := listAPIKeysResponse{Items: make([]servers.APIKey, 0, len(resp.Keys))}
+	for _, key := range resp.Keys {
+		body.Items = append(body.Items, toAPIKeyResponse(key))
+	}
+
+	return ctx.JSON(http.StatusOK, body)
+}
+
+// Creates api key
+// (POST /api/v1/admin/keys)
+
+func (s *Server) CreateAPIKey(ctx echo.Context) error {
+	if _, err := s.authorize(ctx); err != nil {
+		return err
+	}
+
+	var req servers.CreateAPIKeyJSONBody
+	if err := ctx.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	var scopes []string
+	if req.Scopes != nil {
+		for _, scope := range *req.Scopes {
+			scopes = append(scopes, string(scope))
+		}
+	}
+
+	cmd, err := commands.NewCreateAPIKeyCommand(req.Name, string(req.Role), scopes, req.ExpiresAt)
+	if err != nil {
+		return newBadRequestError(err)
+	}
+
+	res, err := s.createAPIKeyCommandHandler.Handle(ctx.Request().Context(), cmd)
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrValueIsInvalid), errors.Is(err, errs.ErrValueIsRequired):
+			return newBadRequestError(err)
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+		}
+	}
+
+	return ctx.JSON(http.StatusCreated, servers.CreatedAPIKey{
+		Key: res.Key,
+		ApiKey: toAPIKeyResponse(queries.APIKeyInfo{
+			ID:            res.APIKey.ID.String(),
+			Prefix:        res.APIKey.Prefix,
+			Name:          res.APIKey.Name,
+			Role:          string(res.APIKey.Role),
+			Scopes:        res.APIKey.Scopes,
+			CreatedAtUTC:  res.APIKey.CreatedAtUTC,
+			LastUsedAtUTC: res.APIKey.LastUsedAtUTC,
+			ExpiresAtUTC:  res.APIKey.ExpiresAtUTC,
+			Revoked:       res.APIKey.Revoked,
+		}),
+	})
+}
+
+// Revokes api key
+// (DELETE /api/v1/admin/keys/{id})
+
+func (s *Server) RevokeAPIKey(ctx echo.Context, id string) error {
+	if _, err := s.authorize(ctx); err != nil {
+		return err
+	}
+
+	cmd, err := commands.NewRevokeAPIKeyCommand(id)
+	if err != nil {
+		return newBadRequestError(err)
+	}
+
+	err = s.revokeAPIKeyCommandHandler.Handle(ctx.Request().Context(), cmd)
+	if err != nil {
+		if errors.Is(err, errs.ErrObjectNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "api key not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// toAPIKeyResponse maps api key info to openapi APIKey schema.
+func toAPIKeyResponse(key queries.APIKeyInfo) servers.APIKey {
+	scopes := key.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	return servers.APIKey{
+		Id:         key.ID,
+		Prefix:     key.Prefix,
+		Name:       key.Name,
+		Role:       servers.Role(key.Role),
+		Scopes:     scopes,
+		CreatedAt:  key.CreatedAtUTC,
+		LastUsedAt: key.LastUsedAtUTC,
+		ExpiresAt:  key.ExpiresAtUTC,
+		Revoked:    key.Revoked,
+	}
+}
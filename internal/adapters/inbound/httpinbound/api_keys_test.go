@@ -0,0 +1,221 @@
+//nolint:nolintlint,exhaustruct,testpackage
+package httpinbound
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/commands"
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/queries"
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/auth"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
+	commands_mocks "github.com/dzhordano/urlshortener/mocks/core/application_mocks/commands"
+	queries_mocks "github.com/dzhordano/urlshortener/mocks/core/application_mocks/queries"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_CreateAPIKey(t *testing.T) {
+	tt := []struct {
+		name         string
+		principal    *auth.Principal
+		reqName      string
+		reqRole      servers.Role
+		reqScopes    []servers.Scope
+		expectedCode int
+		mockBehavior func(m *commands_mocks.CreateAPIKeyCommandHandlerMock)
+	}{
+		{
+			name:         "success",
+			principal:    &adminPrincipal,
+			reqName:      "ci",
+			reqRole:      servers.RoleViewer,
+			reqScopes:    []servers.Scope{servers.ScopeLinksCreate},
+			expectedCode: http.StatusCreated,
+			mockBehavior: func(m *commands_mocks.CreateAPIKeyCommandHandlerMock) {
+				m.On("Handle", mock.Anything, commands.CreateAPIKeyCommand{
+					Name:   "ci",
+					Role:   model.RoleViewer,
+					Scopes: []string{model.ScopeLinksCreate},
+				}).
+					Return(commands.CreateAPIKeyResult{
+						Key:    "usk_0123456789",
+						APIKey: model.APIKey{ID: uuid.New(), Prefix: "usk_01234567", Name: "ci"},
+					}, nil).
+					Once()
+			},
+		},
+		{
+			name:         "unknown scope",
+			principal:    &adminPrincipal,
+			reqName:      "ci",
+			reqRole:      servers.RoleEditor,
+			reqScopes:    []servers.Scope{"root"},
+			expectedCode: http.StatusBadRequest,
+			mockBehavior: func(*commands_mocks.CreateAPIKeyCommandHandlerMock) {},
+		},
+		{
+			name:         "unknown role",
+			principal:    &adminPrincipal,
+			reqName:      "ci",
+			reqRole:      "root",
+			expectedCode: http.StatusBadRequest,
+			mockBehavior: func(*commands_mocks.CreateAPIKeyCommandHandlerMock) {},
+		},
+		{
+			name:         "no name",
+			principal:    &adminPrincipal,
+			reqRole:      servers.RoleEditor,
+			expectedCode: http.StatusBadRequest,
+			mockBehavior: func(*commands_mocks.CreateAPIKeyCommandHandlerMock) {},
+		},
+		{
+			name:         "not admin",
+			principal:    &auth.Principal{Name: "user", Scopes: model.RoleEditor.Scopes()},
+			reqName:      "ci",
+			reqRole:      servers.RoleEditor,
+			expectedCode: http.StatusForbidden,
+			mockBehavior: func(*commands_mocks.CreateAPIKeyCommandHandlerMock) {},
+		},
+		{
+			name:         "unauthorized",
+			reqName:      "ci",
+			reqRole:      servers.RoleEditor,
+			expectedCode: http.StatusUnauthorized,
+			mockBehavior: func(*commands_mocks.CreateAPIKeyCommandHandlerMock) {},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			rs := servers.CreateAPIKeyJSONBody{Name: tc.reqName, Role: tc.reqRole}
+			if tc.reqScopes != nil {
+				rs.Scopes = &tc.reqScopes
+			}
+			body, _ := json.Marshal(rs)
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			setScopes(ctx, model.ScopeAdminKeys)
+			if tc.principal != nil {
+				setPrincipal(ctx, *tc.principal)
+			}
+
+			m := commands_mocks.NewCreateAPIKeyCommandHandlerMock(t)
+			tc.mockBehavior(m)
+
+			s := &Server{createAPIKeyCommandHandler: m, authorizer: testAuthorizer(t)}
+
+			err := s.CreateAPIKey(ctx)
+
+			if err != nil {
+				var httpErr *echo.HTTPError
+				require.True(t, errors.As(err, &httpErr))
+				assert.Equal(t, tc.expectedCode, httpErr.Code)
+				return
+			}
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+
+			var resp servers.CreatedAPIKey
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, "usk_0123456789", resp.Key)
+			assert.Equal(t, "usk_01234567", resp.ApiKey.Prefix)
+		})
+	}
+}
+
+func TestServer_ListAPIKeys(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+	setPrincipal(ctx, adminPrincipal)
+
+	m := queries_mocks.NewListAPIKeysQueryHandlerMock(t)
+	m.On("Handle", mock.Anything, queries.ListAPIKeysQuery{IncludeRevoked: false}).
+		Return(queries.ListAPIKeysResponse{Keys: []queries.APIKeyInfo{{ID: "id", Prefix: "usk_01234567"}}}, nil).
+		Once()
+
+	s := &Server{listAPIKeysQueryHandler: m, authorizer: testAuthorizer(t)}
+
+	require.NoError(t, s.ListAPIKeys(ctx, servers.ListAPIKeysParams{}))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp listAPIKeysResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, "usk_01234567", resp.Items[0].Prefix)
+	assert.Empty(t, resp.Items[0].Scopes)
+}
+
+func TestServer_RevokeAPIKey(t *testing.T) {
+	id := uuid.New()
+
+	tt := []struct {
+		name         string
+		reqID        string
+		expectedCode int
+		mockBehavior func(m *commands_mocks.RevokeAPIKeyCommandHandlerMock)
+	}{
+		{
+			name:         "success",
+			reqID:        id.String(),
+			expectedCode: http.StatusNoContent,
+			mockBehavior: func(m *commands_mocks.RevokeAPIKeyCommandHandlerMock) {
+				m.On("Handle", mock.Anything, commands.RevokeAPIKeyCommand{ID: id}).Return(nil).Once()
+			},
+		},
+		{
+			name:         "malformed id",
+			reqID:        "not-uuid",
+			expectedCode: http.StatusBadRequest,
+			mockBehavior: func(*commands_mocks.RevokeAPIKeyCommandHandlerMock) {},
+		},
+		{
+			name:         "not found",
+			reqID:        id.String(),
+			expectedCode: http.StatusNotFound,
+			mockBehavior: func(m *commands_mocks.RevokeAPIKeyCommandHandlerMock) {
+				m.On("Handle", mock.Anything, commands.RevokeAPIKeyCommand{ID: id}).
+					Return(errs.NewObjectNotFoundError("id", id)).
+					Once()
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(httptest.NewRequest(http.MethodDelete, "/", nil), rec)
+			setPrincipal(ctx, adminPrincipal)
+
+			m := commands_mocks.NewRevokeAPIKeyCommandHandlerMock(t)
+			tc.mockBehavior(m)
+
+			s := &Server{revokeAPIKeyCommandHandler: m, authorizer: testAuthorizer(t)}
+
+			err := s.RevokeAPIKey(ctx, tc.reqID)
+
+			if err != nil {
+				var httpErr *echo.HTTPError
+				require.True(t, errors.As(err, &httpErr))
+				assert.Equal(t, tc.expectedCode, httpErr.Code)
+				return
+			}
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
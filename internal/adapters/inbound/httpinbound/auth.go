@@ -0,0 +1,116 @@
+package httpinbound
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/commands"
+	"github.com/dzhordano/urlshortener/internal/pkg/auth"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// apiKeySecurityScheme is name of openapi security scheme authenticating requests with api keys.
+	apiKeySecurityScheme = "ApiKeyAuth"
+	apiKeyHeader         = "X-Api-Key"
+)
+
+// NewAuthMiddleware returns echo middleware authenticating requests with api key passed in X-Api-Key header.
+//
+// Operations requiring ApiKeyAuth security scheme are taken from openapi spec and rejected if key is missing.
+// For other operations key is optional, but must be valid if given. Authenticated principal is put
+// into request context. Routes not described in spec are passed as is.
+func NewAuthMiddleware(
+	authenticateCommandHandler commands.AuthenticateCommandHandler,
+	spec *openapi3.T,
+) (echo.MiddlewareFunc, error) {
+	if authenticateCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("authenticateCommandHandler")
+	}
+
+	if spec == nil {
+		return nil, errs.NewValueIsRequiredError("spec")
+	}
+
+	required := apiKeyRequirements(spec)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			keyRequired, ok := required[routeKey(ctx.Request().Method, ctx.Path())]
+			if !ok {
+				return next(ctx)
+			}
+
+			key := ctx.Request().Header.Get(apiKeyHeader)
+			if key == "" {
+				if keyRequired {
+					return newHTTPError(http.StatusUnauthorized, errCodeUnauthorized, "api key is required")
+				}
+				return next(ctx)
+			}
+
+			cmd, err := commands.NewAuthenticateCommand(key)
+			if err != nil {
+				return newHTTPError(http.StatusUnauthorized, errCodeUnauthorized, "invalid api key")
+			}
+
+			principal, err := authenticateCommandHandler.Handle(ctx.Request().Context(), cmd)
+			if err != nil {
+				if errors.Is(err, commands.ErrInvalidAPIKey) {
+					return newHTTPError(http.StatusUnauthorized, errCodeUnauthorized, "invalid api key")
+				}
+				return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+			}
+
+			ctx.SetRequest(ctx.Request().WithContext(auth.WithPrincipal(ctx.Request().Context(), principal)))
+
+			return next(ctx)
+		}
+	}, nil
+}
+
+// apiKeyRequirements returns whether api key is required for every spec operation keyed by routeKey.
+func apiKeyRequirements(spec *openapi3.T) map[string]bool {
+	required := make(map[string]bool)
+
+	for path, item := range spec.Paths.Map() {
+		for method, op := range item.Operations() {
+			security := spec.Security
+			if op.Security != nil {
+				security = *op.Security
+			}
+
+			required[routeKey(method, echoPath(path))] = requiresAPIKey(security)
+		}
+	}
+
+	return required
+}
+
+// requiresAPIKey reports whether security can be satisfied with api key only.
+// Empty security or empty requirement in it means operation is public.
+func requiresAPIKey(security openapi3.SecurityRequirements) bool {
+	if len(security) == 0 {
+		return false
+	}
+
+	for _, req := range security {
+		if _, ok := req[apiKeySecurityScheme]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// echoPath converts openapi path template to echo one, e.g. /{token}/info to /:token/info.
+func echoPath(path string) string {
+	return strings.NewReplacer("{", ":", "}", "").Replace(path)
+}
+
+func routeKey(method string, path string) string {
+	return method + " " + path
+}
@@ -0,0 +1,250 @@
+//nolint:nolintlint,exhaustruct,testpackage
+package httpinbound
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/commands"
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/auth"
+	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
+	"github.com/dzhordano/urlshortener/internal/pkg/logger"
+	commands_mocks "github.com/dzhordano/urlshortener/mocks/core/application_mocks/commands"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:gochecknoglobals // Test fixture.
+var adminPrincipal = auth.Principal{Name: "admin", Role: string(model.RoleAdmin), Scopes: model.RoleAdmin.Scopes()}
+
+func testAuthorizer(t *testing.T) auth.Authorizer {
+	t.Helper()
+
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	a, err := auth.NewAuthorizer(l)
+	require.NoError(t, err)
+
+	return a
+}
+
+// setScopes declares scopes required by operation the way generated server wrapper does.
+func setScopes(ctx echo.Context, scopes ...string) {
+	ctx.Set(servers.ApiKeyAuthScopes, scopes)
+}
+
+// setPrincipal authenticates request as principal the way auth middleware does.
+func setPrincipal(ctx echo.Context, principal auth.Principal) {
+	ctx.SetRequest(ctx.Request().WithContext(auth.WithPrincipal(ctx.Request().Context(), principal)))
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	tt := []struct {
+		name          string
+		method        string
+		path          string
+		apiKey        string
+		expectedCode  int
+		expectedScope string
+		mockBehavior  func(m *commands_mocks.AuthenticateCommandHandlerMock)
+	}{
+		{
+			name:         "secured without key",
+			method:       http.MethodGet,
+			path:         "/api/v1/:token/info",
+			expectedCode: http.StatusUnauthorized,
+			mockBehavior: func(*commands_mocks.AuthenticateCommandHandlerMock) {},
+		},
+		{
+			name:          "secured with valid key",
+			method:        http.MethodGet,
+			path:          "/api/v1/:token/info",
+			apiKey:        "usk_valid",
+			expectedCode:  http.StatusOK,
+			expectedScope: model.ScopeAdmin,
+			mockBehavior: func(m *commands_mocks.AuthenticateCommandHandlerMock) {
+				m.On("Handle", mock.Anything, commands.AuthenticateCommand{Key: "usk_valid"}).
+					Return(adminPrincipal, nil).
+					Once()
+			},
+		},
+		{
+			name:         "secured with invalid key",
+			method:       http.MethodGet,
+			path:         "/api/v1/:token/info",
+			apiKey:       "usk_invalid",
+			expectedCode: http.StatusUnauthorized,
+			mockBehavior: func(m *commands_mocks.AuthenticateCommandHandlerMock) {
+				m.On("Handle", mock.Anything, commands.AuthenticateCommand{Key: "usk_invalid"}).
+					Return(auth.Principal{}, commands.ErrInvalidAPIKey).
+					Once()
+			},
+		},
+		{
+			name:         "public without key",
+			method:       http.MethodPost,
+			path:         "/api/v1/shorten",
+			expectedCode: http.StatusOK,
+			mockBehavior: func(*commands_mocks.AuthenticateCommandHandlerMock) {},
+		},
+		{
+			name:         "public with invalid key",
+			method:       http.MethodPost,
+			path:         "/api/v1/shorten",
+			apiKey:       "usk_invalid",
+			expectedCode: http.StatusUnauthorized,
+			mockBehavior: func(m *commands_mocks.AuthenticateCommandHandlerMock) {
+				m.On("Handle", mock.Anything, commands.AuthenticateCommand{Key: "usk_invalid"}).
+					Return(auth.Principal{}, commands.ErrInvalidAPIKey).
+					Once()
+			},
+		},
+		{
+			name:         "internal",
+			method:       http.MethodGet,
+			path:         "/api/v1/admin/keys",
+			apiKey:       "usk_valid",
+			expectedCode: http.StatusInternalServerError,
+			mockBehavior: func(m *commands_mocks.AuthenticateCommandHandlerMock) {
+				m.On("Handle", mock.Anything, commands.AuthenticateCommand{Key: "usk_valid"}).
+					Return(auth.Principal{}, assert.AnError).
+					Once()
+			},
+		},
+		{
+			name:         "route not in spec",
+			method:       http.MethodGet,
+			path:         "/metrics",
+			apiKey:       "whatever",
+			expectedCode: http.StatusOK,
+			mockBehavior: func(*commands_mocks.AuthenticateCommandHandlerMock) {},
+		},
+	}
+
+	spec, err := servers.GetSwagger()
+	require.NoError(t, err)
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(tc.method, "/", nil)
+			if tc.apiKey != "" {
+				req.Header.Set(apiKeyHeader, tc.apiKey)
+			}
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetPath(tc.path)
+
+			m := commands_mocks.NewAuthenticateCommandHandlerMock(t)
+			tc.mockBehavior(m)
+
+			mw, err := NewAuthMiddleware(m, spec)
+			require.NoError(t, err)
+
+			var principal auth.Principal
+			err = mw(func(c echo.Context) error {
+				principal, _ = auth.PrincipalFromContext(c.Request().Context())
+				return c.NoContent(http.StatusOK)
+			})(ctx)
+
+			if err != nil {
+				var httpErr *echo.HTTPError
+				require.True(t, errors.As(err, &httpErr))
+				assert.Equal(t, tc.expectedCode, httpErr.Code)
+				return
+			}
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectedScope != "" {
+				assert.True(t, principal.HasScope(tc.expectedScope))
+			}
+		})
+	}
+}
+
+func TestServer_Authorize(t *testing.T) {
+	viewer := auth.Principal{Name: "viewer", Role: string(model.RoleViewer), Scopes: model.RoleViewer.Scopes()}
+
+	tt := []struct {
+		name         string
+		principal    *auth.Principal
+		scopes       []string
+		expectedCode int
+	}{
+		{
+			name:         "granted",
+			principal:    &viewer,
+			scopes:       []string{model.ScopeLinksReadStats},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "no scopes required",
+			principal:    &viewer,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "scope missing",
+			principal:    &viewer,
+			scopes:       []string{model.ScopeLinksDelete},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "admin granted everything",
+			principal:    &adminPrincipal,
+			scopes:       []string{model.ScopeAdminKeys, model.ScopeLinksDelete},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "anonymous",
+			scopes:       []string{model.ScopeLinksReadStats},
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctx := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+			setScopes(ctx, tc.scopes...)
+			if tc.principal != nil {
+				setPrincipal(ctx, *tc.principal)
+			}
+
+			s := &Server{authorizer: testAuthorizer(t)}
+
+			principal, err := s.authorize(ctx)
+
+			if tc.expectedCode != http.StatusOK {
+				var httpErr *echo.HTTPError
+				require.ErrorAs(t, err, &httpErr)
+				assert.Equal(t, tc.expectedCode, httpErr.Code)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.principal.Name, principal.Name)
+		})
+	}
+}
+
+func TestServer_AuthorizeOptional(t *testing.T) {
+	e := echo.New()
+	ctx := e.NewContext(httptest.NewRequest(http.MethodPost, "/", nil), httptest.NewRecorder())
+	setScopes(ctx, model.ScopeLinksCreate)
+
+	s := &Server{authorizer: testAuthorizer(t)}
+
+	// Anonymous requests are let through.
+	require.NoError(t, s.authorizeOptional(ctx))
+
+	setPrincipal(ctx, auth.Principal{Name: "viewer", Scopes: model.RoleViewer.Scopes()})
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, s.authorizeOptional(ctx), &httpErr)
+	assert.Equal(t, http.StatusForbidden, httpErr.Code)
+}
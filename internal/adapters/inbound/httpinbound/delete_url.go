@@ -0,0 +1,36 @@
+package httpinbound
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/commands"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/labstack/echo/v4"
+)
+
+// Deletes shortened url
+// (DELETE /api/v1/{token})
+
+func (s *Server) DeleteURL(ctx echo.Context, token string) error {
+	principal, err := s.authorize(ctx)
+	if err != nil {
+		return err
+	}
+
+	cmd, err := commands.NewDeleteURLCommand(principal, token)
+	if err != nil {
+		return newBadRequestError(err)
+	}
+
+	err = s.deleteURLCommandHandler.Handle(ctx.Request().Context(), cmd)
+	if err != nil {
+		if errors.Is(err, errs.ErrObjectNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "short url not found")
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,143 @@
+//nolint:nolintlint,exhaustruct,testpackage
+package httpinbound
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/commands"
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/auth"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	commands_mocks "github.com/dzhordano/urlshortener/mocks/core/application_mocks/commands"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_DeleteURL(t *testing.T) {
+	tt := []struct {
+		name         string
+		isAuthorized bool
+		reqShortURL  string
+		expectedCode int
+		expectErr    bool
+		mockBehavior func(m *commands_mocks.DeleteURLCommandHandlerMock, c commands.DeleteURLCommand)
+	}{
+		{
+			name:         "success",
+			isAuthorized: true,
+			reqShortURL:  "RAND000",
+			expectedCode: http.StatusNoContent,
+			expectErr:    false,
+			mockBehavior: func(m *commands_mocks.DeleteURLCommandHandlerMock, c commands.DeleteURLCommand) {
+				m.On("Handle", mock.Anything, c).
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			name:         "bad request",
+			isAuthorized: true,
+			reqShortURL:  "",
+			expectedCode: http.StatusBadRequest,
+			expectErr:    true,
+			mockBehavior: func(*commands_mocks.DeleteURLCommandHandlerMock, commands.DeleteURLCommand) {},
+		},
+		{
+			name:         "not found",
+			isAuthorized: true,
+			reqShortURL:  "RAND000",
+			expectedCode: http.StatusNotFound,
+			expectErr:    true,
+			mockBehavior: func(m *commands_mocks.DeleteURLCommandHandlerMock, c commands.DeleteURLCommand) {
+				m.On("Handle", mock.Anything, c).
+					Return(errs.NewObjectNotFoundError("shortenedURL", c.ShortURL)).
+					Once()
+			},
+		},
+		{
+			name:         "internal",
+			isAuthorized: true,
+			reqShortURL:  "RAND000",
+			expectedCode: http.StatusInternalServerError,
+			expectErr:    true,
+			mockBehavior: func(m *commands_mocks.DeleteURLCommandHandlerMock, c commands.DeleteURLCommand) {
+				m.On("Handle", mock.Anything, c).
+					Return(assert.AnError).
+					Once()
+			},
+		},
+		{
+			name:         "unauthorized",
+			isAuthorized: false,
+			reqShortURL:  "RAND000",
+			expectedCode: http.StatusUnauthorized,
+			expectErr:    true,
+			mockBehavior: func(*commands_mocks.DeleteURLCommandHandlerMock, commands.DeleteURLCommand) {},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(
+				http.MethodDelete,
+				fmt.Sprintf("/api/v1/%s", tc.reqShortURL),
+				nil,
+			)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			setScopes(ctx, model.ScopeLinksDelete)
+			if tc.isAuthorized {
+				setPrincipal(ctx, adminPrincipal)
+			}
+
+			m := commands_mocks.NewDeleteURLCommandHandlerMock(t)
+			c := commands.DeleteURLCommand{Principal: adminPrincipal, ShortURL: tc.reqShortURL}
+			tc.mockBehavior(m, c)
+
+			s := &Server{
+				authorizer:              testAuthorizer(t),
+				deleteURLCommandHandler: m,
+			}
+
+			err := s.DeleteURL(ctx, tc.reqShortURL)
+
+			if err != nil {
+				// Since echo.NewHTTPError() is used to return errors, cast error to echo.HTTPError
+				var httpErr *echo.HTTPError
+				if errors.As(err, &httpErr) {
+					assert.Equal(t, tc.expectedCode, httpErr.Code)
+				} else if tc.expectErr {
+					assert.Error(t, err)
+				}
+			} else {
+				assert.Equal(t, tc.expectedCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestServer_DeleteURL_ScopeMissing(t *testing.T) {
+	e := echo.New()
+	ctx := e.NewContext(httptest.NewRequest(http.MethodDelete, "/api/v1/RAND000", nil), httptest.NewRecorder())
+	setScopes(ctx, model.ScopeLinksDelete)
+	setPrincipal(ctx, auth.Principal{Name: "viewer", Role: string(model.RoleViewer), Scopes: model.RoleViewer.Scopes()})
+
+	// Use case must not be invoked.
+	m := commands_mocks.NewDeleteURLCommandHandlerMock(t)
+
+	s := &Server{
+		authorizer:              testAuthorizer(t),
+		deleteURLCommandHandler: m,
+	}
+
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, s.DeleteURL(ctx, "RAND000"), &httpErr)
+	assert.Equal(t, http.StatusForbidden, httpErr.Code)
+}
@@ -0,0 +1,69 @@
+package httpinbound
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
+	"github.com/labstack/echo/v4"
+)
+
+// Error codes returned in openapi Error schema.
+const (
+	errCodeConflict                = "conflict"
+	errCodeForbidden               = "forbidden"
+	errCodeInvalidValue            = "invalid_value"
+	errCodeInternal                = "internal"
+	errCodeUnauthorized            = "unauthorized"
+	errCodePasswordRequired        = "password_required"
+	errCodePasswordInvalid         = "password_invalid"
+	errCodeTooManyPasswordAttempts = "too_many_password_attempts"
+	errCodeClickLimitReached       = "click_limit_reached"
+	errCodeURLPending              = "url_pending"
+	errCodeTokenGenerationFailed   = "token_generation_failed"
+)
+
+// newHTTPError returns echo error whose body matches openapi Error schema.
+func newHTTPError(status int, code string, message string) *echo.HTTPError {
+	return echo.NewHTTPError(status, servers.Error{
+		Code:    code,
+		Message: message,
+	})
+}
+
+// newBadRequestError returns 400 echo error describing err. Invalid or missing request field is reported
+// in openapi Error schema's field.
+func newBadRequestError(err error) *echo.HTTPError {
+	return echo.NewHTTPError(http.StatusBadRequest, invalidValueErrorBody(err))
+}
+
+// invalidValueErrorBody describes invalid or missing value error in openapi Error schema.
+func invalidValueErrorBody(err error) servers.Error {
+	var (
+		invalidErr  *errs.ValueIsInvalidError
+		requiredErr *errs.ValueIsRequiredError
+		field       string
+		message     string
+	)
+
+	switch {
+	case errors.As(err, &invalidErr):
+		field = invalidErr.ParamName
+		message = field + " is invalid"
+		if invalidErr.Cause != nil {
+			message += ": " + invalidErr.Cause.Error()
+		}
+	case errors.As(err, &requiredErr):
+		field = requiredErr.ParamName
+		message = field + " is required"
+	default:
+		return servers.Error{Code: errCodeInvalidValue, Message: err.Error()}
+	}
+
+	return servers.Error{
+		Code:    errCodeInvalidValue,
+		Field:   &field,
+		Message: message,
+	}
+}
@@ -0,0 +1,52 @@
+package httpinbound
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/commands"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/labstack/echo/v4"
+)
+
+// maxTTLSeconds is the longest url lifetime in seconds time.Duration holds.
+const maxTTLSeconds = math.MaxInt64 / int64(time.Second)
+
+// newExpiration builds requested url expiration from request fields. Returned error is *echo.HTTPError.
+func newExpiration(
+	ctx echo.Context,
+	expiresAt *time.Time,
+	ttlSeconds *int64,
+	neverExpires *bool,
+) (commands.Expiration, error) {
+	never := neverExpires != nil && *neverExpires
+	if never && !isAdmin(ctx) {
+		return commands.Expiration{}, newHTTPError(
+			http.StatusForbidden,
+			errCodeForbidden,
+			"never expiring urls are for operators only",
+		)
+	}
+
+	var ttl *time.Duration
+	if ttlSeconds != nil {
+		// Longer lifetime overflows duration, which would make it negative or wrap around.
+		if *ttlSeconds > maxTTLSeconds {
+			return commands.Expiration{}, newBadRequestError(
+				errs.NewValueIsInvalidErrorWithCause("ttl", errors.New("is too long")),
+			)
+		}
+
+		d := time.Duration(*ttlSeconds) * time.Second
+		ttl = &d
+	}
+
+	expiration, err := commands.NewExpiration(expiresAt, ttl, never)
+	if err != nil {
+		return commands.Expiration{}, newBadRequestError(err)
+	}
+
+	return expiration, nil
+}
@@ -0,0 +1,65 @@
+//nolint:nolintlint,exhaustruct,testpackage
+package httpinbound
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewExpiration_TTL(t *testing.T) {
+	tests := []struct {
+		name    string
+		ttl     int64
+		wantTTL time.Duration
+		wantErr bool
+	}{
+		{
+			name:    "longest",
+			ttl:     maxTTLSeconds,
+			wantTTL: time.Duration(maxTTLSeconds) * time.Second,
+		},
+		{
+			name:    "overflowing",
+			ttl:     maxTTLSeconds + 1,
+			wantErr: true,
+		},
+		{
+			name:    "max int64",
+			ttl:     math.MaxInt64,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctx := e.NewContext(httptest.NewRequest(http.MethodPost, "/", nil), httptest.NewRecorder())
+
+			expiration, err := newExpiration(ctx, nil, &tc.ttl, nil)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				require.NotNil(t, expiration.TTL)
+				assert.Equal(t, tc.wantTTL, *expiration.TTL)
+				return
+			}
+
+			var httpErr *echo.HTTPError
+			require.True(t, errors.As(err, &httpErr))
+			assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+			body, ok := httpErr.Message.(servers.Error)
+			require.True(t, ok)
+			require.NotNil(t, body.Field)
+			assert.Equal(t, "ttl", *body.Field)
+		})
+	}
+}
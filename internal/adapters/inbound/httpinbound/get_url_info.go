@@ -13,13 +13,12 @@ import (
 // (GET /api/v1/{token}/info)
 
 func (s *Server) GetShortenedURLInfo(ctx echo.Context, token string) error {
-	apiKey := ctx.Request().Header.Get("X-Api-Key")
-	// TODO are YOU an admin?
-	if apiKey != "admin" {
-		return echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
+	principal, err := s.authorize(ctx)
+	if err != nil {
+		return err
 	}
 
-	q, err := queries.NewGetURLInfoQuery(token)
+	q, err := queries.NewGetURLInfoQuery(principal, token)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
@@ -32,5 +31,5 @@ func (s *Server) GetShortenedURLInfo(ctx echo.Context, token string) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	return ctx.JSON(http.StatusOK, resp)
+	return ctx.JSON(http.StatusOK, toURLResponse(resp))
 }
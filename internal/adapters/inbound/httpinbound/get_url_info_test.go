@@ -2,6 +2,7 @@
 package httpinbound
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -10,13 +11,17 @@ import (
 	"time"
 
 	"github.com/dzhordano/urlshortener/internal/core/application/usecases/queries"
+	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
 	queries_mocks "github.com/dzhordano/urlshortener/mocks/core/application_mocks/queries"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestServer_GetShortenedURLInfo(t *testing.T) {
+	uniqueVisitors := 3
+
 	tt := []struct {
 		name string
 		// This is not good, however, i can afford that
@@ -35,11 +40,12 @@ func TestServer_GetShortenedURLInfo(t *testing.T) {
 			mockBehavior: func(m *queries_mocks.GetURLInfoQueryHandlerMock, q queries.GetURLInfoQuery) {
 				m.On("Handle", mock.Anything, q).
 					Return(queries.GetURLInfoResponse{
-						OriginalURL:   "",
-						ShortURL:      "",
-						Clicks:        0,
-						CreatedAtUTC:  time.Time{},
-						ValidUntilUTC: time.Time{},
+						OriginalURL:    "",
+						ShortURL:       "",
+						Clicks:         0,
+						CreatedAtUTC:   time.Time{},
+						ValidUntilUTC:  nil,
+						UniqueVisitors: &uniqueVisitors,
 					}, nil).
 					Once()
 			},
@@ -85,14 +91,15 @@ func TestServer_GetShortenedURLInfo(t *testing.T) {
 			rec := httptest.NewRecorder()
 			ctx := e.NewContext(req, rec)
 			if tc.isAuthorized {
-				ctx.Request().Header.Set("X-Api-Key", "admin")
+				setPrincipal(ctx, adminPrincipal)
 			}
 
 			m := queries_mocks.NewGetURLInfoQueryHandlerMock(t)
-			q := queries.GetURLInfoQuery{ShortURL: tc.reqShortURL}
+			q := queries.GetURLInfoQuery{Principal: adminPrincipal, ShortURL: tc.reqShortURL}
 			tc.mockBehavior(m, q)
 
 			s := &Server{
+				authorizer:               testAuthorizer(t),
 				shortenURLCommandHandler: nil,
 				redirectQueryHandler:     nil,
 				getURLInfoQueryHandler:   m,
@@ -110,6 +117,12 @@ func TestServer_GetShortenedURLInfo(t *testing.T) {
 				}
 			} else {
 				assert.Equal(t, tc.expectedCode, rec.Code)
+
+				var url servers.URL
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &url))
+				if assert.NotNil(t, url.UniqueVisitors) {
+					assert.Equal(t, uniqueVisitors, *url.UniqueVisitors)
+				}
 			}
 		})
 	}
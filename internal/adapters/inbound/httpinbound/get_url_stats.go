@@ -0,0 +1,69 @@
+package httpinbound
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/queries"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
+	"github.com/labstack/echo/v4"
+)
+
+// Returns click statistics of shortened url
+// (GET /api/v1/{token}/stats)
+
+func (s *Server) GetShortenedURLStats(ctx echo.Context, token string, params servers.GetShortenedURLStatsParams) error {
+	principal, err := s.authorize(ctx)
+	if err != nil {
+		return err
+	}
+
+	var interval string
+	if params.Interval != nil {
+		interval = string(*params.Interval)
+	}
+
+	q, err := queries.NewGetURLStatsQuery(principal, token, params.From, params.To, interval)
+	if err != nil {
+		return newBadRequestError(err)
+	}
+
+	resp, err := s.getURLStatsQueryHandler.Handle(ctx.Request().Context(), q)
+	if err != nil {
+		if errors.Is(err, errs.ErrObjectNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "short url not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+	}
+
+	stats := servers.URLStats{
+		ShortUrl:       resp.ShortURL,
+		From:           resp.FromUTC,
+		To:             resp.ToUTC,
+		Interval:       string(resp.Interval),
+		Clicks:         resp.Clicks,
+		UniqueVisitors: resp.UniqueVisitors,
+		Buckets:        make([]servers.ClicksBucket, 0, len(resp.Buckets)),
+		TopReferrers:   toStatsEntries(resp.TopReferrers),
+		TopCountries:   toStatsEntries(resp.TopCountries),
+		Devices:        toStatsEntries(resp.Devices),
+		Browsers:       toStatsEntries(resp.Browsers),
+		Os:             toStatsEntries(resp.OSs),
+	}
+	for _, b := range resp.Buckets {
+		stats.Buckets = append(stats.Buckets, servers.ClicksBucket{Start: b.StartUTC, Clicks: b.Clicks})
+	}
+
+	return ctx.JSON(http.StatusOK, stats)
+}
+
+// toStatsEntries maps stats entries to openapi schema. Nil becomes empty slice, so it's encoded as [].
+func toStatsEntries(entries []queries.StatsEntry) []servers.StatsEntry {
+	res := make([]servers.StatsEntry, 0, len(entries))
+	for _, e := range entries {
+		res = append(res, servers.StatsEntry{Value: e.Value, Clicks: e.Clicks})
+	}
+
+	return res
+}
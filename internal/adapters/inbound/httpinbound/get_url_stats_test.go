@@ -0,0 +1,167 @@
+//nolint:nolintlint,exhaustruct,testpackage
+package httpinbound
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/queries"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
+	queries_mocks "github.com/dzhordano/urlshortener/mocks/core/application_mocks/queries"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_GetShortenedURLStats(t *testing.T) {
+	from := time.Date(2026, 10, 1, 10, 30, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 1, 13, 0, 0, 0, time.UTC)
+	hour := servers.Hour
+	unknownInterval := servers.GetShortenedURLStatsParamsInterval("month")
+	longAgo := to.AddDate(-1, 0, 0)
+
+	tt := []struct {
+		name         string
+		isAuthorized bool
+		params       servers.GetShortenedURLStatsParams
+		expectedCode int
+		expectErr    bool
+		mockBehavior func(m *queries_mocks.GetURLStatsQueryHandlerMock)
+	}{
+		{
+			name:         "success",
+			isAuthorized: true,
+			params:       servers.GetShortenedURLStatsParams{From: &from, To: &to, Interval: &hour},
+			expectedCode: http.StatusOK,
+			expectErr:    false,
+			mockBehavior: func(m *queries_mocks.GetURLStatsQueryHandlerMock) {
+				m.On("Handle", mock.Anything, mock.MatchedBy(func(q queries.GetURLStatsQuery) bool {
+					return q.ShortURL == "SOMEURL" &&
+						q.FromUTC.Equal(from.Truncate(time.Hour)) &&
+						q.ToUTC.Equal(to) &&
+						q.Interval == queries.URLStatsIntervalHour
+				})).
+					Return(queries.GetURLStatsResponse{
+						ShortURL: "SOMEURL",
+						FromUTC:  from.Truncate(time.Hour),
+						ToUTC:    to,
+						Interval: queries.URLStatsIntervalHour,
+						Clicks:   3,
+						Buckets: []queries.ClicksBucket{
+							{StartUTC: from.Truncate(time.Hour), Clicks: 1},
+							{StartUTC: from.Truncate(time.Hour).Add(time.Hour), Clicks: 0},
+							{StartUTC: from.Truncate(time.Hour).Add(2 * time.Hour), Clicks: 2},
+						},
+						TopReferrers:   []queries.StatsEntry{{Value: "news.example.org", Clicks: 2}},
+						Devices:        []queries.StatsEntry{{Value: "mobile", Clicks: 3}},
+						UniqueVisitors: 2,
+					}, nil).
+					Once()
+			},
+		},
+		{
+			name:         "unknown interval",
+			isAuthorized: true,
+			params:       servers.GetShortenedURLStatsParams{Interval: &unknownInterval},
+			expectedCode: http.StatusBadRequest,
+			expectErr:    true,
+			mockBehavior: func(*queries_mocks.GetURLStatsQueryHandlerMock) {},
+		},
+		{
+			name:         "from after to",
+			isAuthorized: true,
+			params:       servers.GetShortenedURLStatsParams{From: &to, To: &from},
+			expectedCode: http.StatusBadRequest,
+			expectErr:    true,
+			mockBehavior: func(*queries_mocks.GetURLStatsQueryHandlerMock) {},
+		},
+		{
+			name:         "too many buckets",
+			isAuthorized: true,
+			params:       servers.GetShortenedURLStatsParams{From: &longAgo, To: &to, Interval: &hour},
+			expectedCode: http.StatusBadRequest,
+			expectErr:    true,
+			mockBehavior: func(*queries_mocks.GetURLStatsQueryHandlerMock) {},
+		},
+		{
+			name:         "not found",
+			isAuthorized: true,
+			expectedCode: http.StatusNotFound,
+			expectErr:    true,
+			mockBehavior: func(m *queries_mocks.GetURLStatsQueryHandlerMock) {
+				m.On("Handle", mock.Anything, mock.Anything).
+					Return(queries.GetURLStatsResponse{}, errs.NewObjectNotFoundError("short url", "SOMEURL")).
+					Once()
+			},
+		},
+		{
+			name:         "internal",
+			isAuthorized: true,
+			expectedCode: http.StatusInternalServerError,
+			expectErr:    true,
+			mockBehavior: func(m *queries_mocks.GetURLStatsQueryHandlerMock) {
+				m.On("Handle", mock.Anything, mock.Anything).
+					Return(queries.GetURLStatsResponse{}, assert.AnError).
+					Once()
+			},
+		},
+		{
+			name:         "unauthorized",
+			isAuthorized: false,
+			expectedCode: http.StatusUnauthorized,
+			expectErr:    true,
+			mockBehavior: func(*queries_mocks.GetURLStatsQueryHandlerMock) {},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/SOMEURL/stats", nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			if tc.isAuthorized {
+				setPrincipal(ctx, adminPrincipal)
+			}
+
+			m := queries_mocks.NewGetURLStatsQueryHandlerMock(t)
+			tc.mockBehavior(m)
+
+			s := &Server{
+				authorizer:              testAuthorizer(t),
+				getURLStatsQueryHandler: m,
+			}
+
+			err := s.GetShortenedURLStats(ctx, "SOMEURL", tc.params)
+
+			if err != nil {
+				// Since echo.NewHTTPError() is used to return errors, cast error to echo.HTTPError
+				var httpErr *echo.HTTPError
+				if errors.As(err, &httpErr) {
+					assert.Equal(t, tc.expectedCode, httpErr.Code)
+				} else if tc.expectErr {
+					assert.Error(t, err)
+				}
+				return
+			}
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+
+			var stats servers.URLStats
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &stats))
+			assert.Equal(t, 3, stats.Clicks)
+			assert.Equal(t, 2, stats.UniqueVisitors)
+			assert.Len(t, stats.Buckets, 3)
+			assert.Equal(t, []servers.StatsEntry{{Value: "news.example.org", Clicks: 2}}, stats.TopReferrers)
+			// Dimensions without values are encoded as empty arrays.
+			assert.NotNil(t, stats.TopCountries)
+			assert.Empty(t, stats.TopCountries)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package httpinbound
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/queries"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
+	"github.com/labstack/echo/v4"
+)
+
+// Lists shortened urls
+// (GET /api/v1/links)
+
+func (s *Server) ListLinks(ctx echo.Context, params servers.ListLinksParams) error {
+	principal, err := s.authorize(ctx)
+	if err != nil {
+		return err
+	}
+
+	filter := queries.ListURLsFilter{
+		CreatedFrom: params.CreatedFrom,
+		CreatedTo:   params.CreatedTo,
+	}
+	if params.Status != nil {
+		filter.State = queries.URLState(*params.Status)
+	}
+	if params.Host != nil {
+		filter.Host = *params.Host
+	}
+	if params.Tag != nil {
+		filter.Tag = *params.Tag
+	}
+	if params.Owner != nil {
+		if !isAdmin(ctx) {
+			return newHTTPError(http.StatusForbidden, errCodeForbidden, "filtering by owner is for admin only")
+		}
+		filter.OwnerID = params.Owner
+	}
+
+	var (
+		sortBy, order, cursor string
+		limit                 int
+	)
+	if params.SortBy != nil {
+		sortBy = string(*params.SortBy)
+	}
+	if params.Order != nil {
+		order = string(*params.Order)
+	}
+	if params.Limit != nil {
+		if *params.Limit < 1 {
+			return newBadRequestError(errs.NewValueIsInvalidError("limit"))
+		}
+		limit = *params.Limit
+	}
+	if params.Cursor != nil {
+		cursor = *params.Cursor
+	}
+
+	q, err := queries.NewListURLsQuery(principal, filter, sortBy, order, limit, cursor)
+	if err != nil {
+		return newBadRequestError(err)
+	}
+
+	resp, err := s.listURLsQueryHandler.Handle(ctx.Request().Context(), q)
+	if err != nil {
+		if errors.Is(err, errs.ErrValueIsInvalid) {
+			return newBadRequestError(err)
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+	}
+
+	page := servers.LinksPage{
+		Items:      make([]servers.URL, 0, len(resp.URLs)),
+		NextCursor: nil,
+	}
+	for _, url := range resp.URLs {
+		page.Items = append(page.Items, toURLResponse(url))
+	}
+	if resp.NextCursor != "" {
+		page.NextCursor = &resp.NextCursor
+	}
+
+	return ctx.JSON(http.StatusOK, page)
+}
+
+// toURLResponse maps url info to openapi URL schema.
+func toURLResponse(url queries.GetURLInfoResponse) servers.URL {
+	status := servers.URLStatus(url.Status)
+	redirectCode := servers.RedirectCode(url.RedirectCode)
+	tags := url.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	resp := servers.URL{
+		Clicks:            &url.Clicks,
+		BotClicks:         &url.BotClicks,
+		CreatedAtUtc:      &url.CreatedAtUTC,
+		OriginalUrl:       &url.OriginalURL,
+		ShortUrl:          &url.ShortURL,
+		Status:            &status,
+		Tags:              &tags,
+		Preview:           toLinkPreview(url.Preview),
+		RedirectCode:      &redirectCode,
+		PasswordProtected: &url.PasswordProtected,
+		MaxClicks:         url.MaxClicks,
+		UniqueVisitors:    url.UniqueVisitors,
+		ValidFromUtc:      url.ValidFromUTC,
+		ValidUntilUtc:     url.ValidUntilUTC,
+		Pending:           &url.Pending,
+		FallbackUrl:       nil,
+		Targeting:         toTargetingRules(url.TargetingRules),
+	}
+	if url.FallbackURL != "" {
+		resp.FallbackUrl = &url.FallbackURL
+	}
+
+	return resp
+}
@@ -0,0 +1,145 @@
+//nolint:nolintlint,exhaustruct,testpackage
+package httpinbound
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/queries"
+	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
+	queries_mocks "github.com/dzhordano/urlshortener/mocks/core/application_mocks/queries"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_ListLinks(t *testing.T) {
+	active := servers.ListLinksParamsStatusActive
+	unknownStatus := servers.ListLinksParamsStatus("unknown")
+	clicks := servers.Clicks
+	tooBigLimit := 1000
+	badCursor := "not a cursor"
+	tag := "Promo"
+
+	tt := []struct {
+		name         string
+		isAuthorized bool
+		params       servers.ListLinksParams
+		expectedCode int
+		expectErr    bool
+		mockBehavior func(m *queries_mocks.ListURLsQueryHandlerMock)
+	}{
+		{
+			name:         "success",
+			isAuthorized: true,
+			params:       servers.ListLinksParams{Status: &active, SortBy: &clicks, Tag: &tag},
+			expectedCode: http.StatusOK,
+			expectErr:    false,
+			mockBehavior: func(m *queries_mocks.ListURLsQueryHandlerMock) {
+				m.On("Handle", mock.Anything, mock.MatchedBy(func(q queries.ListURLsQuery) bool {
+					return q.Filter.State == queries.URLStateActive &&
+						q.Filter.Tag == "promo" &&
+						q.SortBy == queries.ListURLsSortByClicks &&
+						q.Desc &&
+						q.Limit == queries.ListURLsDefaultLimit
+				})).
+					Return(queries.ListURLsResponse{
+						URLs: []queries.GetURLInfoResponse{
+							{ShortURL: "RAND000", CreatedAtUTC: time.Now(), Status: "active"},
+						},
+						NextCursor: "next",
+					}, nil).
+					Once()
+			},
+		},
+		{
+			name:         "unknown status",
+			isAuthorized: true,
+			params:       servers.ListLinksParams{Status: &unknownStatus},
+			expectedCode: http.StatusBadRequest,
+			expectErr:    true,
+			mockBehavior: func(*queries_mocks.ListURLsQueryHandlerMock) {},
+		},
+		{
+			name:         "limit too big",
+			isAuthorized: true,
+			params:       servers.ListLinksParams{Limit: &tooBigLimit},
+			expectedCode: http.StatusBadRequest,
+			expectErr:    true,
+			mockBehavior: func(*queries_mocks.ListURLsQueryHandlerMock) {},
+		},
+		{
+			name:         "malformed cursor",
+			isAuthorized: true,
+			params:       servers.ListLinksParams{Cursor: &badCursor},
+			expectedCode: http.StatusBadRequest,
+			expectErr:    true,
+			mockBehavior: func(*queries_mocks.ListURLsQueryHandlerMock) {},
+		},
+		{
+			name:         "internal",
+			isAuthorized: true,
+			expectedCode: http.StatusInternalServerError,
+			expectErr:    true,
+			mockBehavior: func(m *queries_mocks.ListURLsQueryHandlerMock) {
+				m.On("Handle", mock.Anything, mock.Anything).
+					Return(queries.ListURLsResponse{}, assert.AnError).
+					Once()
+			},
+		},
+		{
+			name:         "unauthorized",
+			isAuthorized: false,
+			expectedCode: http.StatusUnauthorized,
+			expectErr:    true,
+			mockBehavior: func(*queries_mocks.ListURLsQueryHandlerMock) {},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/links", nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			if tc.isAuthorized {
+				setPrincipal(ctx, adminPrincipal)
+			}
+
+			m := queries_mocks.NewListURLsQueryHandlerMock(t)
+			tc.mockBehavior(m)
+
+			s := &Server{
+				authorizer:           testAuthorizer(t),
+				listURLsQueryHandler: m,
+			}
+
+			err := s.ListLinks(ctx, tc.params)
+
+			if err != nil {
+				// Since echo.NewHTTPError() is used to return errors, cast error to echo.HTTPError
+				var httpErr *echo.HTTPError
+				if errors.As(err, &httpErr) {
+					assert.Equal(t, tc.expectedCode, httpErr.Code)
+				} else if tc.expectErr {
+					assert.Error(t, err)
+				}
+				return
+			}
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+
+			var page servers.LinksPage
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+			assert.Len(t, page.Items, 1)
+			if assert.NotNil(t, page.NextCursor) {
+				assert.Equal(t, "next", *page.NextCursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package httpinbound
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/queries"
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
+	"github.com/labstack/echo/v4"
+)
+
+// Shows where short url leads without following it
+// (GET /api/v1/{token}/preview)
+
+func (s *Server) PreviewURL(ctx echo.Context, token string) error {
+	q, err := queries.NewPreviewURLQuery(token)
+	if err != nil {
+		return newBadRequestError(err)
+	}
+
+	resp, err := s.previewURLQueryHandler.Handle(ctx.Request().Context(), q)
+	if err != nil {
+		if errors.Is(err, errs.ErrObjectNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "short url not found")
+		}
+		if errors.Is(err, queries.ErrClickLimitReached) {
+			return newHTTPError(http.StatusGone, errCodeClickLimitReached, "short url reached its click limit")
+		}
+		if errors.Is(err, queries.ErrURLPending) {
+			return newHTTPError(http.StatusTooEarly, errCodeURLPending, "short url is not active yet")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+	}
+
+	if prefersJSON(ctx.Request().Header.Get(echo.HeaderAccept)) {
+		return ctx.JSON(http.StatusOK, toURLPreviewResponse(resp))
+	}
+
+	return renderURLPreviewPage(ctx, resp)
+}
+
+// toURLPreviewResponse maps preview to openapi URLPreview schema.
+func toURLPreviewResponse(resp queries.PreviewURLResponse) servers.URLPreview {
+	warnings := make([]servers.DestinationSafetyWarnings, 0, len(resp.Safety.Warnings))
+	for _, w := range resp.Safety.Warnings {
+		warnings = append(warnings, servers.DestinationSafetyWarnings(w))
+	}
+
+	return servers.URLPreview{
+		ShortUrl:      resp.ShortURL,
+		OriginalUrl:   resp.OriginalURL,
+		CreatedAtUtc:  resp.CreatedAtUTC,
+		ValidUntilUtc: resp.ValidUntilUTC,
+		Safety: servers.DestinationSafety{
+			Verdict:  servers.DestinationSafetyVerdict(resp.Safety.Verdict),
+			Warnings: warnings,
+		},
+		PasswordProtected: resp.PasswordProtected,
+		ClickLimited:      resp.ClickLimited,
+	}
+}
+
+// prefersJSON reports whether client ranks application/json above text/html in accept header.
+// HTML wins ties, so browsers and clients not caring get the page.
+func prefersJSON(accept string) bool {
+	var jsonQ, htmlQ float64
+
+	for part := range strings.SplitSeq(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+
+		switch mediaType {
+		case echo.MIMEApplicationJSON, "application/*":
+			jsonQ = max(jsonQ, q)
+		case echo.MIMETextHTML, "text/*":
+			htmlQ = max(htmlQ, q)
+		case "*/*":
+			jsonQ, htmlQ = max(jsonQ, q), max(htmlQ, q)
+		}
+	}
+
+	return jsonQ > htmlQ
+}
+
+//nolint:gochecknoglobals // Read only.
+var urlPreviewPageTemplate = template.Must(template.New("url_preview").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex">
+<title>Where {{.ShortURL}} leads</title>
+</head>
+<body>
+{{- if .PasswordProtected}}
+<h1>{{.ShortURL}} is password protected</h1>
+<p>Its destination is revealed to the ones knowing password only.</p>
+{{- else if .ClickLimited}}
+<h1>{{.ShortURL}} may be followed limited number of times</h1>
+<p>Its destination is revealed to the ones following it only.</p>
+{{- else}}
+<h1>{{.ShortURL}} leads to</h1>
+<p><a href="{{.OriginalURL}}" rel="noopener noreferrer nofollow">{{.OriginalURL}}</a></p>
+{{- end}}
+<dl>
+<dt>Created</dt>
+<dd>{{.CreatedAt}}</dd>
+<dt>Expires</dt>
+<dd>{{.ExpiresAt}}</dd>
+<dt>Safety</dt>
+<dd>{{.Verdict}}</dd>
+</dl>
+{{- if .Warnings}}
+<ul>
+{{- range .Warnings}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+<p>Safety verdict is guessed from the address alone, make sure you trust the destination before following it.</p>
+</body>
+</html>
+`))
+
+// renderURLPreviewPage responds with human readable preview.
+func renderURLPreviewPage(ctx echo.Context, resp queries.PreviewURLResponse) error {
+	expiresAt := "never"
+	if resp.ValidUntilUTC != nil {
+		expiresAt = resp.ValidUntilUTC.Format(time.RFC1123)
+	}
+
+	warnings := make([]string, 0, len(resp.Safety.Warnings))
+	for _, w := range resp.Safety.Warnings {
+		warnings = append(warnings, safetyWarningText(w))
+	}
+
+	var buf bytes.Buffer
+	err := urlPreviewPageTemplate.Execute(&buf, struct {
+		ShortURL          string
+		OriginalURL       string
+		CreatedAt         string
+		ExpiresAt         string
+		Verdict           string
+		Warnings          []string
+		PasswordProtected bool
+		ClickLimited      bool
+	}{
+		ShortURL:          resp.ShortURL,
+		OriginalURL:       resp.OriginalURL,
+		CreatedAt:         resp.CreatedAtUTC.Format(time.RFC1123),
+		ExpiresAt:         expiresAt,
+		Verdict:           string(resp.Safety.Verdict),
+		Warnings:          warnings,
+		PasswordProtected: resp.PasswordProtected,
+		ClickLimited:      resp.ClickLimited,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+	}
+
+	return ctx.HTMLBlob(http.StatusOK, buf.Bytes())
+}
+
+// safetyWarningText explains warning to human.
+func safetyWarningText(w model.SafetyWarning) string {
+	switch w {
+	case model.SafetyWarningInsecureScheme:
+		return "Connection to destination is not encrypted (http)."
+	case model.SafetyWarningIPHost:
+		return "Destination is addressed by ip instead of domain name."
+	case model.SafetyWarningPunycodeHost:
+		return "Domain name contains international characters, which may imitate a well-known one."
+	case model.SafetyWarningCredentials:
+		return "Address contains user info, which may hide the real host."
+	case model.SafetyWarningNonStandardPort:
+		return "Destination uses non-standard port."
+	default:
+		return string(w)
+	}
+}
@@ -0,0 +1,215 @@
+//nolint:nolintlint,exhaustruct,testpackage
+package httpinbound
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/queries"
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
+	queries_mocks "github.com/dzhordano/urlshortener/mocks/core/application_mocks/queries"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_PreviewURL(t *testing.T) {
+	created := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+	resp := queries.PreviewURLResponse{
+		ShortURL:     "RAND000",
+		OriginalURL:  "http://203.0.113.7/login?next=/",
+		CreatedAtUTC: created,
+		Safety: model.DestinationSafety{
+			Verdict:  model.SafetyVerdictCaution,
+			Warnings: []model.SafetyWarning{model.SafetyWarningInsecureScheme, model.SafetyWarningIPHost},
+		},
+	}
+
+	tt := []struct {
+		name         string
+		accept       string
+		expectedCode int
+		expectJSON   bool
+		mockBehavior func(m *queries_mocks.PreviewURLQueryHandlerMock)
+	}{
+		{
+			name:         "json",
+			accept:       "application/json",
+			expectedCode: http.StatusOK,
+			expectJSON:   true,
+			mockBehavior: func(m *queries_mocks.PreviewURLQueryHandlerMock) {
+				m.On("Handle", mock.Anything, queries.PreviewURLQuery{ShortURL: "RAND000"}).Return(resp, nil).Once()
+			},
+		},
+		{
+			name:         "html for browser",
+			accept:       "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+			expectedCode: http.StatusOK,
+			expectJSON:   false,
+			mockBehavior: func(m *queries_mocks.PreviewURLQueryHandlerMock) {
+				m.On("Handle", mock.Anything, queries.PreviewURLQuery{ShortURL: "RAND000"}).Return(resp, nil).Once()
+			},
+		},
+		{
+			name:         "html by default",
+			accept:       "",
+			expectedCode: http.StatusOK,
+			expectJSON:   false,
+			mockBehavior: func(m *queries_mocks.PreviewURLQueryHandlerMock) {
+				m.On("Handle", mock.Anything, queries.PreviewURLQuery{ShortURL: "RAND000"}).Return(resp, nil).Once()
+			},
+		},
+		{
+			name:         "not found",
+			accept:       "application/json",
+			expectedCode: http.StatusNotFound,
+			mockBehavior: func(m *queries_mocks.PreviewURLQueryHandlerMock) {
+				m.On("Handle", mock.Anything, mock.Anything).
+					Return(queries.PreviewURLResponse{}, errs.ErrObjectNotFound).
+					Once()
+			},
+		},
+		{
+			name:         "click limit reached",
+			accept:       "application/json",
+			expectedCode: http.StatusGone,
+			mockBehavior: func(m *queries_mocks.PreviewURLQueryHandlerMock) {
+				m.On("Handle", mock.Anything, mock.Anything).
+					Return(queries.PreviewURLResponse{}, queries.ErrClickLimitReached).
+					Once()
+			},
+		},
+		{
+			name:         "pending",
+			accept:       "application/json",
+			expectedCode: http.StatusTooEarly,
+			mockBehavior: func(m *queries_mocks.PreviewURLQueryHandlerMock) {
+				m.On("Handle", mock.Anything, mock.Anything).
+					Return(queries.PreviewURLResponse{}, queries.ErrURLPending).
+					Once()
+			},
+		},
+		{
+			name:         "internal",
+			accept:       "application/json",
+			expectedCode: http.StatusInternalServerError,
+			mockBehavior: func(m *queries_mocks.PreviewURLQueryHandlerMock) {
+				m.On("Handle", mock.Anything, mock.Anything).
+					Return(queries.PreviewURLResponse{}, assert.AnError).
+					Once()
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/RAND000/preview", nil)
+			if tc.accept != "" {
+				req.Header.Set(echo.HeaderAccept, tc.accept)
+			}
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+
+			m := queries_mocks.NewPreviewURLQueryHandlerMock(t)
+			tc.mockBehavior(m)
+
+			s := &Server{
+				previewURLQueryHandler: m,
+			}
+
+			err := s.PreviewURL(ctx, "RAND000")
+
+			if tc.expectedCode != http.StatusOK {
+				var httpErr *echo.HTTPError
+				require.True(t, errors.As(err, &httpErr))
+				assert.Equal(t, tc.expectedCode, httpErr.Code)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			if tc.expectJSON {
+				var body servers.URLPreview
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, resp.OriginalURL, body.OriginalUrl)
+				assert.Equal(t, created, body.CreatedAtUtc)
+				assert.Nil(t, body.ValidUntilUtc)
+				assert.Equal(t, servers.Caution, body.Safety.Verdict)
+				assert.Equal(t, []servers.DestinationSafetyWarnings{servers.InsecureScheme, servers.IpHost}, body.Safety.Warnings)
+				return
+			}
+
+			assert.Contains(t, rec.Header().Get(echo.HeaderContentType), "text/html")
+			body := rec.Body.String()
+			assert.Contains(t, body, `href="http://203.0.113.7/login?next=/"`)
+			assert.Contains(t, body, "<dd>never</dd>")
+			assert.Contains(t, body, "<dd>caution</dd>")
+			assert.Contains(t, body, "addressed by ip")
+		})
+	}
+}
+
+func TestServer_PreviewURL_PasswordProtected(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/RAND000/preview", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+
+	m := queries_mocks.NewPreviewURLQueryHandlerMock(t)
+	m.On("Handle", mock.Anything, queries.PreviewURLQuery{ShortURL: "RAND000"}).
+		Return(queries.PreviewURLResponse{
+			ShortURL:          "RAND000",
+			CreatedAtUTC:      time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC),
+			Safety:            model.DestinationSafety{Verdict: model.SafetyVerdictUnknown},
+			PasswordProtected: true,
+		}, nil).
+		Once()
+
+	s := &Server{
+		previewURLQueryHandler: m,
+	}
+
+	err := s.PreviewURL(ctx, "RAND000")
+
+	require.NoError(t, err)
+	body := rec.Body.String()
+	assert.Contains(t, body, "<h1>RAND000 is password protected</h1>")
+	assert.NotContains(t, body, "<a href=")
+}
+
+func TestServer_PreviewURL_ClickLimited(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/RAND000/preview", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+
+	m := queries_mocks.NewPreviewURLQueryHandlerMock(t)
+	m.On("Handle", mock.Anything, queries.PreviewURLQuery{ShortURL: "RAND000"}).
+		Return(queries.PreviewURLResponse{
+			ShortURL:     "RAND000",
+			CreatedAtUTC: time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC),
+			Safety:       model.DestinationSafety{Verdict: model.SafetyVerdictUnknown},
+			ClickLimited: true,
+		}, nil).
+		Once()
+
+	s := &Server{
+		previewURLQueryHandler: m,
+	}
+
+	err := s.PreviewURL(ctx, "RAND000")
+
+	require.NoError(t, err)
+	body := rec.Body.String()
+	assert.Contains(t, body, "<h1>RAND000 may be followed limited number of times</h1>")
+	assert.NotContains(t, body, "<a href=")
+}
@@ -1,31 +1,299 @@
 package httpinbound
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
+	"html/template"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/dzhordano/urlshortener/internal/core/application/usecases/queries"
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
 	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
 	"github.com/labstack/echo/v4"
 )
 
 // Redirect to original url using provided token (using short url)
 // (GET /api/v1/{token})
 
-func (s *Server) Redirect(ctx echo.Context, token string) error {
-	q, err := queries.NewRedirectQuery(token)
+func (s *Server) Redirect(ctx echo.Context, token string, params servers.RedirectParams) error {
+	// Clients giving password in header are api clients, they get json errors instead of password form.
+	if params.XLinkPassword != nil {
+		return s.redirect(ctx, token, *params.XLinkPassword, false)
+	}
+
+	return s.redirect(ctx, token, "", true)
+}
+
+// Redirect to original url of password protected url
+// (POST /api/v1/{token})
+
+func (s *Server) RedirectWithPassword(ctx echo.Context, token string) error {
+	var form servers.RedirectWithPasswordFormdataRequestBody
+	if err := ctx.Bind(&form); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	if form.Password == "" {
+		return newBadRequestError(errs.NewValueIsRequiredError("password"))
+	}
+
+	return s.redirect(ctx, token, form.Password, true)
+}
+
+// redirect redirects to original url of token. Password protected url is followed with password only,
+// missing or wrong password results in password form if passwordForm is set and client doesn't prefer json.
+func (s *Server) redirect(ctx echo.Context, token string, password string, passwordForm bool) error {
+	req := ctx.Request()
+	q, err := queries.NewRedirectQuery(token, queries.Visitor{
+		Method:         req.Method,
+		Referrer:       req.Referer(),
+		UserAgent:      req.UserAgent(),
+		IP:             ctx.RealIP(),
+		Accept:         req.Header.Get("Accept"),
+		AcceptLanguage: req.Header.Get("Accept-Language"),
+		PlatformHint:   req.Header.Get(headerPlatformHint),
+		MobileHint:     req.Header.Get(headerMobileHint),
+	}, password)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
 	resp, err := s.redirectQueryHandler.Handle(ctx.Request().Context(), q)
 	if err != nil {
-		if errors.Is(err, errs.ErrObjectNotFound) {
+		switch {
+		case errors.Is(err, errs.ErrObjectNotFound):
 			return echo.NewHTTPError(http.StatusNotFound, "not found")
+		case errors.Is(err, queries.ErrClickLimitReached):
+			return newHTTPError(http.StatusGone, errCodeClickLimitReached, "short url reached its click limit")
+		case errors.Is(err, queries.ErrURLPending):
+			return newHTTPError(http.StatusTooEarly, errCodeURLPending, "short url is not active yet")
+		case errors.Is(err, queries.ErrPasswordRequired),
+			errors.Is(err, queries.ErrPasswordInvalid),
+			errors.Is(err, queries.ErrTooManyPasswordAttempts):
+			if passwordForm && !prefersJSON(req.Header.Get(echo.HeaderAccept)) {
+				return renderPasswordForm(ctx, token, err)
+			}
+			return newPasswordError(err)
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
 		}
+	}
+
+	// Shared caches must not hand visitor destination targeted at someone else's device, nor preview page meant
+	// for bots.
+	if vary := redirectVary(resp); vary != "" {
+		ctx.Response().Header().Set(echo.HeaderVary, vary)
+	}
+
+	ctx.Response().Header().Set(echo.HeaderCacheControl, redirectCacheControl(resp, time.Now()))
+
+	// Bots following url with click limit get no destination, the page carries just url's preview if it has one.
+	if resp.Preview != nil || (resp.ClickLimited && resp.OriginalURL == "") {
+		return renderOpenGraphPage(ctx, token, resp)
+	}
+
+	// Submitted password form is redirected with GET, 307 and 308 would resubmit password to original url.
+	if req.Method == http.MethodPost {
+		return ctx.Redirect(http.StatusSeeOther, resp.OriginalURL)
+	}
+
+	return ctx.Redirect(int(resp.RedirectCode), resp.OriginalURL)
+}
+
+// Client hints browsers send by default, which tell platform of the ones sending reduced user agent.
+const (
+	headerPlatformHint = "Sec-CH-UA-Platform"
+	headerMobileHint   = "Sec-CH-UA-Mobile"
+)
+
+// redirectVary returns Vary of redirect, listing request headers response depends on. Empty if there are none.
+// Destination of url with targeting rules depends on visitor's device. Whether url with preview is redirected
+// depends on telling bots apart, which is done by user agent and accepted content.
+func redirectVary(resp queries.RedirectResponse) string {
+	var headers []string
+	if resp.Targeted {
+		headers = append(headers, "User-Agent", headerPlatformHint, headerMobileHint)
+	}
+
+	if resp.Previewed {
+		if !resp.Targeted {
+			headers = append(headers, "User-Agent")
+		}
+		headers = append(headers, echo.HeaderAccept)
+	}
+
+	return strings.Join(headers, ", ")
+}
+
+// permanentRedirectMaxAge is how long clients may cache permanent redirects. It's bounded,
+// so changed destination is picked up by them eventually. Redirects of expiring urls are cached until
+// they expire at most.
+const permanentRedirectMaxAge = 24 * time.Hour
 
+// redirectCacheControl returns Cache-Control of redirect. Temporary redirects aren't cached,
+// so every visit reaches server and is counted. Redirects of password protected urls aren't cached either,
+// so they're never followed without password. Geo-targeted redirects are cached by browsers only, shared caches
+// would hand them to visitors from other countries. Redirects of urls with click limit aren't cached, since cached
+// redirects would be neither counted nor stopped once the limit is reached.
+func redirectCacheControl(resp queries.RedirectResponse, now time.Time) string {
+	if !resp.RedirectCode.IsPermanent() || resp.PasswordProtected || resp.ClickLimited {
+		return "no-store"
+	}
+
+	maxAge := permanentRedirectMaxAge
+	if resp.ValidUntilUTC != nil {
+		maxAge = min(maxAge, resp.ValidUntilUTC.Sub(now))
+	}
+
+	// Url expiring within a second isn't worth caching.
+	if maxAge < time.Second {
+		return "no-store"
+	}
+
+	scope := "public"
+	if resp.GeoTargeted {
+		scope = "private"
+	}
+
+	return fmt.Sprintf("%s, max-age=%d", scope, int(maxAge.Seconds()))
+}
+
+//nolint:gochecknoglobals // Read only.
+var openGraphPageTemplate = template.Must(template.New("open_graph").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<meta property="og:type" content="website">
+<meta property="og:url" content="{{.URL}}">
+<meta property="og:title" content="{{.Title}}">
+{{- if .Description}}
+<meta property="og:description" content="{{.Description}}">
+{{- end}}
+{{- if .ImageURL}}
+<meta property="og:image" content="{{.ImageURL}}">
+{{- end}}
+{{- if .OriginalURL}}
+<meta http-equiv="refresh" content="0; url={{.OriginalURL}}">
+{{- end}}
+</head>
+<body>
+{{- if .OriginalURL}}
+<a href="{{.OriginalURL}}">{{.Title}}</a>
+{{- else}}
+{{.Title}}
+{{- end}}
+</body>
+</html>
+`))
+
+// renderOpenGraphPage responds with page carrying url's Open Graph preview, which redirects to original url
+// those following it. Page of url without preview is titled with its token, page without original url
+// redirects nowhere.
+func renderOpenGraphPage(ctx echo.Context, token string, resp queries.RedirectResponse) error {
+	req := ctx.Request()
+
+	var preview model.LinkPreview
+	if resp.Preview != nil {
+		preview = *resp.Preview
+	} else {
+		preview.Title = token
+	}
+
+	var buf bytes.Buffer
+	err := openGraphPageTemplate.Execute(&buf, struct {
+		URL         string
+		OriginalURL string
+		Title       string
+		Description string
+		ImageURL    string
+	}{
+		URL:         ctx.Scheme() + "://" + req.Host + req.URL.Path,
+		OriginalURL: resp.OriginalURL,
+		Title:       preview.Title,
+		Description: preview.Description,
+		ImageURL:    preview.ImageURL,
+	})
+	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
 	}
 
-	return ctx.Redirect(http.StatusMovedPermanently, resp.OriginalURL)
+	return ctx.HTMLBlob(http.StatusOK, buf.Bytes())
+}
+
+// Redirect to original url using provided token for link checkers
+// (HEAD /api/v1/{token})
+
+func (s *Server) RedirectHead(ctx echo.Context, token string) error {
+	return s.redirect(ctx, token, "", false)
+}
+
+// newPasswordError returns echo error describing err of following password protected url.
+func newPasswordError(err error) *echo.HTTPError {
+	switch {
+	case errors.Is(err, queries.ErrTooManyPasswordAttempts):
+		return newHTTPError(
+			http.StatusTooManyRequests,
+			errCodeTooManyPasswordAttempts,
+			"too many wrong passwords, try again later",
+		)
+	case errors.Is(err, queries.ErrPasswordInvalid):
+		return newHTTPError(http.StatusUnauthorized, errCodePasswordInvalid, "invalid password")
+	default:
+		return newHTTPError(http.StatusUnauthorized, errCodePasswordRequired, "password required")
+	}
+}
+
+//nolint:gochecknoglobals // Read only.
+var passwordFormTemplate = template.Must(template.New("password_form").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex">
+<title>{{.ShortURL}} is password protected</title>
+</head>
+<body>
+<h1>{{.ShortURL}} is password protected</h1>
+{{- if .Message}}
+<p>{{.Message}}</p>
+{{- end}}
+<form method="post">
+<label for="password">Password</label>
+<input type="password" id="password" name="password" required autofocus>
+<button type="submit">Continue</button>
+</form>
+</body>
+</html>
+`))
+
+// renderPasswordForm responds with form asking password of password protected url. Form is submitted to
+// RedirectWithPassword. Status and message shown above form are told by err.
+func renderPasswordForm(ctx echo.Context, token string, err error) error {
+	status, message := http.StatusUnauthorized, ""
+	switch {
+	case errors.Is(err, queries.ErrTooManyPasswordAttempts):
+		status, message = http.StatusTooManyRequests, "Too many wrong passwords, try again later."
+	case errors.Is(err, queries.ErrPasswordInvalid):
+		message = "Wrong password, try again."
+	}
+
+	var buf bytes.Buffer
+	err = passwordFormTemplate.Execute(&buf, struct {
+		ShortURL string
+		Message  string
+	}{
+		ShortURL: token,
+		Message:  message,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+	}
+
+	ctx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+
+	return ctx.HTMLBlob(status, buf.Bytes())
 }
@@ -6,14 +6,20 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/dzhordano/urlshortener/internal/core/application/usecases/queries"
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
 	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
 	queries_mocks "github.com/dzhordano/urlshortener/mocks/core/application_mocks/queries"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestServer_Redirect(t *testing.T) {
@@ -31,7 +37,7 @@ func TestServer_Redirect(t *testing.T) {
 			expectErr:    false,
 			mockBehavior: func(m *queries_mocks.RedirectQueryHandlerMock, q queries.RedirectQuery) {
 				m.On("Handle", mock.Anything, q).
-					Return(queries.RedirectResponse{}, nil).
+					Return(queries.RedirectResponse{RedirectCode: model.RedirectCodeMovedPermanently}, nil).
 					Once()
 			},
 		},
@@ -53,6 +59,42 @@ func TestServer_Redirect(t *testing.T) {
 					Once()
 			},
 		},
+		{
+			name:         "click limit reached",
+			reqShortURL:  "USEDUP0",
+			expectedCode: http.StatusGone,
+			expectErr:    true,
+			mockBehavior: func(m *queries_mocks.RedirectQueryHandlerMock, q queries.RedirectQuery) {
+				m.On("Handle", mock.Anything, q).
+					Return(queries.RedirectResponse{}, queries.ErrClickLimitReached).
+					Once()
+			},
+		},
+		{
+			name:         "pending",
+			reqShortURL:  "SOON000",
+			expectedCode: http.StatusTooEarly,
+			expectErr:    true,
+			mockBehavior: func(m *queries_mocks.RedirectQueryHandlerMock, q queries.RedirectQuery) {
+				m.On("Handle", mock.Anything, q).
+					Return(queries.RedirectResponse{}, queries.ErrURLPending).
+					Once()
+			},
+		},
+		{
+			name:         "pending with fallback",
+			reqShortURL:  "SOON001",
+			expectedCode: http.StatusFound,
+			expectErr:    false,
+			mockBehavior: func(m *queries_mocks.RedirectQueryHandlerMock, q queries.RedirectQuery) {
+				m.On("Handle", mock.Anything, q).
+					Return(queries.RedirectResponse{
+						OriginalURL:  "https://example.com/soon",
+						RedirectCode: model.RedirectCodeFound,
+					}, nil).
+					Once()
+			},
+		},
 		{
 			name:         "not found",
 			reqShortURL:  "NOTFND0",
@@ -74,18 +116,31 @@ func TestServer_Redirect(t *testing.T) {
 				fmt.Sprintf("/api/v1/%s", tc.reqShortURL),
 				nil,
 			)
+			req.Header.Set("Referer", "https://example.org/post")
+			req.Header.Set("User-Agent", "Mozilla/5.0")
+			req.Header.Set("Accept", "text/html")
+			req.Header.Set("Accept-Language", "en-US")
+			req.Header.Set(echo.HeaderXRealIP, "203.0.113.7")
 			rec := httptest.NewRecorder()
 			ctx := e.NewContext(req, rec)
 
 			m := queries_mocks.NewRedirectQueryHandlerMock(t)
-			q := queries.RedirectQuery{ShortURL: tc.reqShortURL}
+			// Visitor is taken from request as is, it's anonymized by query handler.
+			q := queries.RedirectQuery{ShortURL: tc.reqShortURL, Visitor: queries.Visitor{
+				Method:         http.MethodGet,
+				Referrer:       "https://example.org/post",
+				UserAgent:      "Mozilla/5.0",
+				IP:             "203.0.113.7",
+				Accept:         "text/html",
+				AcceptLanguage: "en-US",
+			}}
 			tc.mockBehavior(m, q)
 
 			s := &Server{
 				redirectQueryHandler: m,
 			}
 
-			err := s.Redirect(ctx, tc.reqShortURL)
+			err := s.Redirect(ctx, tc.reqShortURL, servers.RedirectParams{})
 
 			if err != nil {
 				// Since echo.NewHTTPError() is used to return errors, cast error to echo.HTTPError
@@ -101,3 +156,477 @@ func TestServer_Redirect(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_RedirectHead(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodHead, "/api/v1/RAND000", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+
+	m := queries_mocks.NewRedirectQueryHandlerMock(t)
+	// HEAD requests are passed as is, query handler counts them as bot clicks.
+	m.On("Handle", mock.Anything, mock.MatchedBy(func(q queries.RedirectQuery) bool {
+		return q.ShortURL == "RAND000" && q.Visitor.Method == http.MethodHead
+	})).
+		Return(queries.RedirectResponse{
+			OriginalURL:  "https://example.com",
+			RedirectCode: model.RedirectCodeMovedPermanently,
+		}, nil).
+		Once()
+
+	s := &Server{
+		redirectQueryHandler: m,
+	}
+
+	err := s.RedirectHead(ctx, "RAND000")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+	assert.Equal(t, "https://example.com", rec.Header().Get(echo.HeaderLocation))
+}
+
+func TestServer_RedirectTargeted(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/RAND000", nil)
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Linux; Android 10; K) Chrome/130.0 Mobile Safari/537.36")
+	req.Header.Set("Sec-CH-UA-Platform", `"Android"`)
+	req.Header.Set("Sec-CH-UA-Mobile", "?1")
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+
+	m := queries_mocks.NewRedirectQueryHandlerMock(t)
+	m.On("Handle", mock.Anything, mock.MatchedBy(func(q queries.RedirectQuery) bool {
+		return q.Visitor.PlatformHint == `"Android"` && q.Visitor.MobileHint == "?1"
+	})).
+		Return(queries.RedirectResponse{
+			OriginalURL:  "https://play.google.com/store/apps/details?id=app",
+			RedirectCode: model.RedirectCodeMovedPermanently,
+			Targeted:     true,
+		}, nil).
+		Once()
+
+	s := &Server{
+		redirectQueryHandler: m,
+	}
+
+	err := s.Redirect(ctx, "RAND000", servers.RedirectParams{})
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+	assert.Equal(t, "https://play.google.com/store/apps/details?id=app", rec.Header().Get(echo.HeaderLocation))
+	assert.Equal(t, "User-Agent, Sec-CH-UA-Platform, Sec-CH-UA-Mobile", rec.Header().Get(echo.HeaderVary))
+}
+
+func TestServer_RedirectPreviewed(t *testing.T) {
+	tests := []struct {
+		name       string
+		userAgent  string
+		resp       queries.RedirectResponse
+		wantStatus int
+	}{
+		{
+			name:      "human",
+			userAgent: "Mozilla/5.0 (X11; Linux x86_64) Chrome/130.0 Safari/537.36",
+			resp: queries.RedirectResponse{
+				OriginalURL:  "https://example.com",
+				RedirectCode: model.RedirectCodeMovedPermanently,
+				Previewed:    true,
+			},
+			wantStatus: http.StatusMovedPermanently,
+		},
+		{
+			name:      "bot",
+			userAgent: "Slackbot-LinkExpanding 1.0",
+			resp: queries.RedirectResponse{
+				OriginalURL:  "https://example.com",
+				RedirectCode: model.RedirectCodeMovedPermanently,
+				Preview:      &model.LinkPreview{Title: "Example"},
+				Previewed:    true,
+			},
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/RAND000", nil)
+			req.Header.Set("User-Agent", tc.userAgent)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+
+			m := queries_mocks.NewRedirectQueryHandlerMock(t)
+			m.On("Handle", mock.Anything, mock.Anything).Return(tc.resp, nil).Once()
+
+			s := &Server{
+				redirectQueryHandler: m,
+			}
+
+			err := s.Redirect(ctx, "RAND000", servers.RedirectParams{})
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			// Both responses are cached publicly, so shared caches must tell bots apart from others.
+			assert.Equal(t, "User-Agent, Accept", rec.Header().Get(echo.HeaderVary))
+		})
+	}
+}
+
+func TestRedirectVary(t *testing.T) {
+	resp := queries.RedirectResponse{Targeted: true, Previewed: true}
+
+	assert.Equal(t, "User-Agent, Sec-CH-UA-Platform, Sec-CH-UA-Mobile, Accept", redirectVary(resp))
+	assert.Empty(t, redirectVary(queries.RedirectResponse{}))
+}
+
+func TestServer_RedirectGeoTargeted(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/RAND000", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+
+	m := queries_mocks.NewRedirectQueryHandlerMock(t)
+	m.On("Handle", mock.Anything, mock.Anything).
+		Return(queries.RedirectResponse{
+			OriginalURL:  "https://shop.example.de",
+			RedirectCode: model.RedirectCodeMovedPermanently,
+			Targeted:     true,
+			GeoTargeted:  true,
+		}, nil).
+		Once()
+
+	s := &Server{
+		redirectQueryHandler: m,
+	}
+
+	err := s.Redirect(ctx, "RAND000", servers.RedirectParams{})
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+	assert.Equal(t, "https://shop.example.de", rec.Header().Get(echo.HeaderLocation))
+	assert.Equal(t, "private, max-age=86400", rec.Header().Get(echo.HeaderCacheControl))
+}
+
+func TestServer_RedirectCode(t *testing.T) {
+	tt := []struct {
+		code         model.RedirectCode
+		cacheControl string
+	}{
+		{code: model.RedirectCodeMovedPermanently, cacheControl: "public, max-age=86400"},
+		{code: model.RedirectCodeFound, cacheControl: "no-store"},
+		{code: model.RedirectCodeTemporaryRedirect, cacheControl: "no-store"},
+		{code: model.RedirectCodePermanentRedirect, cacheControl: "public, max-age=86400"},
+	}
+
+	for _, tc := range tt {
+		t.Run(fmt.Sprint(tc.code), func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/RAND000", nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+
+			m := queries_mocks.NewRedirectQueryHandlerMock(t)
+			m.On("Handle", mock.Anything, mock.Anything).
+				Return(queries.RedirectResponse{OriginalURL: "https://example.com", RedirectCode: tc.code}, nil).
+				Once()
+
+			s := &Server{
+				redirectQueryHandler: m,
+			}
+
+			err := s.Redirect(ctx, "RAND000", servers.RedirectParams{})
+
+			require.NoError(t, err)
+			assert.Equal(t, int(tc.code), rec.Code)
+			assert.Equal(t, "https://example.com", rec.Header().Get(echo.HeaderLocation))
+			assert.Equal(t, tc.cacheControl, rec.Header().Get(echo.HeaderCacheControl))
+		})
+	}
+}
+
+func TestRedirectCacheControl(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+
+	tt := []struct {
+		name         string
+		validUntil   *time.Time
+		cacheControl string
+	}{
+		{name: "never expiring", validUntil: nil, cacheControl: "public, max-age=86400"},
+		{name: "expiring after max age", validUntil: at(48 * time.Hour), cacheControl: "public, max-age=86400"},
+		{name: "expiring within max age", validUntil: at(90 * time.Minute), cacheControl: "public, max-age=5400"},
+		{name: "expiring right away", validUntil: at(500 * time.Millisecond), cacheControl: "no-store"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := queries.RedirectResponse{
+				OriginalURL:   "https://example.com",
+				RedirectCode:  model.RedirectCodeMovedPermanently,
+				ValidUntilUTC: tc.validUntil,
+			}
+
+			assert.Equal(t, tc.cacheControl, redirectCacheControl(resp, now))
+		})
+	}
+	// Redirect of url with click limit isn't cached whatever its code, so every click is counted.
+	resp := queries.RedirectResponse{RedirectCode: model.RedirectCodeMovedPermanently, ClickLimited: true}
+	assert.Equal(t, "no-store", redirectCacheControl(resp, now))
+}
+
+func TestServer_RedirectClickLimitedBot(t *testing.T) {
+	for _, preview := range []*model.LinkPreview{nil, {Title: "Spring sale"}} {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/RAND000", nil)
+		req.Header.Set("User-Agent", "curl/8.0")
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		m := queries_mocks.NewRedirectQueryHandlerMock(t)
+		m.On("Handle", mock.Anything, mock.Anything).
+			Return(queries.RedirectResponse{
+				RedirectCode: model.RedirectCodeMovedPermanently,
+				Preview:      preview,
+				ClickLimited: true,
+			}, nil).
+			Once()
+
+		s := &Server{
+			redirectQueryHandler: m,
+		}
+
+		err := s.Redirect(ctx, "RAND000", servers.RedirectParams{})
+
+		// Bot gets page leading nowhere, which is never cached.
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get(echo.HeaderLocation))
+		assert.Equal(t, "no-store", rec.Header().Get(echo.HeaderCacheControl))
+		assert.NotContains(t, rec.Body.String(), "refresh")
+		assert.NotContains(t, rec.Body.String(), "href")
+	}
+}
+
+func TestServer_RedirectPreview(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/RAND000", nil)
+	req.Header.Set("User-Agent", "Slackbot-LinkExpanding 1.0")
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+
+	m := queries_mocks.NewRedirectQueryHandlerMock(t)
+	m.On("Handle", mock.Anything, mock.Anything).
+		Return(queries.RedirectResponse{
+			OriginalURL: "https://example.com/?a=1&b=2",
+			Preview: &model.LinkPreview{
+				Title:       `Spring "sale"`,
+				Description: "Up to 50% off",
+				ImageURL:    "https://cdn.example.com/sale.png",
+			},
+		}, nil).
+		Once()
+
+	s := &Server{
+		redirectQueryHandler: m,
+	}
+
+	err := s.Redirect(ctx, "RAND000", servers.RedirectParams{})
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), "text/html")
+	assert.Empty(t, rec.Header().Get(echo.HeaderLocation))
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `<meta property="og:title" content="Spring &#34;sale&#34;">`)
+	assert.Contains(t, body, `<meta property="og:description" content="Up to 50% off">`)
+	assert.Contains(t, body, `<meta property="og:image" content="https://cdn.example.com/sale.png">`)
+	assert.Contains(t, body, `<meta property="og:url" content="http://example.com/api/v1/RAND000">`)
+	assert.Contains(t, body, `<meta http-equiv="refresh" content="0; url=https://example.com/?a=1&amp;b=2">`)
+}
+
+func TestServer_RedirectPassword(t *testing.T) {
+	password := "s3cret!"
+
+	tt := []struct {
+		name         string
+		method       string
+		accept       string
+		header       *string
+		form         url.Values
+		queryErr     error
+		expectedCode int
+		// expectedErrCode is set if json error is expected instead of page.
+		expectedErrCode string
+		expectedBody    string
+	}{
+		{
+			name:         "form for browser",
+			method:       http.MethodGet,
+			accept:       "text/html",
+			queryErr:     queries.ErrPasswordRequired,
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `<form method="post">`,
+		},
+		{
+			name:            "json for client preferring it",
+			method:          http.MethodGet,
+			accept:          "application/json",
+			queryErr:        queries.ErrPasswordRequired,
+			expectedCode:    http.StatusUnauthorized,
+			expectedErrCode: errCodePasswordRequired,
+		},
+		{
+			name:            "wrong header password",
+			method:          http.MethodGet,
+			accept:          "text/html",
+			header:          &password,
+			queryErr:        queries.ErrPasswordInvalid,
+			expectedCode:    http.StatusUnauthorized,
+			expectedErrCode: errCodePasswordInvalid,
+		},
+		{
+			name:            "too many header attempts",
+			method:          http.MethodGet,
+			header:          &password,
+			queryErr:        queries.ErrTooManyPasswordAttempts,
+			expectedCode:    http.StatusTooManyRequests,
+			expectedErrCode: errCodeTooManyPasswordAttempts,
+		},
+		{
+			name:         "wrong form password",
+			method:       http.MethodPost,
+			accept:       "text/html",
+			form:         url.Values{"password": {password}},
+			queryErr:     queries.ErrPasswordInvalid,
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: "Wrong password",
+		},
+		{
+			name:         "too many form attempts",
+			method:       http.MethodPost,
+			accept:       "text/html",
+			form:         url.Values{"password": {password}},
+			queryErr:     queries.ErrTooManyPasswordAttempts,
+			expectedCode: http.StatusTooManyRequests,
+			expectedBody: "Too many wrong passwords",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			var body *strings.Reader
+			if tc.form != nil {
+				body = strings.NewReader(tc.form.Encode())
+			} else {
+				body = strings.NewReader("")
+			}
+			req := httptest.NewRequest(tc.method, "/api/v1/RAND000", body)
+			if tc.form != nil {
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+			}
+			if tc.accept != "" {
+				req.Header.Set(echo.HeaderAccept, tc.accept)
+			}
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+
+			expectedPassword := ""
+			if tc.header != nil || tc.form != nil {
+				expectedPassword = password
+			}
+
+			m := queries_mocks.NewRedirectQueryHandlerMock(t)
+			m.On("Handle", mock.Anything, mock.MatchedBy(func(q queries.RedirectQuery) bool {
+				return q.ShortURL == "RAND000" && q.Password == expectedPassword
+			})).
+				Return(queries.RedirectResponse{}, tc.queryErr).
+				Once()
+
+			s := &Server{
+				redirectQueryHandler: m,
+			}
+
+			var err error
+			if tc.method == http.MethodPost {
+				err = s.RedirectWithPassword(ctx, "RAND000")
+			} else {
+				err = s.Redirect(ctx, "RAND000", servers.RedirectParams{XLinkPassword: tc.header})
+			}
+
+			if tc.expectedErrCode != "" {
+				var httpErr *echo.HTTPError
+				require.True(t, errors.As(err, &httpErr))
+				assert.Equal(t, tc.expectedCode, httpErr.Code)
+				assert.Equal(t, tc.expectedErrCode, httpErr.Message.(servers.Error).Code)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Contains(t, rec.Header().Get(echo.HeaderContentType), "text/html")
+			assert.Equal(t, "no-store", rec.Header().Get(echo.HeaderCacheControl))
+			assert.Contains(t, rec.Body.String(), tc.expectedBody)
+			assert.Contains(t, rec.Body.String(), `<input type="password" id="password" name="password" required autofocus>`)
+		})
+	}
+}
+
+func TestServer_RedirectWithPassword(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/api/v1/RAND000",
+		strings.NewReader(url.Values{"password": {"s3cret!"}}.Encode()),
+	)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	req.Header.Set(echo.HeaderAccept, "text/html")
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+
+	m := queries_mocks.NewRedirectQueryHandlerMock(t)
+	m.On("Handle", mock.Anything, mock.MatchedBy(func(q queries.RedirectQuery) bool {
+		return q.ShortURL == "RAND000" && q.Password == "s3cret!" && q.Visitor.Method == http.MethodPost
+	})).
+		Return(queries.RedirectResponse{
+			OriginalURL:       "https://example.com",
+			RedirectCode:      model.RedirectCodePermanentRedirect,
+			PasswordProtected: true,
+		}, nil).
+		Once()
+
+	s := &Server{
+		redirectQueryHandler: m,
+	}
+
+	err := s.RedirectWithPassword(ctx, "RAND000")
+
+	require.NoError(t, err)
+	// Form is never redirected with 307 or 308, those would resubmit password to original url.
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "https://example.com", rec.Header().Get(echo.HeaderLocation))
+	assert.Equal(t, "no-store", rec.Header().Get(echo.HeaderCacheControl))
+}
+
+func TestServer_RedirectWithPassword_NoPassword(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/RAND000", strings.NewReader(""))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+
+	s := &Server{
+		redirectQueryHandler: queries_mocks.NewRedirectQueryHandlerMock(t),
+	}
+
+	err := s.RedirectWithPassword(ctx, "RAND000")
+
+	var httpErr *echo.HTTPError
+	require.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
@@ -1,40 +1,169 @@
 package httpinbound
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+
 	"github.com/dzhordano/urlshortener/internal/core/application/usecases/commands"
 	"github.com/dzhordano/urlshortener/internal/core/application/usecases/queries"
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/auth"
 	"github.com/dzhordano/urlshortener/internal/pkg/errs"
 	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
 )
 
 var _ servers.ServerInterface = (*Server)(nil)
 
 type Server struct {
-	shortenURLCommandHandler commands.ShortenURLCommandHandler
-	redirectQueryHandler     queries.RedirectQueryHandler
-	getURLInfoQueryHandler   queries.GetURLInfoQueryHandler
+	shortenURLCommandHandler       commands.ShortenURLCommandHandler
+	shortenURLsBatchCommandHandler commands.ShortenURLsBatchCommandHandler
+	updateURLCommandHandler        commands.UpdateURLCommandHandler
+	deleteURLCommandHandler        commands.DeleteURLCommandHandler
+	redirectQueryHandler           queries.RedirectQueryHandler
+	previewURLQueryHandler         queries.PreviewURLQueryHandler
+	getURLInfoQueryHandler         queries.GetURLInfoQueryHandler
+	getURLStatsQueryHandler        queries.GetURLStatsQueryHandler
+	listURLsQueryHandler           queries.ListURLsQueryHandler
+	createAPIKeyCommandHandler     commands.CreateAPIKeyCommandHandler
+	revokeAPIKeyCommandHandler     commands.RevokeAPIKeyCommandHandler
+	listAPIKeysQueryHandler        queries.ListAPIKeysQueryHandler
+	authorizer                     auth.Authorizer
 }
 
 func NewServer(
 	shortenURLCommandHandler commands.ShortenURLCommandHandler,
+	shortenURLsBatchCommandHandler commands.ShortenURLsBatchCommandHandler,
+	updateURLCommandHandler commands.UpdateURLCommandHandler,
+	deleteURLCommandHandler commands.DeleteURLCommandHandler,
 	redirectQueryHandler queries.RedirectQueryHandler,
+	previewURLQueryHandler queries.PreviewURLQueryHandler,
 	getURLInfoQueryHandler queries.GetURLInfoQueryHandler,
+	getURLStatsQueryHandler queries.GetURLStatsQueryHandler,
+	listURLsQueryHandler queries.ListURLsQueryHandler,
+	createAPIKeyCommandHandler commands.CreateAPIKeyCommandHandler,
+	revokeAPIKeyCommandHandler commands.RevokeAPIKeyCommandHandler,
+	listAPIKeysQueryHandler queries.ListAPIKeysQueryHandler,
+	authorizer auth.Authorizer,
 ) (*Server, error) {
 	if shortenURLCommandHandler == nil {
 		return nil, errs.NewValueIsRequiredError("shortenURLCommandHandler")
 	}
 
+	if shortenURLsBatchCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("shortenURLsBatchCommandHandler")
+	}
+
+	if updateURLCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("updateURLCommandHandler")
+	}
+
+	if deleteURLCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("deleteURLCommandHandler")
+	}
+
 	if redirectQueryHandler == nil {
 		return nil, errs.NewValueIsRequiredError("redirectQueryHandler")
 	}
 
+	if previewURLQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("previewURLQueryHandler")
+	}
+
 	if getURLInfoQueryHandler == nil {
 		return nil, errs.NewValueIsRequiredError("getURLInfoQueryHandler")
 	}
 
+	if getURLStatsQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getURLStatsQueryHandler")
+	}
+
+	if listURLsQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("listURLsQueryHandler")
+	}
+
+	if createAPIKeyCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("createAPIKeyCommandHandler")
+	}
+
+	if revokeAPIKeyCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("revokeAPIKeyCommandHandler")
+	}
+
+	if listAPIKeysQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("listAPIKeysQueryHandler")
+	}
+
+	if authorizer == nil {
+		return nil, errs.NewValueIsRequiredError("authorizer")
+	}
+
 	return &Server{
-		shortenURLCommandHandler: shortenURLCommandHandler,
-		redirectQueryHandler:     redirectQueryHandler,
-		getURLInfoQueryHandler:   getURLInfoQueryHandler,
+		shortenURLCommandHandler:       shortenURLCommandHandler,
+		shortenURLsBatchCommandHandler: shortenURLsBatchCommandHandler,
+		updateURLCommandHandler:        updateURLCommandHandler,
+		deleteURLCommandHandler:        deleteURLCommandHandler,
+		redirectQueryHandler:           redirectQueryHandler,
+		previewURLQueryHandler:         previewURLQueryHandler,
+		getURLInfoQueryHandler:         getURLInfoQueryHandler,
+		getURLStatsQueryHandler:        getURLStatsQueryHandler,
+		listURLsQueryHandler:           listURLsQueryHandler,
+		createAPIKeyCommandHandler:     createAPIKeyCommandHandler,
+		revokeAPIKeyCommandHandler:     revokeAPIKeyCommandHandler,
+		listAPIKeysQueryHandler:        listAPIKeysQueryHandler,
+		authorizer:                     authorizer,
 	}, nil
 }
+
+// isAdmin reports whether request is made by admin (operator).
+func isAdmin(ctx echo.Context) bool {
+	p, ok := auth.PrincipalFromContext(ctx.Request().Context())
+	return ok && p.HasScope(model.ScopeAdmin)
+}
+
+// ownerID returns id of api key request is authenticated with. Nil is returned for anonymous requests
+// and ones authenticated with bootstrap admin key, since such keys can't own urls.
+func ownerID(ctx echo.Context) *uuid.UUID {
+	p, ok := auth.PrincipalFromContext(ctx.Request().Context())
+	if !ok || p.KeyID == uuid.Nil {
+		return nil
+	}
+
+	return &p.KeyID
+}
+
+// authorize checks request is made by principal granted scopes openapi spec requires for operation.
+// Returned error is 401 echo error for anonymous requests and 403 one for principals lacking scopes.
+func (s *Server) authorize(ctx echo.Context) (auth.Principal, error) {
+	scopes, _ := ctx.Get(servers.ApiKeyAuthScopes).([]string)
+
+	p, err := s.authorizer.Authorize(ctx.Request().Context(), scopes...)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			return auth.Principal{}, newHTTPError(http.StatusUnauthorized, errCodeUnauthorized, "api key is required")
+		case errors.Is(err, auth.ErrForbidden):
+			return auth.Principal{}, newHTTPError(
+				http.StatusForbidden,
+				errCodeForbidden,
+				fmt.Sprintf("api key is not granted required scopes %v", scopes),
+			)
+		default:
+			return auth.Principal{}, echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+		}
+	}
+
+	return p, nil
+}
+
+// authorizeOptional is authorize for operations allowing anonymous requests, those are let through.
+func (s *Server) authorizeOptional(ctx echo.Context) error {
+	if _, ok := auth.PrincipalFromContext(ctx.Request().Context()); !ok {
+		return nil
+	}
+
+	_, err := s.authorize(ctx)
+	return err
+}
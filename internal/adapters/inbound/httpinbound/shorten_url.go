@@ -1,9 +1,12 @@
 package httpinbound
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/dzhordano/urlshortener/internal/core/application/usecases/commands"
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
 	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
 	"github.com/labstack/echo/v4"
 )
@@ -12,22 +15,180 @@ import (
 // (POST /api/v1/shorten)
 
 func (s *Server) ShortenURL(ctx echo.Context) error {
-	var req servers.ShortenURLJSONBody
+	if err := s.authorizeOptional(ctx); err != nil {
+		return err
+	}
+
+	var req servers.ShortenRequest
 	if err := ctx.Bind(&req); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
 	}
 
-	cmd, err := commands.NewShortenURLCommand(req.Url)
+	cmd, err := newShortenURLCommand(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.shortenURLCommandHandler.Handle(ctx.Request().Context(), cmd)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		switch {
+		case errors.Is(err, errs.ErrObjectAlreadyExists):
+			return newHTTPError(http.StatusConflict, errCodeConflict, "alias is already taken")
+		case errors.Is(err, errs.ErrValueIsInvalid):
+			return newBadRequestError(err)
+		case errors.Is(err, commands.ErrTokenGenerationExhausted):
+			return newHTTPError(http.StatusServiceUnavailable, errCodeTokenGenerationFailed,
+				"could not generate unique short url, try again")
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, servers.ShortenResponse{
+		ShortUrl: &res.ShortURL,
+		Created:  &res.Created,
+	})
+}
+
+// newShortenURLCommand builds command from request. Returned error is *echo.HTTPError.
+func newShortenURLCommand(ctx echo.Context, req servers.ShortenRequest) (commands.ShortenURLCommand, error) {
+	var alias string
+	if req.Alias != nil {
+		alias = *req.Alias
 	}
 
-	redirectToken, err := s.shortenURLCommandHandler.Handle(ctx.Request().Context(), cmd)
+	expiration, err := newExpiration(ctx, req.ExpiresAt, req.Ttl, req.NeverExpires)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+		return commands.ShortenURLCommand{}, err
+	}
+
+	reuseExisting := req.ReuseExisting != nil && *req.ReuseExisting
+
+	var tags []string
+	if req.Tags != nil {
+		tags = *req.Tags
+	}
+
+	var password string
+	if req.Password != nil {
+		password = *req.Password
 	}
 
-	return ctx.JSON(http.StatusOK, echo.Map{
-		"short_url": redirectToken,
+	var fallbackURL string
+	if req.FallbackUrl != nil {
+		fallbackURL = *req.FallbackUrl
+	}
+
+	cmd, err := commands.NewShortenURLCommand(commands.ShortenURLParams{
+		URL:           req.Url,
+		Alias:         alias,
+		Expiration:    expiration,
+		ReuseExisting: reuseExisting,
+		Tags:          tags,
+		RedirectCode:  (*int)(req.RedirectCode),
+		Preview:       fromLinkPreview(req.Preview),
+		Password:      password,
+		MaxClicks:     req.MaxClicks,
+		ValidFrom:     req.ValidFrom,
+		FallbackURL:   fallbackURL,
+		Targeting:     fromTargetingRules(req.Targeting),
+		OwnerID:       ownerID(ctx),
 	})
+	if err != nil {
+		return commands.ShortenURLCommand{}, newBadRequestError(err)
+	}
+
+	return cmd, nil
+}
+
+// fromTargetingRules maps openapi TargetingRule schemas to rules. Returned rules are not validated.
+func fromTargetingRules(rules *[]servers.TargetingRule) []model.TargetingRule {
+	if rules == nil {
+		return nil
+	}
+
+	targeting := make([]model.TargetingRule, 0, len(*rules))
+	for _, r := range *rules {
+		rule := model.TargetingRule{Country: "", OS: "", Device: "", TargetURL: r.Url}
+		if r.Country != nil {
+			rule.Country = *r.Country
+		}
+		if r.Os != nil {
+			rule.OS = string(*r.Os)
+		}
+		if r.Device != nil {
+			rule.Device = string(*r.Device)
+		}
+		targeting = append(targeting, rule)
+	}
+
+	return targeting
+}
+
+// toTargetingRules maps rules to openapi TargetingRule schemas. Nil is returned for no rules.
+func toTargetingRules(rules []model.TargetingRule) *[]servers.TargetingRule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	targeting := make([]servers.TargetingRule, 0, len(rules))
+	for _, r := range rules {
+		rule := servers.TargetingRule{Country: nil, Os: nil, Device: nil, Url: r.TargetURL}
+		if r.Country != "" {
+			rule.Country = &r.Country
+		}
+		if r.OS != "" {
+			os := servers.TargetingRuleOs(r.OS)
+			rule.Os = &os
+		}
+		if r.Device != "" {
+			device := servers.TargetingRuleDevice(r.Device)
+			rule.Device = &device
+		}
+		targeting = append(targeting, rule)
+	}
+
+	return &targeting
+}
+
+// fromLinkPreview maps openapi LinkPreview schema to preview. Returned preview is not validated.
+func fromLinkPreview(p *servers.LinkPreview) *model.LinkPreview {
+	if p == nil {
+		return nil
+	}
+
+	preview := &model.LinkPreview{
+		Title:       p.Title,
+		Description: "",
+		ImageURL:    "",
+	}
+	if p.Description != nil {
+		preview.Description = *p.Description
+	}
+	if p.ImageUrl != nil {
+		preview.ImageURL = *p.ImageUrl
+	}
+
+	return preview
+}
+
+// toLinkPreview maps preview to openapi LinkPreview schema. Nil preview is mapped to nil.
+func toLinkPreview(p *model.LinkPreview) *servers.LinkPreview {
+	if p == nil {
+		return nil
+	}
+
+	preview := &servers.LinkPreview{
+		Title:       p.Title,
+		Description: nil,
+		ImageUrl:    nil,
+	}
+	if p.Description != "" {
+		preview.Description = &p.Description
+	}
+	if p.ImageURL != "" {
+		preview.ImageUrl = &p.ImageURL
+	}
+
+	return preview
 }
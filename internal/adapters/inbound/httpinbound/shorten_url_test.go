@@ -5,11 +5,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/dzhordano/urlshortener/internal/core/application/usecases/commands"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
 	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
 	commands_mocks "github.com/dzhordano/urlshortener/mocks/core/application_mocks/commands"
 	"github.com/labstack/echo/v4"
@@ -21,6 +23,8 @@ func TestServer_ShortenURL(t *testing.T) {
 	tt := []struct {
 		name           string
 		reqOriginalURL string
+		reqAlias       string
+		reqReuse       bool
 		expectedCode   int
 		expectErr      bool
 		mockBehavior   func(m *commands_mocks.ShortenURLCommandHandlerMock, c commands.ShortenURLCommand)
@@ -32,10 +36,43 @@ func TestServer_ShortenURL(t *testing.T) {
 			expectErr:      false,
 			mockBehavior: func(m *commands_mocks.ShortenURLCommandHandlerMock, c commands.ShortenURLCommand) {
 				m.On("Handle", mock.Anything, c).
-					Return("SHORT00", nil).
+					Return(commands.ShortenURLResult{ShortURL: "SHORT00", Created: true}, nil).
 					Once()
 			},
 		},
+		{
+			name:           "success with alias",
+			reqOriginalURL: "https://google.com",
+			reqAlias:       "spring-sale",
+			expectedCode:   http.StatusOK,
+			expectErr:      false,
+			mockBehavior: func(m *commands_mocks.ShortenURLCommandHandlerMock, c commands.ShortenURLCommand) {
+				m.On("Handle", mock.Anything, c).
+					Return(commands.ShortenURLResult{ShortURL: "spring-sale", Created: true}, nil).
+					Once()
+			},
+		},
+		{
+			name:           "reuse existing",
+			reqOriginalURL: "https://google.com",
+			reqReuse:       true,
+			expectedCode:   http.StatusOK,
+			expectErr:      false,
+			mockBehavior: func(m *commands_mocks.ShortenURLCommandHandlerMock, c commands.ShortenURLCommand) {
+				m.On("Handle", mock.Anything, c).
+					Return(commands.ShortenURLResult{ShortURL: "SHORT00", Created: false}, nil).
+					Once()
+			},
+		},
+		{
+			name:           "reuse existing with alias",
+			reqOriginalURL: "https://google.com",
+			reqAlias:       "spring-sale",
+			reqReuse:       true,
+			expectedCode:   http.StatusBadRequest,
+			expectErr:      true,
+			mockBehavior:   func(*commands_mocks.ShortenURLCommandHandlerMock, commands.ShortenURLCommand) {},
+		},
 		{
 			name:           "bad request",
 			reqOriginalURL: "",
@@ -43,6 +80,44 @@ func TestServer_ShortenURL(t *testing.T) {
 			expectErr:      true,
 			mockBehavior:   func(*commands_mocks.ShortenURLCommandHandlerMock, commands.ShortenURLCommand) {},
 		},
+		{
+			name:           "unsupported scheme",
+			reqOriginalURL: "javascript:alert(1)",
+			expectedCode:   http.StatusBadRequest,
+			expectErr:      true,
+			mockBehavior:   func(*commands_mocks.ShortenURLCommandHandlerMock, commands.ShortenURLCommand) {},
+		},
+		{
+			name:           "reserved alias",
+			reqOriginalURL: "https://google.com",
+			reqAlias:       "info",
+			expectedCode:   http.StatusBadRequest,
+			expectErr:      true,
+			mockBehavior:   func(*commands_mocks.ShortenURLCommandHandlerMock, commands.ShortenURLCommand) {},
+		},
+		{
+			name:           "alias taken",
+			reqOriginalURL: "https://google.com",
+			reqAlias:       "spring-sale",
+			expectedCode:   http.StatusConflict,
+			expectErr:      true,
+			mockBehavior: func(m *commands_mocks.ShortenURLCommandHandlerMock, c commands.ShortenURLCommand) {
+				m.On("Handle", mock.Anything, c).
+					Return(commands.ShortenURLResult{}, errs.NewObjectAlreadyExistsError("shortURL", c.Alias)).
+					Once()
+			},
+		},
+		{
+			name:           "token generation exhausted",
+			reqOriginalURL: "https://google.com",
+			expectedCode:   http.StatusServiceUnavailable,
+			expectErr:      true,
+			mockBehavior: func(m *commands_mocks.ShortenURLCommandHandlerMock, c commands.ShortenURLCommand) {
+				m.On("Handle", mock.Anything, c).
+					Return(commands.ShortenURLResult{}, fmt.Errorf("%w: 5 attempts", commands.ErrTokenGenerationExhausted)).
+					Once()
+			},
+		},
 		{
 			name:           "internal",
 			reqOriginalURL: "https://google.com",
@@ -50,7 +125,7 @@ func TestServer_ShortenURL(t *testing.T) {
 			expectErr:      true,
 			mockBehavior: func(m *commands_mocks.ShortenURLCommandHandlerMock, c commands.ShortenURLCommand) {
 				m.On("Handle", mock.Anything, c).
-					Return("", assert.AnError).
+					Return(commands.ShortenURLResult{}, assert.AnError).
 					Once()
 			},
 		},
@@ -59,9 +134,15 @@ func TestServer_ShortenURL(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			e := echo.New()
-			rs := servers.ShortenURLJSONBody{
+			rs := servers.ShortenRequest{
 				Url: tc.reqOriginalURL,
 			}
+			if tc.reqAlias != "" {
+				rs.Alias = &tc.reqAlias
+			}
+			if tc.reqReuse {
+				rs.ReuseExisting = &tc.reqReuse
+			}
 			body, _ := json.Marshal(rs)
 			req := httptest.NewRequest(http.MethodGet, "/", bytes.NewBuffer(body))
 			req.Header.Set("Content-Type", "application/json")
@@ -69,10 +150,15 @@ func TestServer_ShortenURL(t *testing.T) {
 			ctx := e.NewContext(req, rec)
 
 			m := commands_mocks.NewShortenURLCommandHandlerMock(t)
-			c := commands.ShortenURLCommand{OriginalURL: tc.reqOriginalURL}
+			c := commands.ShortenURLCommand{
+				OriginalURL:   tc.reqOriginalURL,
+				Alias:         tc.reqAlias,
+				ReuseExisting: tc.reqReuse,
+			}
 			tc.mockBehavior(m, c)
 
 			s := &Server{
+				authorizer:               testAuthorizer(t),
 				shortenURLCommandHandler: m,
 				redirectQueryHandler:     nil,
 				getURLInfoQueryHandler:   nil,
@@ -94,3 +180,17 @@ func TestServer_ShortenURL(t *testing.T) {
 		})
 	}
 }
+
+func TestNewBadRequestError(t *testing.T) {
+	httpErr := newBadRequestError(errs.NewValueIsInvalidErrorWithCause("url", errors.New("malformed url")))
+
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+	body, ok := httpErr.Message.(servers.Error)
+	if assert.True(t, ok) {
+		assert.Equal(t, errCodeInvalidValue, body.Code)
+		assert.Equal(t, "url is invalid: malformed url", body.Message)
+		if assert.NotNil(t, body.Field) {
+			assert.Equal(t, "url", *body.Field)
+		}
+	}
+}
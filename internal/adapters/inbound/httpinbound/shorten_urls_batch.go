@@ -0,0 +1,120 @@
+package httpinbound
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/commands"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
+	"github.com/labstack/echo/v4"
+)
+
+// shortenURLsBatchResponse is 200 response body of ShortenURLsBatch.
+type shortenURLsBatchResponse struct {
+	Items []servers.ShortenBatchItemResult `json:"items"`
+}
+
+// Shorten URLs in bulk
+// (POST /api/v1/shorten/batch)
+
+func (s *Server) ShortenURLsBatch(ctx echo.Context) error {
+	if err := s.authorizeOptional(ctx); err != nil {
+		return err
+	}
+
+	var req servers.ShortenURLsBatchJSONBody
+	if err := ctx.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	if len(req.Items) == 0 {
+		return newBadRequestError(errs.NewValueIsRequiredError("items"))
+	}
+
+	if len(req.Items) > commands.MaxShortenBatchSize {
+		return newBadRequestError(errs.NewValueIsInvalidError("items"))
+	}
+
+	results := make([]servers.ShortenBatchItemResult, len(req.Items))
+	cmds := make([]commands.ShortenURLCommand, 0, len(req.Items))
+	// indexes maps command to index of request item it was built from.
+	indexes := make([]int, 0, len(req.Items))
+
+	for i, item := range req.Items {
+		results[i].Index = i
+
+		// Hashing passwords is costly on purpose, so batches of anonymous callers could burn a lot of cpu.
+		// Password protected urls are shortened one by one.
+		if item.Password != nil {
+			results[i].Error = batchItemError(errs.NewValueIsInvalidErrorWithCause(
+				"password",
+				errors.New("can not be used in batch, shorten password protected url on its own"),
+			))
+			continue
+		}
+
+		cmd, err := newShortenURLCommand(ctx, item)
+		if err != nil {
+			results[i].Error = batchItemError(err)
+			continue
+		}
+
+		cmds = append(cmds, cmd)
+		indexes = append(indexes, i)
+	}
+
+	if len(cmds) == 0 {
+		return ctx.JSON(http.StatusOK, shortenURLsBatchResponse{Items: results})
+	}
+
+	batch, err := commands.NewShortenURLsBatchCommand(cmds)
+	if err != nil {
+		return newBadRequestError(err)
+	}
+
+	cmdResults, err := s.shortenURLsBatchCommandHandler.Handle(ctx.Request().Context(), batch)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+	}
+
+	for j, res := range cmdResults {
+		i := indexes[j]
+		if res.Err != nil {
+			results[i].Error = batchItemError(res.Err)
+			continue
+		}
+
+		results[i].ShortUrl = &res.ShortURL
+		results[i].Created = &res.Created
+	}
+
+	return ctx.JSON(http.StatusOK, shortenURLsBatchResponse{Items: results})
+}
+
+// batchItemError describes error of single batch item in openapi Error schema.
+func batchItemError(err error) *servers.Error {
+	var (
+		httpErr *echo.HTTPError
+		body    servers.Error
+	)
+
+	switch {
+	case errors.As(err, &httpErr):
+		if e, ok := httpErr.Message.(servers.Error); ok {
+			body = e
+		} else {
+			body = servers.Error{Code: errCodeInvalidValue, Message: http.StatusText(httpErr.Code)}
+		}
+	case errors.Is(err, errs.ErrObjectAlreadyExists):
+		body = servers.Error{Code: errCodeConflict, Message: "alias is already taken"}
+	case errors.Is(err, errs.ErrValueIsInvalid), errors.Is(err, errs.ErrValueIsRequired):
+		body = invalidValueErrorBody(err)
+	case errors.Is(err, commands.ErrTokenGenerationExhausted):
+		body = servers.Error{Code: errCodeTokenGenerationFailed, Message: "could not generate unique short url, try again"}
+	default:
+		body = servers.Error{Code: errCodeInternal, Message: "internal server error"}
+	}
+
+	return &body
+}
@@ -0,0 +1,130 @@
+//nolint:nolintlint,exhaustruct,testpackage
+package httpinbound
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/commands"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
+	commands_mocks "github.com/dzhordano/urlshortener/mocks/core/application_mocks/commands"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_ShortenURLsBatch(t *testing.T) {
+	var (
+		alias         = "spring-sale"
+		shortURL      = "SHORT00"
+		created       = true
+		urlField      = "url"
+		password      = "s3cret!"
+		passwordField = "password"
+	)
+	validItems := []servers.ShortenRequest{
+		{Url: "https://google.com"},
+		{Url: "javascript:alert(1)"},
+		{Url: "https://example.com", Alias: &alias},
+		{Url: "https://example.com/private", Password: &password},
+	}
+	validCmd := commands.ShortenURLsBatchCommand{Items: []commands.ShortenURLCommand{
+		{OriginalURL: "https://google.com"},
+		{OriginalURL: "https://example.com", Alias: alias},
+	}}
+
+	tt := []struct {
+		name          string
+		reqItems      []servers.ShortenRequest
+		expectedCode  int
+		expectedItems []servers.ShortenBatchItemResult
+		mockBehavior  func(m *commands_mocks.ShortenURLsBatchCommandHandlerMock)
+	}{
+		{
+			name:         "per item results",
+			reqItems:     validItems,
+			expectedCode: http.StatusOK,
+			expectedItems: []servers.ShortenBatchItemResult{
+				{Index: 0, ShortUrl: &shortURL, Created: &created},
+				{Index: 1, Error: &servers.Error{
+					Code:    errCodeInvalidValue,
+					Field:   &urlField,
+					Message: `url is invalid: scheme "javascript" is not allowed, use http or https`,
+				}},
+				{Index: 2, Error: &servers.Error{Code: errCodeConflict, Message: "alias is already taken"}},
+				{Index: 3, Error: &servers.Error{
+					Code:    errCodeInvalidValue,
+					Field:   &passwordField,
+					Message: "password is invalid: can not be used in batch, shorten password protected url on its own",
+				}},
+			},
+			mockBehavior: func(m *commands_mocks.ShortenURLsBatchCommandHandlerMock) {
+				m.On("Handle", mock.Anything, validCmd).
+					Return([]commands.ShortenURLsBatchItemResult{
+						{ShortenURLResult: commands.ShortenURLResult{ShortURL: "SHORT00", Created: true}},
+						{Err: errs.NewObjectAlreadyExistsError("shortURL", alias)},
+					}, nil).
+					Once()
+			},
+		},
+		{
+			name:         "no items",
+			reqItems:     []servers.ShortenRequest{},
+			expectedCode: http.StatusBadRequest,
+			mockBehavior: func(*commands_mocks.ShortenURLsBatchCommandHandlerMock) {},
+		},
+		{
+			name:         "too many items",
+			reqItems:     make([]servers.ShortenRequest, commands.MaxShortenBatchSize+1),
+			expectedCode: http.StatusBadRequest,
+			mockBehavior: func(*commands_mocks.ShortenURLsBatchCommandHandlerMock) {},
+		},
+		{
+			name:         "internal",
+			reqItems:     validItems,
+			expectedCode: http.StatusInternalServerError,
+			mockBehavior: func(m *commands_mocks.ShortenURLsBatchCommandHandlerMock) {
+				m.On("Handle", mock.Anything, validCmd).
+					Return(nil, assert.AnError).
+					Once()
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			body, _ := json.Marshal(servers.ShortenURLsBatchJSONBody{Items: tc.reqItems})
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+
+			m := commands_mocks.NewShortenURLsBatchCommandHandlerMock(t)
+			tc.mockBehavior(m)
+
+			s := &Server{shortenURLsBatchCommandHandler: m, authorizer: testAuthorizer(t)}
+
+			err := s.ShortenURLsBatch(ctx)
+
+			if err != nil {
+				var httpErr *echo.HTTPError
+				require.True(t, errors.As(err, &httpErr))
+				assert.Equal(t, tc.expectedCode, httpErr.Code)
+				return
+			}
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+
+			var resp shortenURLsBatchResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, tc.expectedItems, resp.Items)
+		})
+	}
+}
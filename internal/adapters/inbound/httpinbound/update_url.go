@@ -0,0 +1,62 @@
+package httpinbound
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/commands"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
+	"github.com/labstack/echo/v4"
+)
+
+// Updates shortened url
+// (PATCH /api/v1/{token})
+
+func (s *Server) UpdateURL(ctx echo.Context, token string) error {
+	principal, err := s.authorize(ctx)
+	if err != nil {
+		return err
+	}
+
+	var req servers.UpdateURLJSONBody
+	if err := ctx.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	var expiration *commands.Expiration
+	if req.ExpiresAt != nil || req.Ttl != nil || req.NeverExpires != nil {
+		exp, err := newExpiration(ctx, req.ExpiresAt, req.Ttl, req.NeverExpires)
+		if err != nil {
+			return err
+		}
+		expiration = &exp
+	}
+
+	var status *string
+	if req.Status != nil {
+		st := string(*req.Status)
+		status = &st
+	}
+
+	cmd, err := commands.NewUpdateURLCommand(
+		principal, token, req.Url, expiration, status, req.Tags, (*int)(req.RedirectCode),
+	)
+	if err != nil {
+		return newBadRequestError(err)
+	}
+
+	err = s.updateURLCommandHandler.Handle(ctx.Request().Context(), cmd)
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrObjectNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, "short url not found")
+		case errors.Is(err, errs.ErrValueIsInvalid):
+			return newBadRequestError(err)
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+		}
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
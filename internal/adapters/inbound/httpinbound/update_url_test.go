@@ -0,0 +1,156 @@
+//nolint:nolintlint,exhaustruct,testpackage
+package httpinbound
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/gen/servers"
+	commands_mocks "github.com/dzhordano/urlshortener/mocks/core/application_mocks/commands"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestServer_UpdateURL(t *testing.T) {
+	disabled := servers.URLStatusDisabled
+	unknownStatus := servers.URLStatus("unknown")
+	newURL := "https://example.com/new"
+	ttl := int64(60)
+
+	tt := []struct {
+		name         string
+		isAuthorized bool
+		reqShortURL  string
+		reqBody      servers.UpdateURLJSONBody
+		expectedCode int
+		expectErr    bool
+		mockBehavior func(m *commands_mocks.UpdateURLCommandHandlerMock)
+	}{
+		{
+			name:         "success",
+			isAuthorized: true,
+			reqShortURL:  "RAND000",
+			reqBody:      servers.UpdateURLJSONBody{Url: &newURL, Status: &disabled},
+			expectedCode: http.StatusNoContent,
+			expectErr:    false,
+			mockBehavior: func(m *commands_mocks.UpdateURLCommandHandlerMock) {
+				m.On("Handle", mock.Anything, mock.Anything).
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			name:         "success ttl",
+			isAuthorized: true,
+			reqShortURL:  "RAND000",
+			reqBody:      servers.UpdateURLJSONBody{Ttl: &ttl},
+			expectedCode: http.StatusNoContent,
+			expectErr:    false,
+			mockBehavior: func(m *commands_mocks.UpdateURLCommandHandlerMock) {
+				m.On("Handle", mock.Anything, mock.Anything).
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			name:         "nothing to update",
+			isAuthorized: true,
+			reqShortURL:  "RAND000",
+			reqBody:      servers.UpdateURLJSONBody{},
+			expectedCode: http.StatusBadRequest,
+			expectErr:    true,
+			mockBehavior: func(*commands_mocks.UpdateURLCommandHandlerMock) {},
+		},
+		{
+			name:         "unknown status",
+			isAuthorized: true,
+			reqShortURL:  "RAND000",
+			reqBody:      servers.UpdateURLJSONBody{Status: &unknownStatus},
+			expectedCode: http.StatusBadRequest,
+			expectErr:    true,
+			mockBehavior: func(*commands_mocks.UpdateURLCommandHandlerMock) {},
+		},
+		{
+			name:         "not found",
+			isAuthorized: true,
+			reqShortURL:  "RAND000",
+			reqBody:      servers.UpdateURLJSONBody{Status: &disabled},
+			expectedCode: http.StatusNotFound,
+			expectErr:    true,
+			mockBehavior: func(m *commands_mocks.UpdateURLCommandHandlerMock) {
+				m.On("Handle", mock.Anything, mock.Anything).
+					Return(errs.NewObjectNotFoundError("shortenedURL", "RAND000")).
+					Once()
+			},
+		},
+		{
+			name:         "internal",
+			isAuthorized: true,
+			reqShortURL:  "RAND000",
+			reqBody:      servers.UpdateURLJSONBody{Status: &disabled},
+			expectedCode: http.StatusInternalServerError,
+			expectErr:    true,
+			mockBehavior: func(m *commands_mocks.UpdateURLCommandHandlerMock) {
+				m.On("Handle", mock.Anything, mock.Anything).
+					Return(assert.AnError).
+					Once()
+			},
+		},
+		{
+			name:         "unauthorized",
+			isAuthorized: false,
+			reqShortURL:  "RAND000",
+			reqBody:      servers.UpdateURLJSONBody{Status: &disabled},
+			expectedCode: http.StatusUnauthorized,
+			expectErr:    true,
+			mockBehavior: func(*commands_mocks.UpdateURLCommandHandlerMock) {},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			body, _ := json.Marshal(tc.reqBody)
+			req := httptest.NewRequest(
+				http.MethodPatch,
+				fmt.Sprintf("/api/v1/%s", tc.reqShortURL),
+				bytes.NewBuffer(body),
+			)
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			if tc.isAuthorized {
+				setPrincipal(ctx, adminPrincipal)
+			}
+
+			m := commands_mocks.NewUpdateURLCommandHandlerMock(t)
+			tc.mockBehavior(m)
+
+			s := &Server{
+				authorizer:              testAuthorizer(t),
+				updateURLCommandHandler: m,
+			}
+
+			err := s.UpdateURL(ctx, tc.reqShortURL)
+
+			if err != nil {
+				// Since echo.NewHTTPError() is used to return errors, cast error to echo.HTTPError
+				var httpErr *echo.HTTPError
+				if errors.As(err, &httpErr) {
+					assert.Equal(t, tc.expectedCode, httpErr.Code)
+				} else if tc.expectErr {
+					assert.Error(t, err)
+				}
+			} else {
+				assert.Equal(t, tc.expectedCode, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package geoip
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/dzhordano/urlshortener/internal/core/ports"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// MMDBLocator locates ips using local database in MaxMind DB format, e.g. GeoLite2 Country or City one.
+// Database is memory mapped, so lookups don't read the file.
+type MMDBLocator struct {
+	db *maxminddb.Reader
+}
+
+// mmdbCountryRecord is part of GeoIP2 record telling country. Registered country is used for ips
+// whose country is missing, e.g. of anycast networks.
+type mmdbCountryRecord struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	RegisteredCountry struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"registered_country"`
+}
+
+// NewMMDBLocator returns locator reading database at path. Database is read once, so updated file
+// is picked up on restart only.
+func NewMMDBLocator(path string) (ports.GeoLocator, error) {
+	if path == "" {
+		return nil, errs.NewValueIsRequiredError("path")
+	}
+
+	db, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening geoip database: %w", err)
+	}
+
+	return &MMDBLocator{db: db}, nil
+}
+
+func (l *MMDBLocator) Country(_ context.Context, ip string) (string, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return "", nil
+	}
+
+	var record mmdbCountryRecord
+	if err = l.db.Lookup(addr.Unmap().WithZone("").AsSlice(), &record); err != nil {
+		return "", fmt.Errorf("error looking up ip country: %w", err)
+	}
+
+	code := record.Country.ISOCode
+	if code == "" {
+		code = record.RegisteredCountry.ISOCode
+	}
+
+	return strings.ToUpper(code), nil
+}
+
+func (l *MMDBLocator) Close(_ context.Context) error {
+	return l.db.Close()
+}
@@ -0,0 +1,197 @@
+//nolint:nolintlint,exhaustruct,testpackage
+package geoip
+
+import (
+	"context"
+	"encoding/binary"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMMDBLocator_NoDatabase(t *testing.T) {
+	_, err := NewMMDBLocator("")
+	require.ErrorIs(t, err, errs.ErrValueIsRequired)
+
+	_, err = NewMMDBLocator(filepath.Join(t.TempDir(), "missing.mmdb"))
+	require.Error(t, err)
+}
+
+func TestMMDBLocator_Country(t *testing.T) {
+	path := writeTestMMDB(t, map[string]string{
+		"81.2.69.0/24":  "de",
+		"2a02:c7f::/32": "gb",
+		// Network without country of its own, it's told by registered country.
+		"198.18.0.0/15": "",
+	})
+
+	l, err := NewMMDBLocator(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, l.Close(context.Background())) })
+
+	tt := []struct {
+		ip      string
+		country string
+	}{
+		{ip: "81.2.69.142", country: "DE"},
+		{ip: " ::ffff:81.2.69.1 ", country: "DE"},
+		{ip: "2a02:c7f:1234::1", country: "GB"},
+		{ip: "198.19.0.1", country: "NL"},
+		// Misses.
+		{ip: "81.2.70.1", country: ""},
+		{ip: "8.8.8.8", country: ""},
+		{ip: "2001:db8::1", country: ""},
+		// Private and invalid ips.
+		{ip: "10.0.0.1", country: ""},
+		{ip: "192.168.1.1", country: ""},
+		{ip: "not an ip", country: ""},
+		{ip: "", country: ""},
+	}
+
+	for _, tc := range tt {
+		country, err := l.Country(context.Background(), tc.ip)
+		require.NoError(t, err, tc.ip)
+		assert.Equal(t, tc.country, country, tc.ip)
+	}
+}
+
+func TestNoopLocator_Country(t *testing.T) {
+	country, err := NewNoopLocator().Country(context.Background(), "203.0.113.7")
+	require.NoError(t, err)
+	assert.Empty(t, country)
+}
+
+// testMMDBRegisteredCountry is registered country of networks written without country.
+const testMMDBRegisteredCountry = "nl"
+
+// writeTestMMDB writes minimal IPv6 MaxMind DB with 24 bit records mapping networks to GeoIP2 Country
+// records, and returns its path. Networks mapped to empty country have registered country only.
+func writeTestMMDB(t *testing.T, countries map[string]string) string {
+	t.Helper()
+
+	type node struct {
+		children [2]*node
+		// data is offset of network's record in data section, -1 for nodes networks continue from.
+		data int
+	}
+
+	var (
+		root = &node{data: -1}
+		data []byte
+	)
+	for network, country := range countries {
+		prefix := netip.MustParsePrefix(network)
+		record := mmdbMap(mmdbString("registered_country"), mmdbMap(mmdbString("iso_code"),
+			mmdbString(testMMDBRegisteredCountry)))
+		if country != "" {
+			record = mmdbMap(
+				mmdbString("country"), mmdbMap(mmdbString("iso_code"), mmdbString(country)),
+				mmdbString("registered_country"), mmdbMap(mmdbString("iso_code"),
+					mmdbString(testMMDBRegisteredCountry)),
+			)
+		}
+
+		// IPv4 networks live in ::/96 of IPv6 tree.
+		addr := prefix.Addr().As16()
+		bits := prefix.Bits()
+		if prefix.Addr().Is4() {
+			addr = netip.AddrFrom16([16]byte{12: addr[12], 13: addr[13], 14: addr[14], 15: addr[15]}).As16()
+			bits += 96
+		}
+
+		n := root
+		for i := range bits {
+			bit := addr[i/8] >> (7 - i%8) & 1
+			if n.children[bit] == nil {
+				n.children[bit] = &node{data: -1}
+			}
+			n = n.children[bit]
+		}
+		n.data = len(data)
+		data = append(data, record...)
+	}
+
+	// Nodes are numbered breadth first, networks' nodes are records pointing to data instead.
+	var nodes []*node
+	ids := map[*node]int{}
+	for queue := []*node{root}; len(queue) > 0; queue = queue[1:] {
+		n := queue[0]
+		ids[n] = len(nodes)
+		nodes = append(nodes, n)
+		for _, c := range n.children {
+			if c != nil && c.data < 0 {
+				queue = append(queue, c)
+			}
+		}
+	}
+
+	var db []byte
+	for _, n := range nodes {
+		for _, c := range n.children {
+			record := len(nodes)
+			switch {
+			case c == nil:
+			case c.data >= 0:
+				record = len(nodes) + 16 + c.data
+			default:
+				record = ids[c]
+			}
+			db = append(db, byte(record>>16), byte(record>>8), byte(record))
+		}
+	}
+
+	const ipVersion, recordSize, formatVersion = 6, 24, 2
+	db = append(db, make([]byte, 16)...)
+	db = append(db, data...)
+	db = append(db, "\xAB\xCD\xEFMaxMind.com"...)
+	db = append(db, mmdbMap(
+		mmdbString("node_count"), mmdbUint(6, uint64(len(nodes))),
+		mmdbString("record_size"), mmdbUint(5, recordSize),
+		mmdbString("ip_version"), mmdbUint(5, ipVersion),
+		mmdbString("database_type"), mmdbString("Test-Country"),
+		mmdbString("languages"), []byte{1, 4, 2<<5 | 2, 'e', 'n'},
+		mmdbString("binary_format_major_version"), mmdbUint(5, formatVersion),
+		mmdbString("binary_format_minor_version"), mmdbUint(5, 0),
+		mmdbString("build_epoch"), mmdbUint(9, 0),
+		mmdbString("description"), mmdbMap(mmdbString("en"), mmdbString("Test country database")),
+	)...)
+
+	path := filepath.Join(t.TempDir(), "test.mmdb")
+	require.NoError(t, os.WriteFile(path, db, 0o600))
+
+	return path
+}
+
+// mmdbString encodes short string of MaxMind DB data section.
+func mmdbString(s string) []byte {
+	return append([]byte{2<<5 | byte(len(s))}, s...)
+}
+
+// mmdbMap encodes map of MaxMind DB data section made of encoded keys and values.
+func mmdbMap(pairs ...[]byte) []byte {
+	encoded := []byte{7<<5 | byte(len(pairs)/2)}
+	for _, p := range pairs {
+		encoded = append(encoded, p...)
+	}
+
+	return encoded
+}
+
+// mmdbUint encodes unsigned integer of MaxMind DB data section of type typ (5, 6 or 9).
+func mmdbUint(typ byte, v uint64) []byte {
+	b := binary.BigEndian.AppendUint64(nil, v)
+	for len(b) > 0 && b[0] == 0 {
+		b = b[1:]
+	}
+
+	if typ > 7 {
+		return append([]byte{byte(len(b)), typ - 7}, b...)
+	}
+
+	return append([]byte{typ<<5 | byte(len(b))}, b...)
+}
@@ -0,0 +1,22 @@
+package geoip
+
+import (
+	"context"
+
+	"github.com/dzhordano/urlshortener/internal/core/ports"
+)
+
+// NoopLocator locates no ips, every visitor's country is unknown with it.
+type NoopLocator struct{}
+
+func NewNoopLocator() ports.GeoLocator {
+	return &NoopLocator{}
+}
+
+func (l *NoopLocator) Country(_ context.Context, _ string) (string, error) {
+	return "", nil
+}
+
+func (l *NoopLocator) Close(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,47 @@
+package redirectmetrics
+
+import (
+	"fmt"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/core/ports"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "urlshortener"
+
+// Metrics exports redirect metrics to prometheus.
+type Metrics struct {
+	fallbackRedirects *prometheus.CounterVec
+}
+
+// NewMetrics returns Metrics registering its metrics in reg.
+func NewMetrics(reg prometheus.Registerer) (ports.RedirectMetrics, error) {
+	if reg == nil {
+		return nil, errs.NewValueIsRequiredError("reg")
+	}
+
+	m := &Metrics{
+		fallbackRedirects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "fallback_redirects_total",
+			Help:      "Amount of redirects to fallback url by reason url couldn't be followed.",
+		}, []string{"reason"}),
+	}
+
+	if err := reg.Register(m.fallbackRedirects); err != nil {
+		return nil, fmt.Errorf("error registering redirect metrics: %w", err)
+	}
+
+	// Every reason is exported from start, so rates don't miss the first fallback.
+	for _, reason := range model.UnavailableReasons {
+		m.fallbackRedirects.WithLabelValues(string(reason))
+	}
+
+	return m, nil
+}
+
+func (m *Metrics) FallbackRedirect(reason model.UnavailableReason) {
+	m.fallbackRedirects.WithLabelValues(string(reason)).Inc()
+}
@@ -0,0 +1,140 @@
+package apikeyrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/core/ports"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	apiKeysTable = "api_keys"
+)
+
+type Repository struct {
+	db *pgxpool.Pool
+}
+
+func NewRepository(db *pgxpool.Pool) (ports.APIKeyRepository, error) {
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+
+	return &Repository{
+		db: db,
+	}, nil
+}
+
+func (r *Repository) Save(ctx context.Context, key *model.APIKey) error {
+	const op = "APIKeyRepo.Save"
+
+	query := fmt.Sprintf(
+		`INSERT INTO %s (id, prefix, hash, name, role, scopes, created_at, last_used_at, expires_at, revoked)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		apiKeysTable)
+
+	scopes := key.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	_, err := r.db.Exec(
+		ctx,
+		query,
+		key.ID, key.Prefix, key.Hash, key.Name, key.Role, scopes,
+		key.CreatedAtUTC, key.LastUsedAtUTC, key.ExpiresAtUTC, key.Revoked,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return fmt.Errorf("%s: %w", op, errs.NewObjectAlreadyExistsError("prefix", key.Prefix))
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetByPrefix(ctx context.Context, prefix string) (*model.APIKey, error) {
+	const op = "APIKeyRepo.GetByPrefix"
+
+	query := fmt.Sprintf(
+		`SELECT id, prefix, hash, name, role, scopes, created_at, last_used_at, expires_at, revoked
+		FROM %s
+		WHERE prefix = $1`,
+		apiKeysTable,
+	)
+
+	var key model.APIKey
+	err := r.db.QueryRow(ctx, query, prefix).Scan(
+		&key.ID,
+		&key.Prefix,
+		&key.Hash,
+		&key.Name,
+		&key.Role,
+		&key.Scopes,
+		&key.CreatedAtUTC,
+		&key.LastUsedAtUTC,
+		&key.ExpiresAtUTC,
+		&key.Revoked,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, errs.NewObjectNotFoundError("prefix", prefix))
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &key, nil
+}
+
+func (r *Repository) TouchLastUsed(ctx context.Context, id uuid.UUID, t time.Time) error {
+	const op = "APIKeyRepo.TouchLastUsed"
+
+	query := fmt.Sprintf(
+		`UPDATE %s
+		SET last_used_at = $2
+		WHERE id = $1`,
+		apiKeysTable,
+	)
+
+	_, err := r.db.Exec(ctx, query, id, t)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) Revoke(ctx context.Context, id uuid.UUID) error {
+	const op = "APIKeyRepo.Revoke"
+
+	query := fmt.Sprintf(
+		`UPDATE %s
+		SET revoked = TRUE
+		WHERE id = $1 AND NOT revoked`,
+		apiKeysTable,
+	)
+
+	ct, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.NewObjectNotFoundError("id", id))
+	}
+
+	return nil
+}
@@ -0,0 +1,226 @@
+package clickcounter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/ports"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "urlshortener"
+
+// clicks are url's clicks counted since the last flush.
+type clicks struct {
+	human int64
+	bot   int64
+}
+
+// Counter sums clicks per short url in memory and adds them to urls.clicks (bots' ones to urls.bot_clicks)
+// in one statement on Flush, so redirects neither wait for postgres nor contend for popular url's row lock.
+// Clicks counted since the last flush are lost if process crashes.
+type Counter struct {
+	log logger.Logger
+	db  *pgxpool.Pool
+
+	mu     sync.Mutex
+	counts map[string]clicks
+	// oldest is moment the oldest not flushed click was counted at. Zero if there are none.
+	oldest time.Time
+
+	// flushMu serializes flushes, so clicks are added in order they're taken from buffer.
+	flushMu sync.Mutex
+
+	bufferedURLs   prometheus.Gauge
+	bufferedClicks prometheus.Gauge
+	flushLag       prometheus.Gauge
+	flushErrors    prometheus.Counter
+	botClicks      prometheus.Counter
+}
+
+// NewCounter returns Counter registering its metrics in reg.
+func NewCounter(log logger.Logger, db *pgxpool.Pool, reg prometheus.Registerer) (ports.ClickCounter, error) {
+	if log == nil {
+		return nil, errs.NewValueIsRequiredError("log")
+	}
+
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+
+	if reg == nil {
+		return nil, errs.NewValueIsRequiredError("reg")
+	}
+
+	c := &Counter{
+		log:    log,
+		db:     db,
+		counts: make(map[string]clicks),
+		bufferedURLs: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "click_buffer_urls",
+			Help:      "Amount of urls with clicks not flushed to db yet.",
+		}),
+		bufferedClicks: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "click_buffer_clicks",
+			Help:      "Amount of clicks (bots' included) not flushed to db yet.",
+		}),
+		flushLag: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "click_flush_lag_seconds",
+			Help:      "Age of the oldest click at the moment of the last successful flush.",
+		}),
+		flushErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "click_flush_errors_total",
+			Help:      "Amount of failed click flushes. Clicks of failed flush are kept for the next one.",
+		}),
+		botClicks: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "bot_clicks_total",
+			Help:      "Amount of redirects made by bots. They're not counted as clicks.",
+		}),
+	}
+
+	for _, collector := range []prometheus.Collector{
+		c.bufferedURLs, c.bufferedClicks, c.flushLag, c.flushErrors, c.botClicks,
+	} {
+		if err := reg.Register(collector); err != nil {
+			return nil, fmt.Errorf("error registering click counter metrics: %w", err)
+		}
+	}
+
+	return c, nil
+}
+
+func (c *Counter) Increment(shortURL string) {
+	c.add(shortURL, clicks{human: 1})
+}
+
+// IncrementCapped bypasses buffer, since the limit must be compared with clicks already in db.
+func (c *Counter) IncrementCapped(ctx context.Context, shortURL string) (bool, int, error) {
+	const op = "ClickCounter.IncrementCapped"
+
+	query := `
+	UPDATE urls
+	SET clicks = clicks + 1
+	WHERE short_url = $1
+		AND deleted_at IS NULL
+		AND max_clicks IS NOT NULL
+		AND clicks < max_clicks
+	RETURNING max_clicks - clicks`
+
+	var left int
+	err := c.db.QueryRow(ctx, query, shortURL).Scan(&left)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, 0, nil
+		}
+		return false, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return true, left, nil
+}
+
+func (c *Counter) IncrementBot(shortURL string) {
+	c.botClicks.Inc()
+	c.add(shortURL, clicks{bot: 1})
+}
+
+func (c *Counter) add(shortURL string, n clicks) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.addLocked(shortURL, n)
+
+	if c.oldest.IsZero() {
+		c.oldest = time.Now()
+	}
+}
+
+func (c *Counter) Flush(ctx context.Context) error {
+	const op = "ClickCounter.Flush"
+
+	c.flushMu.Lock()
+	defer c.flushMu.Unlock()
+
+	counts, oldest := c.take()
+	if len(counts) == 0 {
+		c.flushLag.Set(0)
+		return nil
+	}
+
+	shortURLs := make([]string, 0, len(counts))
+	human := make([]int64, 0, len(counts))
+	bot := make([]int64, 0, len(counts))
+	for shortURL, n := range counts {
+		shortURLs = append(shortURLs, shortURL)
+		human = append(human, n.human)
+		bot = append(bot, n.bot)
+	}
+
+	query := `
+	UPDATE urls
+	SET clicks = urls.clicks + c.human, bot_clicks = urls.bot_clicks + c.bot
+	FROM unnest($1::text[], $2::bigint[], $3::bigint[]) AS c(short_url, human, bot)
+	WHERE urls.short_url = c.short_url`
+
+	if _, err := c.db.Exec(ctx, query, shortURLs, human, bot); err != nil {
+		c.flushErrors.Inc()
+		c.putBack(counts, oldest)
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	c.flushLag.Set(time.Since(oldest).Seconds())
+	c.log.Debug("clicks flushed", "urls", len(counts))
+
+	return nil
+}
+
+// take empties buffer returning its clicks and moment the oldest of them was counted at.
+func (c *Counter) take() (map[string]clicks, time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counts, oldest := c.counts, c.oldest
+	c.counts = make(map[string]clicks, len(counts))
+	c.oldest = time.Time{}
+
+	c.bufferedURLs.Set(0)
+	c.bufferedClicks.Set(0)
+
+	return counts, oldest
+}
+
+// putBack returns clicks of failed flush to buffer, so they're flushed next time.
+func (c *Counter) putBack(counts map[string]clicks, oldest time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for shortURL, n := range counts {
+		c.addLocked(shortURL, n)
+	}
+
+	if c.oldest.IsZero() || oldest.Before(c.oldest) {
+		c.oldest = oldest
+	}
+}
+
+// addLocked adds n to url's buffered clicks. c.mu must be held.
+func (c *Counter) addLocked(shortURL string, n clicks) {
+	cur, ok := c.counts[shortURL]
+	if !ok {
+		c.bufferedURLs.Inc()
+	}
+
+	c.counts[shortURL] = clicks{human: cur.human + n.human, bot: cur.bot + n.bot}
+	c.bufferedClicks.Add(float64(n.human + n.bot))
+}
@@ -0,0 +1,197 @@
+package clickrecorder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/core/ports"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	clickEventsTable = "click_events"
+
+	// flushTimeout bounds single batch insert.
+	flushTimeout = 5 * time.Second
+)
+
+var (
+	// ErrQueueFull is returned when events come faster than they are persisted.
+	ErrQueueFull = errors.New("click events queue is full")
+	// ErrClosed is returned for events recorded after Close.
+	ErrClosed = errors.New("click recorder is closed")
+)
+
+//nolint:gochecknoglobals // Read only.
+var clickEventsColumns = []string{
+	"url_id", "short_url", "occurred_at", "referrer_host", "user_agent", "ip_prefix", "accept_language",
+	"device", "browser", "os", "country",
+}
+
+// Config tunes Recorder.
+type Config struct {
+	// QueueSize is amount of events waiting to be persisted. Events recorded over it are dropped.
+	QueueSize int
+	// BatchSize is max amount of events inserted at once.
+	BatchSize int
+	// FlushInterval is max time event waits in queue for batch to fill up.
+	FlushInterval time.Duration
+}
+
+// Recorder queues click events in memory and inserts them into postgres in batches in background,
+// so recording doesn't add latency to redirects. Queued events are lost if process crashes.
+type Recorder struct {
+	log    logger.Logger
+	db     *pgxpool.Pool
+	cfg    Config
+	events chan model.ClickEvent
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewRecorder returns Recorder with background worker already running. Close must be called to stop it.
+func NewRecorder(log logger.Logger, db *pgxpool.Pool, cfg Config) (ports.ClickRecorder, error) {
+	if log == nil {
+		return nil, errs.NewValueIsRequiredError("log")
+	}
+
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+
+	if cfg.QueueSize <= 0 {
+		return nil, errs.NewValueIsInvalidError("cfg.QueueSize")
+	}
+
+	if cfg.BatchSize <= 0 {
+		return nil, errs.NewValueIsInvalidError("cfg.BatchSize")
+	}
+
+	if cfg.FlushInterval <= 0 {
+		return nil, errs.NewValueIsInvalidError("cfg.FlushInterval")
+	}
+
+	r := &Recorder{
+		log:    log,
+		db:     db,
+		cfg:    cfg,
+		events: make(chan model.ClickEvent, cfg.QueueSize),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	go r.run()
+
+	return r, nil
+}
+
+func (r *Recorder) Record(_ context.Context, event model.ClickEvent) error {
+	select {
+	case <-r.stop:
+		return ErrClosed
+	default:
+	}
+
+	select {
+	case r.events <- event:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+func (r *Recorder) Close(ctx context.Context) error {
+	r.stopOnce.Do(func() { close(r.stop) })
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("error waiting for click events flush: %w", ctx.Err())
+	}
+}
+
+// run collects events into batches and flushes them when batch is full or flush interval passes.
+// Queued events are flushed on stop.
+func (r *Recorder) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]model.ClickEvent, 0, r.cfg.BatchSize)
+
+	for {
+		select {
+		case event := <-r.events:
+			batch = append(batch, event)
+			if len(batch) >= r.cfg.BatchSize {
+				batch = r.flush(batch)
+			}
+		case <-ticker.C:
+			batch = r.flush(batch)
+		case <-r.stop:
+			for {
+				select {
+				case event := <-r.events:
+					batch = append(batch, event)
+					if len(batch) >= r.cfg.BatchSize {
+						batch = r.flush(batch)
+					}
+				default:
+					r.flush(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// flush inserts batch and returns it emptied. Failed batch is dropped.
+func (r *Recorder) flush(batch []model.ClickEvent) []model.ClickEvent {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+
+	if err := r.insert(ctx, batch); err != nil {
+		r.log.Error("error saving click events, events dropped", "count", len(batch), "error", err)
+	} else {
+		r.log.Debug("click events saved", "count", len(batch))
+	}
+
+	return batch[:0]
+}
+
+func (r *Recorder) insert(ctx context.Context, batch []model.ClickEvent) error {
+	const op = "ClickRecorder.insert"
+
+	_, err := r.db.CopyFrom(
+		ctx,
+		pgx.Identifier{clickEventsTable},
+		clickEventsColumns,
+		pgx.CopyFromSlice(len(batch), func(i int) ([]any, error) {
+			e := batch[i]
+			return []any{
+				e.URLID, e.ShortURL, e.OccurredAtUTC, e.ReferrerHost, e.UserAgent, e.IPPrefix, e.AcceptLanguage,
+				e.Device, e.Browser, e.OS, e.Country,
+			}, nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
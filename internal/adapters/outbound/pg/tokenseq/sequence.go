@@ -0,0 +1,54 @@
+package tokenseq
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/dzhordano/urlshortener/internal/core/ports"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	sequenceName = "short_url_seq"
+	base62       = 62
+)
+
+// Generator generates tokens from postgres sequence value encoded in base62.
+//
+// Tokens are left-padded with zeroes up to length, so they're fixed-length until sequence outgrows it.
+type Generator struct {
+	db     *pgxpool.Pool
+	length int
+}
+
+func NewGenerator(db *pgxpool.Pool, length int) (ports.TokenGenerator, error) {
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+
+	if length <= 0 {
+		return nil, errs.NewValueIsInvalidError("length")
+	}
+
+	return &Generator{db: db, length: length}, nil
+}
+
+func (g *Generator) Generate(ctx context.Context, _ string, _ int) (string, error) {
+	const op = "TokenSeq.Generate"
+
+	var n int64
+	err := g.db.QueryRow(ctx, fmt.Sprintf("SELECT nextval('%s')", sequenceName)).Scan(&n)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	encoded := big.NewInt(n).Text(base62)
+	if len(encoded) < g.length {
+		encoded = strings.Repeat("0", g.length-len(encoded)) + encoded
+	}
+
+	return encoded, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/dzhordano/urlshortener/internal/core/domain/model"
 	"github.com/dzhordano/urlshortener/internal/core/ports"
 	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/google/uuid"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -16,6 +17,18 @@ import (
 
 const (
 	urlsTable = "urls"
+
+	// urlColumns are selected by scanURL and inserted by Save and SaveBatch in this order.
+	urlColumns = `id, original_url, short_url, clicks, created_at, valid_until, status, tags, owner_id, redirect_code,
+		preview_title, preview_description, preview_image_url, password_hash, max_clicks, valid_from, fallback_url`
+
+	// insertTargetingRulesQuery inserts targeting rules of url $1 given as arrays of their fields, in order they're
+	// checked. Nothing is inserted if there is no such url, e.g. its batch insert was skipped on conflict.
+	insertTargetingRulesQuery = `INSERT INTO url_targeting_rules (url_id, position, country, os, device, target_url)
+		SELECT $1, r.position, r.country, r.os, r.device, r.target_url
+		FROM unnest($2::TEXT[], $3::TEXT[], $4::TEXT[], $5::TEXT[])
+			WITH ORDINALITY AS r(country, os, device, target_url, position)
+		WHERE EXISTS (SELECT 1 FROM urls WHERE id = $1)`
 )
 
 type Repository struct {
@@ -36,21 +49,29 @@ func (r *Repository) Save(ctx context.Context, url *model.ShortenedURL) error {
 	const op = "UrlRepo.Save"
 
 	query := fmt.Sprintf(
-		`INSERT INTO %s (id, original_url, short_url, clicks, created_at, valid_until)
-		VALUES ($1, $2, $3, $4, $5, $6)`,
-		urlsTable)
-
-	_, err := r.db.Exec(
-		ctx,
-		query,
-		url.ID, url.OriginalURL, url.ShortURL, url.Clicks, url.CreatedAtUTC, url.ValidUntilUTC,
-	)
+		`INSERT INTO %s (%s)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`,
+		urlsTable, urlColumns)
+
+	// Url and its targeting rules are saved together, url never redirects without its rules.
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, query, insertArgs(url)...); err != nil {
+			return err
+		}
+
+		if len(url.TargetingRules) == 0 {
+			return nil
+		}
+
+		_, err := tx.Exec(ctx, insertTargetingRulesQuery, targetingRulesArgs(url)...)
+		return err
+	})
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
 			return fmt.Errorf(
 				"%s: %w",
-				op, errs.NewObjectAlreadyExistsError("originalURL", url.OriginalURL),
+				op, errs.NewObjectAlreadyExistsError("shortURL", url.ShortURL),
 			)
 		}
 
@@ -60,6 +81,56 @@ func (r *Repository) Save(ctx context.Context, url *model.ShortenedURL) error {
 	return nil
 }
 
+func (r *Repository) SaveBatch(ctx context.Context, urls []*model.ShortenedURL) ([]error, error) {
+	const op = "UrlRepo.SaveBatch"
+
+	// Conflicts are skipped rather than raised, so that one taken short url doesn't abort the whole batch.
+	query := fmt.Sprintf(
+		`INSERT INTO %s (%s)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+		ON CONFLICT (short_url) DO NOTHING`,
+		urlsTable, urlColumns)
+
+	// Batch is run in one implicit transaction, so urls are saved along with their targeting rules.
+	batch := &pgx.Batch{}
+	for _, url := range urls {
+		batch.Queue(query, insertArgs(url)...)
+		if len(url.TargetingRules) > 0 {
+			batch.Queue(insertTargetingRulesQuery, targetingRulesArgs(url)...)
+		}
+	}
+
+	br := r.db.SendBatch(ctx, batch)
+	defer br.Close()
+
+	saveErrs := make([]error, len(urls))
+	for i, url := range urls {
+		ct, err := br.Exec()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if ct.RowsAffected() == 0 {
+			saveErrs[i] = fmt.Errorf(
+				"%s: %w",
+				op, errs.NewObjectAlreadyExistsError("shortURL", url.ShortURL),
+			)
+		}
+
+		if len(url.TargetingRules) > 0 {
+			if _, err = br.Exec(); err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	if err := br.Close(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saveErrs, nil
+}
+
 func (r *Repository) GetByShortenedURL(
 	ctx context.Context,
 	shortenedURL string,
@@ -67,21 +138,13 @@ func (r *Repository) GetByShortenedURL(
 	const op = "UrlRepo.GetByShortenedURL"
 
 	query := fmt.Sprintf(
-		`SELECT id, original_url, short_url, clicks, created_at, valid_until
+		`SELECT %s
 		FROM %s
-		WHERE short_url = $1`,
-		urlsTable,
+		WHERE short_url = $1 AND deleted_at IS NULL`,
+		urlColumns, urlsTable,
 	)
 
-	var url model.ShortenedURL
-	err := r.db.QueryRow(ctx, query, shortenedURL).Scan(
-		&url.ID,
-		&url.OriginalURL,
-		&url.ShortURL,
-		&url.Clicks,
-		&url.CreatedAtUTC,
-		&url.ValidUntilUTC,
-	)
+	url, err := scanURL(r.db.QueryRow(ctx, query, shortenedURL))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, fmt.Errorf(
@@ -93,40 +156,178 @@ func (r *Repository) GetByShortenedURL(
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return &url, nil
+	return url, nil
 }
 
 func (r *Repository) GetByOriginalURL(
 	ctx context.Context,
 	originalURL string,
+	ownerID *uuid.UUID,
 ) (*model.ShortenedURL, error) {
-	const op = "UrlRepo.GetByShortenedURL"
+	const op = "UrlRepo.GetByOriginalURL"
 
 	query := fmt.Sprintf(
-		`SELECT id, original_url, short_url, clicks, created_at, valid_until
+		`SELECT %s
 		FROM %s
-		WHERE original_url = $1`,
+		WHERE original_url = $1
+			AND owner_id IS NOT DISTINCT FROM $2
+			AND status = 'active'
+			AND password_hash = ''
+			AND max_clicks IS NULL
+			AND NOT EXISTS (SELECT 1 FROM url_targeting_rules r WHERE r.url_id = urls.id)
+			AND deleted_at IS NULL
+			AND (valid_from IS NULL OR valid_from <= NOW())
+			AND (valid_until IS NULL OR valid_until > NOW())
+		ORDER BY created_at DESC
+		LIMIT 1`,
+		urlColumns, urlsTable,
+	)
+
+	url, err := scanURL(r.db.QueryRow(ctx, query, originalURL, ownerID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf(
+				"%s: %w",
+				op, errs.NewObjectNotFoundError("originalURL", originalURL),
+			)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return url, nil
+}
+
+func (r *Repository) Update(ctx context.Context, url *model.ShortenedURL) error {
+	const op = "UrlRepo.Update"
+
+	query := fmt.Sprintf(
+		`UPDATE %s
+		SET original_url = $2, valid_until = $3, status = $4, tags = $5, redirect_code = $6
+		WHERE id = $1 AND deleted_at IS NULL`,
 		urlsTable,
 	)
 
-	var url model.ShortenedURL
-	err := r.db.QueryRow(ctx, query, originalURL).Scan(
+	ct, err := r.db.Exec(
+		ctx, query,
+		url.ID, url.OriginalURL, url.ValidUntilUTC, url.Status, tags(url), redirectCode(url),
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.NewObjectNotFoundError("shortenedURL", url.ShortURL))
+	}
+
+	return nil
+}
+
+func (r *Repository) Delete(ctx context.Context, shortenedURL string) error {
+	const op = "UrlRepo.Delete"
+
+	query := fmt.Sprintf(
+		`UPDATE %s
+		SET deleted_at = NOW()
+		WHERE short_url = $1 AND deleted_at IS NULL`,
+		urlsTable,
+	)
+
+	ct, err := r.db.Exec(ctx, query, shortenedURL)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.NewObjectNotFoundError("shortenedURL", shortenedURL))
+	}
+
+	return nil
+}
+
+// tags returns url tags never being nil, since tags column is not nullable.
+func tags(url *model.ShortenedURL) []string {
+	if url.Tags == nil {
+		return []string{}
+	}
+
+	return url.Tags
+}
+
+// redirectCode returns url redirect code, zero one being RedirectCodeMovedPermanently urls always redirected with
+// before code was made configurable.
+func redirectCode(url *model.ShortenedURL) model.RedirectCode {
+	if url.RedirectCode == 0 {
+		return model.RedirectCodeMovedPermanently
+	}
+
+	return url.RedirectCode
+}
+
+// insertArgs returns values of urlColumns of url.
+func insertArgs(url *model.ShortenedURL) []any {
+	var preview model.LinkPreview
+	if url.Preview != nil {
+		preview = *url.Preview
+	}
+
+	return []any{
+		url.ID, url.OriginalURL, url.ShortURL, url.Clicks, url.CreatedAtUTC, url.ValidUntilUTC, url.Status, tags(url),
+		url.OwnerID, redirectCode(url), preview.Title, preview.Description, preview.ImageURL, url.PasswordHash,
+		url.MaxClicks, url.ValidFromUTC, url.FallbackURL,
+	}
+}
+
+// targetingRulesArgs returns arguments of insertTargetingRulesQuery for url.
+func targetingRulesArgs(url *model.ShortenedURL) []any {
+	var (
+		countries  = make([]string, 0, len(url.TargetingRules))
+		oses       = make([]string, 0, len(url.TargetingRules))
+		devices    = make([]string, 0, len(url.TargetingRules))
+		targetURLs = make([]string, 0, len(url.TargetingRules))
+	)
+	for _, r := range url.TargetingRules {
+		countries = append(countries, r.Country)
+		oses = append(oses, r.OS)
+		devices = append(devices, r.Device)
+		targetURLs = append(targetURLs, r.TargetURL)
+	}
+
+	return []any{url.ID, countries, oses, devices, targetURLs}
+}
+
+// scanURL scans row of urlColumns.
+func scanURL(row pgx.Row) (*model.ShortenedURL, error) {
+	var (
+		url     model.ShortenedURL
+		preview model.LinkPreview
+	)
+	err := row.Scan(
 		&url.ID,
 		&url.OriginalURL,
 		&url.ShortURL,
 		&url.Clicks,
 		&url.CreatedAtUTC,
 		&url.ValidUntilUTC,
+		&url.Status,
+		&url.Tags,
+		&url.OwnerID,
+		&url.RedirectCode,
+		&preview.Title,
+		&preview.Description,
+		&preview.ImageURL,
+		&url.PasswordHash,
+		&url.MaxClicks,
+		&url.ValidFromUTC,
+		&url.FallbackURL,
 	)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf(
-				"%s: %w",
-				op, errs.NewObjectNotFoundError("originalURL", originalURL),
-			)
-		}
+		return nil, err
+	}
 
-		return nil, fmt.Errorf("%s: %w", op, err)
+	// Empty title means there is no preview, since title is required.
+	if preview.Title != "" {
+		url.Preview = &preview
 	}
 
 	return &url, nil
@@ -0,0 +1,67 @@
+package passwordattempts
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/ports"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/redis/go-redis/v9"
+)
+
+// Keys contain ':', which short urls can't, so they never clash with cached urls.
+const attemptsKeyPrefix = "password-attempts:"
+
+// Limiter counts failed password attempts of urls in redis, so the limit is shared by app instances.
+// Failures are counted in fixed windows starting with the first failure. Once maxAttempts failures are
+// counted, no attempts are allowed till the window ends, even with the right password.
+type Limiter struct {
+	rdb         *redis.Client
+	maxAttempts int
+	window      time.Duration
+}
+
+func NewRedisPasswordAttemptLimiter(
+	rdb *redis.Client,
+	maxAttempts int,
+	window time.Duration,
+) (ports.PasswordAttemptLimiter, error) {
+	if rdb == nil {
+		return nil, errs.NewValueIsRequiredError("rdb")
+	}
+
+	if maxAttempts <= 0 {
+		return nil, errs.NewValueIsInvalidError("maxAttempts")
+	}
+
+	if window <= 0 {
+		return nil, errs.NewValueIsInvalidError("window")
+	}
+
+	return &Limiter{rdb: rdb, maxAttempts: maxAttempts, window: window}, nil
+}
+
+func (l *Limiter) Allow(ctx context.Context, shortURL string) (bool, error) {
+	failures, err := l.rdb.Get(ctx, attemptsKeyPrefix+shortURL).Int()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return failures < l.maxAttempts, nil
+}
+
+func (l *Limiter) Fail(ctx context.Context, shortURL string) error {
+	key := attemptsKeyPrefix + shortURL
+
+	pipe := l.rdb.TxPipeline()
+	pipe.Incr(ctx, key)
+	// Window isn't prolonged by failures made in it.
+	pipe.ExpireNX(ctx, key, l.window)
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
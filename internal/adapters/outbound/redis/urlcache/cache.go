@@ -2,19 +2,70 @@ package urlcache
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
 	"github.com/dzhordano/urlshortener/internal/core/ports"
 	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
+const (
+	// emptyValue is cached to mark absence of value.
+	emptyValue = ""
+	// cachedURLVersion is bumped when fields redirect security depends on are added to cachedURL.
+	// Values of older versions are treated as missing, so they're never trusted to lack such fields.
+	cachedURLVersion = 5
+)
+
 type Cache struct {
 	rdb *redis.Client
 	ttl time.Duration
 }
 
+// cachedURL is cache representation of model.ShortenedURL.
+type cachedURL struct {
+	// Version is zero in values cached before versioning was added.
+	Version      int       `json:"version,omitempty"`
+	ID           uuid.UUID `json:"id"`
+	OriginalURL  string    `json:"original_url"`
+	ShortURL     string    `json:"short_url"`
+	CreatedAtUTC time.Time `json:"created_at"`
+	// ValidFromUTC is cached, so cached urls aren't redirected with before they become active.
+	ValidFromUTC  *time.Time      `json:"valid_from,omitempty"`
+	ValidUntilUTC *time.Time      `json:"valid_until,omitempty"`
+	Status        model.URLStatus `json:"status"`
+	// RedirectCode is zero in values cached before it was added.
+	RedirectCode model.RedirectCode `json:"redirect_code,omitempty"`
+	Preview      *cachedPreview     `json:"preview,omitempty"`
+	// PasswordHash is cached along with url, so redirects of cached urls still check password.
+	PasswordHash string `json:"password_hash,omitempty"`
+	// MaxClicks is cached, so redirects of cached urls still count clicks against the limit.
+	MaxClicks   *int   `json:"max_clicks,omitempty"`
+	FallbackURL string `json:"fallback_url,omitempty"`
+	// TargetingRules are cached, so cached urls still redirect visitors to their targeted destinations.
+	TargetingRules []cachedTargetingRule `json:"targeting_rules,omitempty"`
+}
+
+// cachedTargetingRule is cache representation of model.TargetingRule.
+type cachedTargetingRule struct {
+	Country   string `json:"country,omitempty"`
+	OS        string `json:"os,omitempty"`
+	Device    string `json:"device,omitempty"`
+	TargetURL string `json:"target_url"`
+}
+
+// cachedPreview is cache representation of model.LinkPreview.
+type cachedPreview struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	ImageURL    string `json:"image_url,omitempty"`
+}
+
 func NewRedisCache(rdb *redis.Client, ttl time.Duration) (ports.URLCache, error) {
 	if rdb == nil {
 		return nil, errs.NewValueIsRequiredError("rdb")
@@ -23,7 +74,12 @@ func NewRedisCache(rdb *redis.Client, ttl time.Duration) (ports.URLCache, error)
 	return &Cache{rdb: rdb, ttl: ttl}, nil
 }
 
-func (c *Cache) Set(ctx context.Context, key string, value string) error {
+func (c *Cache) Set(ctx context.Context, key string, url *model.ShortenedURL) error {
+	value, err := marshalURL(url)
+	if err != nil {
+		return err
+	}
+
 	sc := c.rdb.Set(ctx, key, value, c.ttl)
 	if sc.Err() != nil {
 		return sc.Err()
@@ -32,14 +88,138 @@ func (c *Cache) Set(ctx context.Context, key string, value string) error {
 	return nil
 }
 
-func (c *Cache) Get(ctx context.Context, key string) (string, error) {
+func (c *Cache) SetMany(ctx context.Context, urls []*model.ShortenedURL) error {
+	if len(urls) == 0 {
+		return nil
+	}
+
+	pipe := c.rdb.Pipeline()
+	for _, url := range urls {
+		value, err := marshalURL(url)
+		if err != nil {
+			return err
+		}
+		pipe.Set(ctx, url.ShortURL, value, c.ttl)
+	}
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// marshalURL returns cache value of url. Nil url is marshaled to emptyValue.
+func marshalURL(url *model.ShortenedURL) (string, error) {
+	if url == nil {
+		return emptyValue, nil
+	}
+
+	cu := cachedURL{
+		Version:        cachedURLVersion,
+		ID:             url.ID,
+		OriginalURL:    url.OriginalURL,
+		ShortURL:       url.ShortURL,
+		CreatedAtUTC:   url.CreatedAtUTC,
+		ValidFromUTC:   url.ValidFromUTC,
+		ValidUntilUTC:  url.ValidUntilUTC,
+		Status:         url.Status,
+		RedirectCode:   url.RedirectCode,
+		Preview:        nil,
+		PasswordHash:   url.PasswordHash,
+		MaxClicks:      url.MaxClicks,
+		FallbackURL:    url.FallbackURL,
+		TargetingRules: nil,
+	}
+	if url.Preview != nil {
+		cu.Preview = &cachedPreview{
+			Title:       url.Preview.Title,
+			Description: url.Preview.Description,
+			ImageURL:    url.Preview.ImageURL,
+		}
+	}
+
+	for _, r := range url.TargetingRules {
+		cu.TargetingRules = append(cu.TargetingRules, cachedTargetingRule{
+			Country:   r.Country,
+			OS:        r.OS,
+			Device:    r.Device,
+			TargetURL: r.TargetURL,
+		})
+	}
+
+	b, err := json.Marshal(cu)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling url: %w", err)
+	}
+
+	return string(b), nil
+}
+
+func (c *Cache) Get(ctx context.Context, key string) (*model.ShortenedURL, error) {
 	sc := c.rdb.Get(ctx, key)
 	if sc.Err() != nil {
 		if errors.Is(sc.Err(), redis.Nil) {
-			return "", errs.NewObjectNotFoundError("key", key)
+			return nil, errs.NewObjectNotFoundError("key", key)
+		}
+		return nil, sc.Err()
+	}
+
+	if sc.Val() == emptyValue {
+		return nil, nil //nolint:nilnil // Cached absence of value.
+	}
+
+	var cu cachedURL
+	if err := json.Unmarshal([]byte(sc.Val()), &cu); err != nil {
+		return nil, fmt.Errorf("error unmarshaling cached url: %w", err)
+	}
+
+	// Outdated value may lack password hash of protected url, click limit, activation moment, targeting rules
+	// or their countries.
+	if cu.Version < cachedURLVersion {
+		return nil, errs.NewObjectNotFoundError("key", key)
+	}
+
+	redirectCode := cu.RedirectCode
+	if redirectCode == 0 {
+		redirectCode = model.RedirectCodeMovedPermanently
+	}
+
+	var preview *model.LinkPreview
+	if cu.Preview != nil {
+		preview = &model.LinkPreview{
+			Title:       cu.Preview.Title,
+			Description: cu.Preview.Description,
+			ImageURL:    cu.Preview.ImageURL,
 		}
-		return "", sc.Err()
 	}
 
-	return sc.Val(), nil
+	var rules []model.TargetingRule
+	for _, r := range cu.TargetingRules {
+		rules = append(rules, model.TargetingRule{
+			Country:   r.Country,
+			OS:        r.OS,
+			Device:    r.Device,
+			TargetURL: r.TargetURL,
+		})
+	}
+
+	return &model.ShortenedURL{
+		ID:             cu.ID,
+		OriginalURL:    cu.OriginalURL,
+		ShortURL:       cu.ShortURL,
+		Clicks:         0,
+		CreatedAtUTC:   cu.CreatedAtUTC,
+		ValidFromUTC:   cu.ValidFromUTC,
+		ValidUntilUTC:  cu.ValidUntilUTC,
+		Status:         cu.Status,
+		RedirectCode:   redirectCode,
+		Preview:        preview,
+		FallbackURL:    cu.FallbackURL,
+		TargetingRules: rules,
+		MaxClicks:      cu.MaxClicks,
+		PasswordHash:   cu.PasswordHash,
+		DeletedAtUTC:   nil,
+	}, nil
+}
+
+func (c *Cache) Delete(ctx context.Context, key string) error {
+	return c.rdb.Del(ctx, key).Err()
 }
@@ -0,0 +1,106 @@
+package visitorcounter
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/ports"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// Keys contain ':', which short urls can't, so they never clash with cached urls.
+	visitorsKeyPrefix = "visitors:"
+	saltKeyPrefix     = "visitors-salt:"
+
+	saltSize = 32
+	// saltTTL outlives the day salt is used in, so instances with skewed clocks still find it.
+	saltTTL = 48 * time.Hour
+)
+
+// Counter counts distinct visitors of urls in redis HyperLogLogs. Visitors are added as hashes of ip
+// and user agent salted with random salt of the current day (UTC). Salt is shared by app instances via redis
+// and expires, so hashes can't be traced back to visitors afterward. Price of it is that visitor coming back
+// next day is counted again.
+type Counter struct {
+	rdb *redis.Client
+
+	mu      sync.Mutex
+	saltDay string
+	salt    []byte
+}
+
+func NewRedisVisitorCounter(rdb *redis.Client) (ports.VisitorCounter, error) {
+	if rdb == nil {
+		return nil, errs.NewValueIsRequiredError("rdb")
+	}
+
+	return &Counter{rdb: rdb}, nil
+}
+
+func (c *Counter) Add(ctx context.Context, shortURL, ip, userAgent string) error {
+	salt, err := c.daySalt(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	h := sha256.New()
+	h.Write(salt)
+	h.Write([]byte(ip))
+	h.Write([]byte{0})
+	h.Write([]byte(userAgent))
+
+	return c.rdb.PFAdd(ctx, visitorsKeyPrefix+shortURL, hex.EncodeToString(h.Sum(nil))).Err()
+}
+
+func (c *Counter) Count(ctx context.Context, shortURL string) (int, error) {
+	n, err := c.rdb.PFCount(ctx, visitorsKeyPrefix+shortURL).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(n), nil
+}
+
+func (c *Counter) Delete(ctx context.Context, shortURL string) error {
+	return c.rdb.Del(ctx, visitorsKeyPrefix+shortURL).Err()
+}
+
+// daySalt returns salt of t's day. Salt is generated by the first instance needing it and kept in memory
+// until day changes.
+func (c *Counter) daySalt(ctx context.Context, t time.Time) ([]byte, error) {
+	day := t.UTC().Format(time.DateOnly)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.saltDay == day {
+		return c.salt, nil
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("error generating visitors salt: %w", err)
+	}
+
+	// Salt of another instance wins if it was set first.
+	key := saltKeyPrefix + day
+	if err := c.rdb.SetNX(ctx, key, salt, saltTTL).Err(); err != nil {
+		return nil, fmt.Errorf("error saving visitors salt: %w", err)
+	}
+
+	stored, err := c.rdb.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("error getting visitors salt: %w", err)
+	}
+
+	c.saltDay, c.salt = day, stored
+
+	return stored, nil
+}
@@ -0,0 +1,57 @@
+package tokengen
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/dzhordano/urlshortener/internal/core/ports"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+)
+
+const base62 = 62
+
+// deterministicAttempts is how many first attempts hash just original url and attempt number.
+// Tokens of later attempts would be taken once url was shortened that many times, by any owners,
+// so random nonce is mixed into their hash.
+const deterministicAttempts = 2
+
+// HashGenerator generates tokens from sha256 hash of original url encoded in base62.
+//
+// Same url always results in the same token on the same one of deterministicAttempts first attempts,
+// attempt number is mixed into hash so retries produce different tokens.
+type HashGenerator struct {
+	length int
+}
+
+func NewHashGenerator(length int) (ports.TokenGenerator, error) {
+	if length <= 0 {
+		return nil, errs.NewValueIsInvalidError("length")
+	}
+
+	return &HashGenerator{length: length}, nil
+}
+
+func (g *HashGenerator) Generate(_ context.Context, originalURL string, attempt int) (string, error) {
+	input := originalURL
+	if attempt > 0 {
+		input += "#" + strconv.Itoa(attempt)
+	}
+
+	if attempt >= deterministicAttempts {
+		input += "#" + rand.Text()
+	}
+
+	sum := sha256.Sum256([]byte(input))
+	encoded := new(big.Int).SetBytes(sum[:]).Text(base62)
+
+	// 256 bits are ~43 base62 chars, so padding is needed only for really long tokens.
+	if len(encoded) < g.length {
+		encoded = strings.Repeat("0", g.length-len(encoded)) + encoded
+	}
+
+	return encoded[:g.length], nil
+}
@@ -0,0 +1,53 @@
+//nolint:nolintlint,exhaustruct,testpackage
+package tokengen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashGenerator_Generate(t *testing.T) {
+	ctx := context.Background()
+
+	g, err := NewHashGenerator(8)
+	require.NoError(t, err)
+
+	first, err := g.Generate(ctx, "https://example.com", 0)
+	require.NoError(t, err)
+
+	same, err := g.Generate(ctx, "https://example.com", 0)
+	require.NoError(t, err)
+
+	retried, err := g.Generate(ctx, "https://example.com", 1)
+	require.NoError(t, err)
+
+	// Same url and attempt results in same token, retry results in another one
+	assert.Len(t, first, 8)
+	assert.Equal(t, first, same)
+	assert.NotEqual(t, first, retried)
+}
+
+func TestHashGenerator_Generate_RandomizesLaterAttempts(t *testing.T) {
+	ctx := context.Background()
+
+	g, err := NewHashGenerator(8)
+	require.NoError(t, err)
+
+	first, err := g.Generate(ctx, "https://example.com", deterministicAttempts)
+	require.NoError(t, err)
+
+	second, err := g.Generate(ctx, "https://example.com", deterministicAttempts)
+	require.NoError(t, err)
+
+	// Url shortened many times already still gets a token nobody has
+	assert.Len(t, first, 8)
+	assert.NotEqual(t, first, second)
+}
+
+func TestNewHashGenerator_InvalidLength(t *testing.T) {
+	_, err := NewHashGenerator(0)
+	require.Error(t, err)
+}
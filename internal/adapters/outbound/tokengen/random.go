@@ -0,0 +1,26 @@
+package tokengen
+
+import (
+	"context"
+
+	"github.com/dzhordano/urlshortener/internal/core/ports"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/random"
+)
+
+// RandomGenerator generates random base62 tokens.
+type RandomGenerator struct {
+	length int
+}
+
+func NewRandomGenerator(length int) (ports.TokenGenerator, error) {
+	if length <= 0 {
+		return nil, errs.NewValueIsInvalidError("length")
+	}
+
+	return &RandomGenerator{length: length}, nil
+}
+
+func (g *RandomGenerator) Generate(_ context.Context, _ string, _ int) (string, error) {
+	return random.NewRandomString(g.length), nil
+}
@@ -0,0 +1,151 @@
+//nolint:nolintlint,exhaustruct
+package commands
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/core/ports"
+	"github.com/dzhordano/urlshortener/internal/pkg/auth"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/logger"
+	"github.com/dzhordano/urlshortener/internal/pkg/tracing"
+)
+
+// lastUsedResolution bounds how often key's last used moment is written, so every request doesn't result in write.
+const lastUsedResolution = time.Minute
+
+// ErrInvalidAPIKey is returned for unknown, revoked or expired api keys.
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+const (
+	// BootstrapAdminKeyMinLength bounds length of bootstrap admin key, so it can't be guessed.
+	BootstrapAdminKeyMinLength = 32
+	// bootstrapAdminKeyMinDistinct bounds amount of distinct characters of bootstrap admin key, so long but
+	// trivial keys like repeated single character are rejected too.
+	bootstrapAdminKeyMinDistinct = 8
+)
+
+// ValidateBootstrapAdminKey checks bootstrap admin key is not trivially weak. Empty key disables it and is valid.
+func ValidateBootstrapAdminKey(key string) error {
+	if key == "" {
+		return nil
+	}
+
+	if len(key) < BootstrapAdminKeyMinLength {
+		return errs.NewValueIsInvalidErrorWithCause(
+			"bootstrapAdminKey",
+			fmt.Errorf("must be at least %d characters long", BootstrapAdminKeyMinLength),
+		)
+	}
+
+	distinct := make(map[rune]struct{})
+	for _, c := range key {
+		distinct[c] = struct{}{}
+	}
+	if len(distinct) < bootstrapAdminKeyMinDistinct {
+		return errs.NewValueIsInvalidErrorWithCause(
+			"bootstrapAdminKey",
+			fmt.Errorf("must consist of at least %d distinct characters", bootstrapAdminKeyMinDistinct),
+		)
+	}
+
+	return nil
+}
+
+type AuthenticateCommand struct {
+	Key string
+}
+
+func NewAuthenticateCommand(key string) (AuthenticateCommand, error) {
+	if key == "" {
+		return AuthenticateCommand{}, errs.NewValueIsRequiredError("key")
+	}
+
+	return AuthenticateCommand{Key: key}, nil
+}
+
+type AuthenticateCommandHandler interface {
+	// Handle returns principal key belongs to. ErrInvalidAPIKey is returned if key can't be used.
+	Handle(context.Context, AuthenticateCommand) (auth.Principal, error)
+}
+
+type authenticateCommandHandler struct {
+	log        logger.Logger
+	apiKeyRepo ports.APIKeyRepository
+	// bootstrapAdminKey is admin key from config used to create the first stored keys. Empty if disabled.
+	bootstrapAdminKey string
+}
+
+func NewAuthenticateCommandHandler(
+	log logger.Logger,
+	apiKeyRepo ports.APIKeyRepository,
+	bootstrapAdminKey string,
+) (AuthenticateCommandHandler, error) {
+	if log == nil {
+		return nil, errs.NewValueIsRequiredError("log")
+	}
+
+	if apiKeyRepo == nil {
+		return nil, errs.NewValueIsRequiredError("apiKeyRepo")
+	}
+
+	if err := ValidateBootstrapAdminKey(bootstrapAdminKey); err != nil {
+		return nil, err
+	}
+
+	return &authenticateCommandHandler{
+		log:               log,
+		apiKeyRepo:        apiKeyRepo,
+		bootstrapAdminKey: bootstrapAdminKey,
+	}, nil
+}
+
+func (h *authenticateCommandHandler) Handle(
+	ctx context.Context,
+	cmd AuthenticateCommand,
+) (auth.Principal, error) {
+	ctx, span := tracing.StartSpan(ctx, "AuthenticateCommandHandler.Handle")
+	defer span.End()
+
+	if h.bootstrapAdminKey != "" &&
+		subtle.ConstantTimeCompare([]byte(cmd.Key), []byte(h.bootstrapAdminKey)) == 1 {
+		return auth.Principal{Name: "bootstrap", Role: string(model.RoleAdmin), Scopes: model.RoleAdmin.Scopes()}, nil
+	}
+
+	prefix, err := model.APIKeyPrefix(cmd.Key)
+	if err != nil {
+		return auth.Principal{}, ErrInvalidAPIKey
+	}
+
+	key, err := h.apiKeyRepo.GetByPrefix(ctx, prefix)
+	span.AddEvent("api key lookup performed")
+	if err != nil {
+		if errors.Is(err, errs.ErrObjectNotFound) {
+			return auth.Principal{}, ErrInvalidAPIKey
+		}
+
+		span.RecordError(err)
+		h.log.Error("error getting api key", "error", err)
+		return auth.Principal{}, err
+	}
+
+	now := time.Now().UTC()
+	if !key.Matches(cmd.Key) || !key.IsUsable(now) {
+		h.log.Debug("api key rejected", "prefix", prefix)
+		return auth.Principal{}, ErrInvalidAPIKey
+	}
+
+	if key.LastUsedAtUTC == nil || now.Sub(*key.LastUsedAtUTC) >= lastUsedResolution {
+		if err = h.apiKeyRepo.TouchLastUsed(ctx, key.ID, now); err != nil {
+			span.RecordError(err)
+			h.log.Error("error updating api key last used", "id", key.ID, "error", err)
+		}
+	}
+
+	return auth.Principal{KeyID: key.ID, Name: key.Name, Role: string(key.Role), Scopes: key.GrantedScopes()}, nil
+}
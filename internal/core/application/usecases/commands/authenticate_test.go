@@ -0,0 +1,176 @@
+//nolint:nolintlint,exhaustruct,testpackage
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/logger"
+	"github.com/dzhordano/urlshortener/mocks/core/ports_mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticateCommandHandler(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	recently := time.Now().Add(-time.Second)
+
+	tt := []struct {
+		name        string
+		modify      func(k *model.APIKey)
+		key         func(key string) string
+		expectErr   error
+		expectTouch bool
+	}{
+		{
+			name:        "valid",
+			modify:      func(*model.APIKey) {},
+			key:         func(key string) string { return key },
+			expectTouch: true,
+		},
+		{
+			name:   "recently used",
+			modify: func(k *model.APIKey) { k.LastUsedAtUTC = &recently },
+			key:    func(key string) string { return key },
+		},
+		{
+			name:      "wrong secret",
+			modify:    func(*model.APIKey) {},
+			key:       func(key string) string { return key + "0" },
+			expectErr: ErrInvalidAPIKey,
+		},
+		{
+			name:      "revoked",
+			modify:    func(k *model.APIKey) { k.Revoked = true },
+			key:       func(key string) string { return key },
+			expectErr: ErrInvalidAPIKey,
+		},
+		{
+			name:      "expired",
+			modify:    func(k *model.APIKey) { k.ExpiresAtUTC = &past },
+			key:       func(key string) string { return key },
+			expectErr: ErrInvalidAPIKey,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			apiKey, key, err := model.NewAPIKey("ci", model.RoleViewer, []string{model.ScopeLinksCreate}, nil)
+			require.NoError(t, err)
+			tc.modify(apiKey)
+
+			rm := ports_mocks.NewAPIKeyRepositoryMock(t)
+			l, err := logger.NewSlogLogger(true, "debug")
+			require.NoError(t, err)
+
+			rm.On("GetByPrefix", mock.Anything, apiKey.Prefix).Return(apiKey, nil).Once()
+			if tc.expectTouch {
+				rm.On("TouchLastUsed", mock.Anything, apiKey.ID, mock.Anything).Return(nil).Once()
+			}
+
+			ch, _ := NewAuthenticateCommandHandler(l, rm, "")
+			principal, err := ch.Handle(ctx, AuthenticateCommand{Key: tc.key(key)})
+
+			if tc.expectErr != nil {
+				require.ErrorIs(t, err, tc.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, apiKey.ID, principal.KeyID)
+			// Principal is granted role's scopes and additional ones.
+			assert.Equal(t, string(model.RoleViewer), principal.Role)
+			assert.True(t, principal.HasScope(model.ScopeLinksReadStats))
+			assert.True(t, principal.HasScope(model.ScopeLinksCreate))
+			assert.False(t, principal.HasScope(model.ScopeLinksDelete))
+		})
+	}
+}
+
+func TestAuthenticateCommandHandler_UnknownKey(t *testing.T) {
+	rm := ports_mocks.NewAPIKeyRepositoryMock(t)
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	rm.On("GetByPrefix", mock.Anything, "usk_01234567").
+		Return(nil, errs.NewObjectNotFoundError("prefix", "usk_01234567")).
+		Once()
+
+	ch, _ := NewAuthenticateCommandHandler(l, rm, "")
+
+	_, err = ch.Handle(context.Background(), AuthenticateCommand{Key: "usk_0123456789abcdef"})
+	require.ErrorIs(t, err, ErrInvalidAPIKey)
+
+	// Malformed keys are rejected without lookup.
+	_, err = ch.Handle(context.Background(), AuthenticateCommand{Key: "admin"})
+	require.ErrorIs(t, err, ErrInvalidAPIKey)
+}
+
+func TestAuthenticateCommandHandler_BootstrapAdminKey(t *testing.T) {
+	rm := ports_mocks.NewAPIKeyRepositoryMock(t)
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	const key = "4f1c9a7e2b8d6035c1e7f9a2b4d8e6c0"
+
+	ch, err := NewAuthenticateCommandHandler(l, rm, key)
+	require.NoError(t, err)
+
+	principal, err := ch.Handle(context.Background(), AuthenticateCommand{Key: key})
+	require.NoError(t, err)
+	assert.True(t, principal.HasScope(model.ScopeAdmin))
+	assert.True(t, principal.HasScope(model.ScopeAdminKeys))
+}
+
+func TestNewAuthenticateCommandHandler_WeakBootstrapAdminKey(t *testing.T) {
+	rm := ports_mocks.NewAPIKeyRepositoryMock(t)
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	for _, key := range []string{"admin", "bootstrap-secret", strings.Repeat("ab", 20)} {
+		_, err = NewAuthenticateCommandHandler(l, rm, key)
+		require.ErrorIs(t, err, errs.ErrValueIsInvalid, key)
+	}
+}
+
+func TestCreateAPIKeyCommandHandler(t *testing.T) {
+	rm := ports_mocks.NewAPIKeyRepositoryMock(t)
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	var saved *model.APIKey
+	rm.On("Save", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { saved, _ = args.Get(1).(*model.APIKey) }).
+		Return(nil).
+		Once()
+
+	ch, _ := NewCreateAPIKeyCommandHandler(l, rm)
+	res, err := ch.Handle(context.Background(), CreateAPIKeyCommand{Name: "ci", Role: model.RoleAdmin})
+	require.NoError(t, err)
+
+	require.NotNil(t, saved)
+	// Only hash is stored, plain text key is returned once.
+	assert.NotContains(t, saved.Hash, res.Key)
+	assert.True(t, saved.Matches(res.Key))
+	assert.Equal(t, saved.Prefix, res.Key[:model.APIKeyPrefixLength])
+}
+
+func TestNewCreateAPIKeyCommand_UnknownScope(t *testing.T) {
+	_, err := NewCreateAPIKeyCommand("ci", string(model.RoleEditor), []string{"root"}, nil)
+	require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
+
+func TestNewCreateAPIKeyCommand_UnknownRole(t *testing.T) {
+	_, err := NewCreateAPIKeyCommand("ci", "root", nil, nil)
+	require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+
+	_, err = NewCreateAPIKeyCommand("ci", "", nil, nil)
+	require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
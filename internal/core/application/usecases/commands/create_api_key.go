@@ -0,0 +1,109 @@
+//nolint:nolintlint,exhaustruct
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/core/ports"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/logger"
+	"github.com/dzhordano/urlshortener/internal/pkg/tracing"
+)
+
+type CreateAPIKeyCommand struct {
+	Name string
+	Role model.Role
+	// Scopes are granted in addition to role's ones.
+	Scopes []string
+	// ExpiresAt is nil for never expiring key.
+	ExpiresAt *time.Time
+}
+
+func NewCreateAPIKeyCommand(
+	name string,
+	role string,
+	scopes []string,
+	expiresAt *time.Time,
+) (CreateAPIKeyCommand, error) {
+	if name == "" {
+		return CreateAPIKeyCommand{}, errs.NewValueIsRequiredError("name")
+	}
+
+	r, err := model.ParseRole(role)
+	if err != nil {
+		return CreateAPIKeyCommand{}, err
+	}
+
+	scopes, err = model.NormalizeScopes(scopes)
+	if err != nil {
+		return CreateAPIKeyCommand{}, err
+	}
+
+	return CreateAPIKeyCommand{
+		Name:      name,
+		Role:      r,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+type CreateAPIKeyResult struct {
+	// Key is plain text key. It is not stored, so can't be retrieved later.
+	Key    string
+	APIKey model.APIKey
+}
+
+type CreateAPIKeyCommandHandler interface {
+	Handle(context.Context, CreateAPIKeyCommand) (CreateAPIKeyResult, error)
+}
+
+type createAPIKeyCommandHandler struct {
+	log        logger.Logger
+	apiKeyRepo ports.APIKeyRepository
+}
+
+func NewCreateAPIKeyCommandHandler(
+	log logger.Logger,
+	apiKeyRepo ports.APIKeyRepository,
+) (CreateAPIKeyCommandHandler, error) {
+	if log == nil {
+		return nil, errs.NewValueIsRequiredError("log")
+	}
+
+	if apiKeyRepo == nil {
+		return nil, errs.NewValueIsRequiredError("apiKeyRepo")
+	}
+
+	return &createAPIKeyCommandHandler{
+		log:        log,
+		apiKeyRepo: apiKeyRepo,
+	}, nil
+}
+
+func (h *createAPIKeyCommandHandler) Handle(
+	ctx context.Context,
+	cmd CreateAPIKeyCommand,
+) (CreateAPIKeyResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "CreateAPIKeyCommandHandler.Handle")
+	defer span.End()
+
+	apiKey, key, err := model.NewAPIKey(cmd.Name, cmd.Role, cmd.Scopes, cmd.ExpiresAt)
+	if err != nil {
+		span.RecordError(err)
+		return CreateAPIKeyResult{}, err
+	}
+
+	err = h.apiKeyRepo.Save(ctx, apiKey)
+	span.AddEvent("api key save attempt performed")
+	if err != nil {
+		span.RecordError(err)
+		h.log.Error("error saving api key", "error", err)
+		return CreateAPIKeyResult{}, err
+	}
+
+	h.log.Info("api key created", "id", apiKey.ID, "prefix", apiKey.Prefix, "role", apiKey.Role, "scopes", apiKey.Scopes)
+
+	return CreateAPIKeyResult{Key: key, APIKey: *apiKey}, nil
+}
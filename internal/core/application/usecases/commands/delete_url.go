@@ -0,0 +1,112 @@
+//nolint:nolintlint,exhaustruct
+package commands
+
+import (
+	"context"
+
+	"github.com/dzhordano/urlshortener/internal/core/ports"
+	"github.com/dzhordano/urlshortener/internal/pkg/auth"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/logger"
+	"github.com/dzhordano/urlshortener/internal/pkg/tracing"
+)
+
+type DeleteURLCommand struct {
+	// Principal is the one deleting url. Only url owner or admin may delete it.
+	Principal auth.Principal
+	ShortURL  string
+}
+
+func NewDeleteURLCommand(principal auth.Principal, shortURL string) (DeleteURLCommand, error) {
+	if shortURL == "" {
+		return DeleteURLCommand{}, errs.NewValueIsInvalidError("shortURL")
+	}
+
+	return DeleteURLCommand{Principal: principal, ShortURL: shortURL}, nil
+}
+
+type DeleteURLCommandHandler interface {
+	Handle(context.Context, DeleteURLCommand) error
+}
+
+type deleteURLCommandHandler struct {
+	log      logger.Logger
+	cache    ports.URLCache
+	urlRepo  ports.URLRepository
+	visitors ports.VisitorCounter
+}
+
+func NewDeleteURLCommandHandler(
+	log logger.Logger,
+	cache ports.URLCache,
+	urlRepo ports.URLRepository,
+	visitors ports.VisitorCounter,
+) (DeleteURLCommandHandler, error) {
+	if log == nil {
+		return nil, errs.NewValueIsRequiredError("log")
+	}
+
+	if cache == nil {
+		return nil, errs.NewValueIsRequiredError("cache")
+	}
+
+	if urlRepo == nil {
+		return nil, errs.NewValueIsRequiredError("urlRepo")
+	}
+
+	if visitors == nil {
+		return nil, errs.NewValueIsRequiredError("visitors")
+	}
+
+	return &deleteURLCommandHandler{
+		log:      log,
+		cache:    cache,
+		urlRepo:  urlRepo,
+		visitors: visitors,
+	}, nil
+}
+
+func (h *deleteURLCommandHandler) Handle(ctx context.Context, cmd DeleteURLCommand) error {
+	ctx, span := tracing.StartSpan(ctx, "DeleteURLCommandHandler.Handle")
+	defer span.End()
+
+	url, err := h.urlRepo.GetByShortenedURL(ctx, cmd.ShortURL)
+	span.AddEvent("url retrieval from db attempt performed")
+	if err != nil {
+		span.RecordError(err)
+		h.log.Error("error getting url", "error", err)
+		return err
+	}
+
+	// Not found is returned to not leak existence of someone else's url.
+	if !canManage(cmd.Principal, url) {
+		span.AddEvent("url delete denied")
+		return errs.NewObjectNotFoundError("shortenedURL", cmd.ShortURL)
+	}
+
+	err = h.urlRepo.Delete(ctx, cmd.ShortURL)
+	span.AddEvent("url delete attempt performed")
+	if err != nil {
+		span.RecordError(err)
+		h.log.Error("error deleting url", "error", err)
+		return err
+	}
+
+	// Invalidate cache so redirects stop right away.
+	err = h.cache.Delete(ctx, cmd.ShortURL)
+	if err != nil {
+		span.RecordError(err)
+		h.log.Error("error invalidating cached url", "short_url", cmd.ShortURL, "error", err)
+	}
+
+	// Visitors are forgotten along with url.
+	err = h.visitors.Delete(ctx, cmd.ShortURL)
+	if err != nil {
+		span.RecordError(err)
+		h.log.Error("error deleting url visitors", "short_url", cmd.ShortURL, "error", err)
+	}
+
+	h.log.Debug("url deleted", "short_url", cmd.ShortURL)
+
+	return nil
+}
@@ -0,0 +1,110 @@
+//nolint:nolintlint,exhaustruct,testpackage
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/auth"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/logger"
+	"github.com/dzhordano/urlshortener/mocks/core/ports_mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteURLCommandHandler_Success(t *testing.T) {
+	ctx := context.Background()
+	cmd := DeleteURLCommand{Principal: testAdmin, ShortURL: "RAND0000"}
+
+	rm := ports_mocks.NewURLRepositoryMock(t)
+	cm := ports_mocks.NewURLCacheMock(t)
+	vm := ports_mocks.NewVisitorCounterMock(t)
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	rm.On("GetByShortenedURL", mock.Anything, cmd.ShortURL).
+		Return(&model.ShortenedURL{ShortURL: cmd.ShortURL}, nil).
+		Once()
+	rm.On("Delete", mock.Anything, cmd.ShortURL).Return(nil).Once()
+	// Cached url must be invalidated and its visitors forgotten.
+	cm.On("Delete", mock.Anything, cmd.ShortURL).Return(nil).Once()
+	vm.On("Delete", mock.Anything, cmd.ShortURL).Return(nil).Once()
+
+	ch, _ := NewDeleteURLCommandHandler(l, cm, rm, vm)
+	err = ch.Handle(ctx, cmd)
+
+	require.NoError(t, err)
+}
+
+func TestDeleteURLCommandHandler_CacheErrorIgnored(t *testing.T) {
+	ctx := context.Background()
+	cmd := DeleteURLCommand{Principal: testAdmin, ShortURL: "RAND0000"}
+
+	rm := ports_mocks.NewURLRepositoryMock(t)
+	cm := ports_mocks.NewURLCacheMock(t)
+	vm := ports_mocks.NewVisitorCounterMock(t)
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	rm.On("GetByShortenedURL", mock.Anything, cmd.ShortURL).
+		Return(&model.ShortenedURL{ShortURL: cmd.ShortURL}, nil).
+		Once()
+	rm.On("Delete", mock.Anything, cmd.ShortURL).Return(nil).Once()
+	cm.On("Delete", mock.Anything, cmd.ShortURL).Return(assert.AnError).Once()
+	vm.On("Delete", mock.Anything, cmd.ShortURL).Return(assert.AnError).Once()
+
+	ch, _ := NewDeleteURLCommandHandler(l, cm, rm, vm)
+	err = ch.Handle(ctx, cmd)
+
+	require.NoError(t, err)
+}
+
+func TestDeleteURLCommandHandler_NotFound(t *testing.T) {
+	ctx := context.Background()
+	cmd := DeleteURLCommand{Principal: testAdmin, ShortURL: "RAND0000"}
+
+	rm := ports_mocks.NewURLRepositoryMock(t)
+	cm := ports_mocks.NewURLCacheMock(t)
+	vm := ports_mocks.NewVisitorCounterMock(t)
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	rm.On("GetByShortenedURL", mock.Anything, cmd.ShortURL).
+		Return(nil, errs.NewObjectNotFoundError("shortenedURL", cmd.ShortURL)).
+		Once()
+
+	ch, _ := NewDeleteURLCommandHandler(l, cm, rm, vm)
+	err = ch.Handle(ctx, cmd)
+
+	require.ErrorIs(t, err, errs.ErrObjectNotFound)
+}
+
+func TestDeleteURLCommandHandler_Ownership(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+	url := &model.ShortenedURL{ShortURL: "RAND0000", OwnerID: &ownerID}
+
+	rm := ports_mocks.NewURLRepositoryMock(t)
+	cm := ports_mocks.NewURLCacheMock(t)
+	vm := ports_mocks.NewVisitorCounterMock(t)
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	rm.On("GetByShortenedURL", mock.Anything, url.ShortURL).Return(url, nil).Twice()
+	rm.On("Delete", mock.Anything, url.ShortURL).Return(nil).Once()
+	cm.On("Delete", mock.Anything, url.ShortURL).Return(nil).Once()
+	vm.On("Delete", mock.Anything, url.ShortURL).Return(nil).Once()
+
+	ch, _ := NewDeleteURLCommandHandler(l, cm, rm, vm)
+
+	// Someone else's url looks like a missing one.
+	err = ch.Handle(ctx, DeleteURLCommand{Principal: auth.Principal{KeyID: uuid.New()}, ShortURL: url.ShortURL})
+	require.ErrorIs(t, err, errs.ErrObjectNotFound)
+
+	err = ch.Handle(ctx, DeleteURLCommand{Principal: auth.Principal{KeyID: ownerID}, ShortURL: url.ShortURL})
+	require.NoError(t, err)
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+)
+
+// Expiration is requested lifetime of shortened url.
+//
+// At most one of fields can be set. Zero value means server's default lifetime.
+type Expiration struct {
+	ExpiresAt *time.Time
+	TTL       *time.Duration
+	// Never makes url never expiring. Meant to be used by operators only.
+	Never bool
+}
+
+func NewExpiration(expiresAt *time.Time, ttl *time.Duration, never bool) (Expiration, error) {
+	set := 0
+	for _, ok := range []bool{expiresAt != nil, ttl != nil, never} {
+		if ok {
+			set++
+		}
+	}
+
+	if set > 1 {
+		return Expiration{}, errs.NewValueIsInvalidErrorWithCause(
+			"expiration",
+			errors.New("only one of expires_at, ttl or never_expires can be set"),
+		)
+	}
+
+	if ttl != nil && *ttl <= 0 {
+		return Expiration{}, errs.NewValueIsInvalidErrorWithCause("ttl", errors.New("must be positive"))
+	}
+
+	return Expiration{
+		ExpiresAt: expiresAt,
+		TTL:       ttl,
+		Never:     never,
+	}, nil
+}
+
+// ExpirationPolicy is server-side bounds of shortened urls lifetime.
+type ExpirationPolicy struct {
+	// DefaultTTL is used when no expiration is requested.
+	DefaultTTL time.Duration
+	// MaxTTL bounds requested expiration. Zero means no bound.
+	MaxTTL time.Duration
+}
+
+// ValidUntil resolves requested expiration to url's valid until time. Nil means never expiring url.
+// Lifetime is counted from start, which is url's activation time.
+func (p ExpirationPolicy) ValidUntil(start time.Time, exp Expiration) (*time.Time, error) {
+	var validUntil time.Time
+
+	switch {
+	case exp.Never:
+		return nil, nil //nolint:nilnil // Nil time is valid value here.
+	case exp.ExpiresAt != nil:
+		validUntil = exp.ExpiresAt.UTC()
+		if !validUntil.After(start) {
+			return nil, errs.NewValueIsInvalidErrorWithCause("expires_at", errors.New("must be in the future"))
+		}
+	case exp.TTL != nil:
+		validUntil = start.Add(*exp.TTL).UTC()
+	default:
+		validUntil = start.Add(p.DefaultTTL).UTC()
+	}
+
+	if p.MaxTTL > 0 && validUntil.Sub(start) > p.MaxTTL {
+		return nil, errs.NewValueIsInvalidErrorWithCause(
+			"expiration",
+			fmt.Errorf("lifetime must not exceed %s", p.MaxTTL),
+		)
+	}
+
+	return &validUntil, nil
+}
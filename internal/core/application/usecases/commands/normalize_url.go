@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	neturl "net/url"
+	"strings"
+
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"golang.org/x/net/idna"
+)
+
+// MaxURLLength bounds length of url (both raw and normalized) that can be shortened.
+const MaxURLLength = 2048
+
+// NormalizeURL validates url that is going to be served as redirect location and brings it to canonical form.
+//
+// Only absolute http(s) urls are accepted. Scheme and host are lowercased, internationalized domain names
+// are converted to punycode, default ports and fragments are stripped.
+func NormalizeURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", errs.NewValueIsInvalidErrorWithCause("url", errors.New("must not be empty"))
+	}
+
+	if len(raw) > MaxURLLength {
+		return "", errs.NewValueIsInvalidErrorWithCause(
+			"url",
+			fmt.Errorf("length must not exceed %d", MaxURLLength),
+		)
+	}
+
+	u, err := neturl.Parse(raw)
+	if err != nil {
+		return "", errs.NewValueIsInvalidErrorWithCause("url", errors.New("malformed url"))
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	defaultPort, ok := schemeDefaultPort(u.Scheme)
+	if !ok {
+		return "", errs.NewValueIsInvalidErrorWithCause(
+			"url",
+			fmt.Errorf("scheme %q is not allowed, use http or https", u.Scheme),
+		)
+	}
+
+	if u.Host == "" {
+		return "", errs.NewValueIsInvalidErrorWithCause("url", errors.New("must be absolute url with host"))
+	}
+
+	host, err := normalizeHost(u.Hostname())
+	if err != nil {
+		return "", errs.NewValueIsInvalidErrorWithCause("url", err)
+	}
+
+	port := u.Port()
+	switch {
+	case port != "" && port != defaultPort:
+		u.Host = net.JoinHostPort(host, port)
+	case strings.Contains(host, ":"):
+		// IPv6 literal.
+		u.Host = "[" + host + "]"
+	default:
+		u.Host = host
+	}
+
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	normalized := u.String()
+	if len(normalized) > MaxURLLength {
+		return "", errs.NewValueIsInvalidErrorWithCause(
+			"url",
+			fmt.Errorf("length must not exceed %d", MaxURLLength),
+		)
+	}
+
+	return normalized, nil
+}
+
+// schemeDefaultPort returns default port of allowed scheme. False is returned for not allowed schemes.
+func schemeDefaultPort(scheme string) (string, bool) {
+	switch scheme {
+	case "http":
+		return "80", true
+	case "https":
+		return "443", true
+	default:
+		return "", false
+	}
+}
+
+func normalizeHost(host string) (string, error) {
+	if host == "" {
+		return "", errors.New("host is empty")
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.String(), nil
+	}
+
+	ascii, err := idna.Lookup.ToASCII(strings.ToLower(host))
+	if err != nil {
+		return "", fmt.Errorf("invalid host %q", host)
+	}
+
+	return ascii, nil
+}
@@ -0,0 +1,115 @@
+//nolint:nolintlint,exhaustruct,testpackage
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeURL(t *testing.T) {
+	tt := []struct {
+		name      string
+		raw       string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "already normalized",
+			raw:      "https://example.com/path?q=1",
+			expected: "https://example.com/path?q=1",
+		},
+		{
+			name:     "uppercase scheme and host",
+			raw:      "HTTPS://Example.COM/Path",
+			expected: "https://example.com/Path",
+		},
+		{
+			name:     "default http port",
+			raw:      "http://example.com:80/",
+			expected: "http://example.com/",
+		},
+		{
+			name:     "default https port",
+			raw:      "https://example.com:443",
+			expected: "https://example.com",
+		},
+		{
+			name:     "non-default port kept",
+			raw:      "https://example.com:8443/a",
+			expected: "https://example.com:8443/a",
+		},
+		{
+			name:     "fragment stripped",
+			raw:      "https://example.com/a#section",
+			expected: "https://example.com/a",
+		},
+		{
+			name:     "idn converted to punycode",
+			raw:      "https://пример.рф/",
+			expected: "https://xn--e1afmkfd.xn--p1ai/",
+		},
+		{
+			name:     "ipv6 with default port",
+			raw:      "http://[::1]:80/",
+			expected: "http://[::1]/",
+		},
+		{
+			name:     "surrounding spaces trimmed",
+			raw:      "  https://example.com  ",
+			expected: "https://example.com",
+		},
+		{
+			name:      "empty",
+			raw:       " ",
+			expectErr: true,
+		},
+		{
+			name:      "javascript scheme",
+			raw:       "javascript:alert(1)",
+			expectErr: true,
+		},
+		{
+			name:      "ftp scheme",
+			raw:       "ftp://example.com/file",
+			expectErr: true,
+		},
+		{
+			name:      "relative path",
+			raw:       "/some/path",
+			expectErr: true,
+		},
+		{
+			name:      "no host",
+			raw:       "http:///path",
+			expectErr: true,
+		},
+		{
+			name:      "garbage",
+			raw:       "http://exa mple.com/%zz",
+			expectErr: true,
+		},
+		{
+			name:      "too long",
+			raw:       "https://example.com/" + strings.Repeat("a", MaxURLLength),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			normalized, err := NormalizeURL(tc.raw)
+
+			if tc.expectErr {
+				require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, normalized)
+		})
+	}
+}
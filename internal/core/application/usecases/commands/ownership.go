@@ -0,0 +1,11 @@
+package commands
+
+import (
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/auth"
+)
+
+// canManage reports whether principal may view and modify url. Admins manage any url, others only their own ones.
+func canManage(principal auth.Principal, url *model.ShortenedURL) bool {
+	return principal.HasScope(model.ScopeAdmin) || url.IsOwnedBy(principal.KeyID)
+}
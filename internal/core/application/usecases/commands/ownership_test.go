@@ -0,0 +1,29 @@
+//nolint:nolintlint,exhaustruct,testpackage
+package commands
+
+import (
+	"testing"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+//nolint:gochecknoglobals // Test fixture.
+var testAdmin = auth.Principal{Name: "admin", Role: string(model.RoleAdmin), Scopes: model.RoleAdmin.Scopes()}
+
+func TestCanManage(t *testing.T) {
+	owner := auth.Principal{KeyID: uuid.New(), Name: "owner"}
+	other := auth.Principal{KeyID: uuid.New(), Name: "other"}
+
+	owned := &model.ShortenedURL{ShortURL: "RAND0000", OwnerID: &owner.KeyID}
+	anonymous := &model.ShortenedURL{ShortURL: "RAND0001"}
+
+	assert.True(t, canManage(owner, owned))
+	assert.False(t, canManage(other, owned))
+	assert.True(t, canManage(testAdmin, owned))
+
+	assert.False(t, canManage(owner, anonymous))
+	assert.True(t, canManage(testAdmin, anonymous))
+}
@@ -0,0 +1,69 @@
+//nolint:nolintlint,exhaustruct
+package commands
+
+import (
+	"context"
+
+	"github.com/dzhordano/urlshortener/internal/core/ports"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/logger"
+	"github.com/dzhordano/urlshortener/internal/pkg/tracing"
+	"github.com/google/uuid"
+)
+
+type RevokeAPIKeyCommand struct {
+	ID uuid.UUID
+}
+
+func NewRevokeAPIKeyCommand(id string) (RevokeAPIKeyCommand, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return RevokeAPIKeyCommand{}, errs.NewValueIsInvalidErrorWithCause("id", err)
+	}
+
+	return RevokeAPIKeyCommand{ID: parsed}, nil
+}
+
+type RevokeAPIKeyCommandHandler interface {
+	Handle(context.Context, RevokeAPIKeyCommand) error
+}
+
+type revokeAPIKeyCommandHandler struct {
+	log        logger.Logger
+	apiKeyRepo ports.APIKeyRepository
+}
+
+func NewRevokeAPIKeyCommandHandler(
+	log logger.Logger,
+	apiKeyRepo ports.APIKeyRepository,
+) (RevokeAPIKeyCommandHandler, error) {
+	if log == nil {
+		return nil, errs.NewValueIsRequiredError("log")
+	}
+
+	if apiKeyRepo == nil {
+		return nil, errs.NewValueIsRequiredError("apiKeyRepo")
+	}
+
+	return &revokeAPIKeyCommandHandler{
+		log:        log,
+		apiKeyRepo: apiKeyRepo,
+	}, nil
+}
+
+func (h *revokeAPIKeyCommandHandler) Handle(ctx context.Context, cmd RevokeAPIKeyCommand) error {
+	ctx, span := tracing.StartSpan(ctx, "RevokeAPIKeyCommandHandler.Handle")
+	defer span.End()
+
+	err := h.apiKeyRepo.Revoke(ctx, cmd.ID)
+	span.AddEvent("api key revoke attempt performed")
+	if err != nil {
+		span.RecordError(err)
+		h.log.Error("error revoking api key", "error", err)
+		return err
+	}
+
+	h.log.Info("api key revoked", "id", cmd.ID)
+
+	return nil
+}
@@ -3,40 +3,226 @@ package commands
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/dzhordano/urlshortener/internal/core/domain/model"
 	"github.com/dzhordano/urlshortener/internal/core/ports"
 	"github.com/dzhordano/urlshortener/internal/pkg/errs"
 	"github.com/dzhordano/urlshortener/internal/pkg/logger"
 	"github.com/dzhordano/urlshortener/internal/pkg/tracing"
+	"github.com/google/uuid"
 )
 
 type ShortenURLCommand struct {
 	OriginalURL string
+	// Alias is optional custom short url. Random one is generated if empty.
+	Alias      string
+	Expiration Expiration
+	// ReuseExisting makes handler return still valid url already shortened for OriginalURL instead of creating new one.
+	// Reused url keeps its own expiration and tags.
+	ReuseExisting bool
+	Tags          []string
+	// RedirectCode is url's redirect status code. Zero means server default.
+	RedirectCode model.RedirectCode
+	// Preview is shown to crawlers instead of redirect. Nil if url has no custom preview.
+	Preview *model.LinkPreview
+	// PasswordHash is hash of password url is protected with. Empty if url isn't password protected.
+	PasswordHash string
+	// MaxClicks is amount of clicks url may be followed with. Nil for urls without click limit.
+	MaxClicks *int
+	// ValidFrom is time url becomes active at. Nil for urls active right away.
+	ValidFrom *time.Time
+	// FallbackURL is redirected to while url can't be followed. Empty if url has none.
+	FallbackURL string
+	// TargetingRules redirect matching visitors elsewhere than OriginalURL. Nil if there are none.
+	TargetingRules []model.TargetingRule
+	// OwnerID is id of api key url is created with. Nil for anonymous urls.
+	// Existing urls are reused among the same owner's ones only.
+	OwnerID *uuid.UUID
 }
 
-func NewShortenURLCommand(url string) (ShortenURLCommand, error) {
-	if url == "" {
+// ShortenURLParams are raw parameters of url to shorten. Zero values stand for parameters not given.
+type ShortenURLParams struct {
+	URL string
+	// Alias is custom short url. Random one is generated if empty.
+	Alias         string
+	Expiration    Expiration
+	ReuseExisting bool
+	Tags          []string
+	// RedirectCode is url's redirect status code. Nil means server default.
+	RedirectCode *int
+	Preview      *model.LinkPreview
+	// Password url is protected with. Empty if url isn't password protected.
+	Password    string
+	MaxClicks   *int
+	ValidFrom   *time.Time
+	FallbackURL string
+	// Targeting rules aren't validated yet.
+	Targeting []model.TargetingRule
+	OwnerID   *uuid.UUID
+}
+
+func NewShortenURLCommand(p ShortenURLParams) (ShortenURLCommand, error) {
+	if p.URL == "" {
 		return ShortenURLCommand{}, errs.NewValueIsInvalidError("url")
 	}
 
-	return ShortenURLCommand{OriginalURL: url}, nil
+	url, err := NormalizeURL(p.URL)
+	if err != nil {
+		return ShortenURLCommand{}, err
+	}
+
+	if p.Alias != "" && p.ReuseExisting {
+		return ShortenURLCommand{}, errs.NewValueIsInvalidErrorWithCause(
+			"reuseExisting",
+			errors.New("can not be used with alias"),
+		)
+	}
+
+	// Reused url wouldn't be protected with given password.
+	if p.Password != "" && p.ReuseExisting {
+		return ShortenURLCommand{}, errs.NewValueIsInvalidErrorWithCause(
+			"reuseExisting",
+			errors.New("can not be used with password"),
+		)
+	}
+
+	// Reused url's clicks would be shared with its other users.
+	if p.MaxClicks != nil && p.ReuseExisting {
+		return ShortenURLCommand{}, errs.NewValueIsInvalidErrorWithCause(
+			"reuseExisting",
+			errors.New("can not be used with maxClicks"),
+		)
+	}
+
+	// Reused url would be active already, or not as scheduled.
+	if p.ValidFrom != nil && p.ReuseExisting {
+		return ShortenURLCommand{}, errs.NewValueIsInvalidErrorWithCause(
+			"reuseExisting",
+			errors.New("can not be used with validFrom"),
+		)
+	}
+
+	if p.MaxClicks != nil && *p.MaxClicks < 1 {
+		return ShortenURLCommand{}, errs.NewValueIsInvalidErrorWithCause("maxClicks", errors.New("must be positive"))
+	}
+
+	if p.ValidFrom != nil && p.Expiration.ExpiresAt != nil && !p.ValidFrom.Before(*p.Expiration.ExpiresAt) {
+		return ShortenURLCommand{}, errs.NewValueIsInvalidErrorWithCause(
+			"validFrom",
+			errors.New("must be before expires_at"),
+		)
+	}
+
+	fallbackURL := p.FallbackURL
+	if fallbackURL != "" {
+		fallbackURL, err = NormalizeURL(fallbackURL)
+		if err != nil {
+			return ShortenURLCommand{}, err
+		}
+	}
+
+	// Reused url would redirect visitors to its original url regardless of given rules.
+	if len(p.Targeting) > 0 && p.ReuseExisting {
+		return ShortenURLCommand{}, errs.NewValueIsInvalidErrorWithCause(
+			"reuseExisting",
+			errors.New("can not be used with targeting"),
+		)
+	}
+
+	targeting, err := newTargetingRules(p.Targeting)
+	if err != nil {
+		return ShortenURLCommand{}, err
+	}
+
+	if p.Alias != "" {
+		if err := model.ValidateAlias(p.Alias); err != nil {
+			return ShortenURLCommand{}, err
+		}
+	}
+
+	tags, err := model.NormalizeTags(p.Tags)
+	if err != nil {
+		return ShortenURLCommand{}, err
+	}
+
+	var rc model.RedirectCode
+	if p.RedirectCode != nil {
+		rc, err = model.ParseRedirectCode(*p.RedirectCode)
+		if err != nil {
+			return ShortenURLCommand{}, err
+		}
+	}
+
+	preview := p.Preview
+	if preview != nil {
+		preview, err = model.NewLinkPreview(preview.Title, preview.Description, preview.ImageURL)
+		if err != nil {
+			return ShortenURLCommand{}, err
+		}
+	}
+
+	var passwordHash string
+	if p.Password != "" {
+		passwordHash, err = model.HashLinkPassword(p.Password)
+		if err != nil {
+			return ShortenURLCommand{}, err
+		}
+	}
+
+	return ShortenURLCommand{
+		OriginalURL:    url,
+		Alias:          p.Alias,
+		Expiration:     p.Expiration,
+		ReuseExisting:  p.ReuseExisting,
+		Tags:           tags,
+		RedirectCode:   rc,
+		Preview:        preview,
+		PasswordHash:   passwordHash,
+		MaxClicks:      p.MaxClicks,
+		ValidFrom:      p.ValidFrom,
+		FallbackURL:    fallbackURL,
+		TargetingRules: targeting,
+		OwnerID:        p.OwnerID,
+	}, nil
+}
+
+type ShortenURLResult struct {
+	ShortURL string
+	// Created is false if existing url was reused.
+	Created bool
 }
 
+// maxTokenGenerationAttempts bounds retries on generated token collision.
+const maxTokenGenerationAttempts = 5
+
+// ErrTokenGenerationExhausted is returned when no unique token was generated in maxTokenGenerationAttempts.
+var ErrTokenGenerationExhausted = errors.New("could not generate unique short url")
+
 type ShortenURLCommandHandler interface {
-	Handle(context.Context, ShortenURLCommand) (string, error)
+	Handle(context.Context, ShortenURLCommand) (ShortenURLResult, error)
 }
 
 type shortenURLCommandHandler struct {
-	log     logger.Logger
-	cache   ports.URLCache
-	urlRepo ports.URLRepository
+	log          logger.Logger
+	cache        ports.URLCache
+	urlRepo      ports.URLRepository
+	tokenGen     ports.TokenGenerator
+	expiration   ExpirationPolicy
+	redirectCode model.RedirectCode
 }
 
+// NewShortenURLCommandHandler returns handler creating urls. Urls created without redirect code redirect
+// with redirectCode.
 func NewShortenURLCommandHandler(
 	log logger.Logger,
 	cache ports.URLCache,
 	urlRepo ports.URLRepository,
+	tokenGen ports.TokenGenerator,
+	expiration ExpirationPolicy,
+	redirectCode model.RedirectCode,
 ) (ShortenURLCommandHandler, error) {
 	if log == nil {
 		return nil, errs.NewValueIsRequiredError("log")
@@ -50,44 +236,60 @@ func NewShortenURLCommandHandler(
 		return nil, errs.NewValueIsRequiredError("urlRepo")
 	}
 
+	if tokenGen == nil {
+		return nil, errs.NewValueIsRequiredError("tokenGen")
+	}
+
+	if expiration.DefaultTTL <= 0 {
+		return nil, errs.NewValueIsInvalidError("expiration.DefaultTTL")
+	}
+
+	if _, err := model.ParseRedirectCode(int(redirectCode)); err != nil {
+		return nil, err
+	}
+
 	return &shortenURLCommandHandler{
-		log:     log,
-		cache:   cache,
-		urlRepo: urlRepo,
+		log:          log,
+		cache:        cache,
+		urlRepo:      urlRepo,
+		tokenGen:     tokenGen,
+		expiration:   expiration,
+		redirectCode: redirectCode,
 	}, nil
 }
 
 func (h *shortenURLCommandHandler) Handle(
 	ctx context.Context,
 	cmd ShortenURLCommand,
-) (string, error) {
+) (ShortenURLResult, error) {
 	ctx, span := tracing.StartSpan(ctx, "ShortenURLCommandHandler.Handle")
 	defer span.End()
 
-	url, err := model.NewShortenedURL(cmd.OriginalURL)
-	if err != nil {
-		span.RecordError(err)
-		h.log.Error("error creating new shortened url", "error", err)
-		return "", err
+	if cmd.ReuseExisting {
+		existing, err := h.urlRepo.GetByOriginalURL(ctx, cmd.OriginalURL, cmd.OwnerID)
+		switch {
+		case err == nil:
+			span.AddEvent("existing shortened url reused")
+			h.log.Debug("existing url reused", "short_url", existing.ShortURL)
+			return ShortenURLResult{ShortURL: existing.ShortURL, Created: false}, nil
+		case !errors.Is(err, errs.ErrObjectNotFound):
+			span.RecordError(err)
+			h.log.Error("error getting existing url", "error", err)
+			return ShortenURLResult{}, err
+		}
 	}
 
-	span.AddEvent("shortened url created")
-	h.log.Debug("shortened url", "short_url", url.ShortURL)
-
-	// If shortened url will contain non-unique short url (collision)
-	// this will result in an error (unique constraint) because no retry logic :p.
-	err = h.urlRepo.Save(ctx, url)
-	span.AddEvent("shortened url save attempt performed")
+	url, err := h.save(ctx, cmd)
 	if err != nil {
 		span.RecordError(err)
 		h.log.Error("error saving url", "error", err)
-		return "", err
+		return ShortenURLResult{}, err
 	}
 
 	span.AddEvent("shortened url saved or retrieved from db")
-	h.log.Debug("url saved or found in db", "url", url)
+	h.log.Debug("url saved or found in db", "short_url", url.ShortURL)
 
-	err = h.cache.Set(ctx, url.ShortURL, url.OriginalURL)
+	err = h.cache.Set(ctx, url.ShortURL, url)
 	if err != nil {
 		span.RecordError(err)
 		h.log.Error("error saving url to cache", "error", err)
@@ -96,5 +298,127 @@ func (h *shortenURLCommandHandler) Handle(
 	span.AddEvent("shortened url saved")
 	h.log.Debug("short url saved to cache", "short_url", url.ShortURL)
 
-	return url.ShortURL, nil
+	return ShortenURLResult{ShortURL: url.ShortURL, Created: true}, nil
+}
+
+// save creates and saves shortened url.
+//
+// Alias is saved as is (taken alias results in errs.ErrObjectAlreadyExists),
+// generated tokens are regenerated on collision up to maxTokenGenerationAttempts times.
+func (h *shortenURLCommandHandler) save(
+	ctx context.Context,
+	cmd ShortenURLCommand,
+) (*model.ShortenedURL, error) {
+	span := tracing.SpanFromContext(ctx)
+
+	start, err := activationStart(time.Now(), cmd.ValidFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	validUntil, err := h.expiration.ValidUntil(start, cmd.Expiration)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := range maxTokenGenerationAttempts {
+		shortURL := cmd.Alias
+		if shortURL == "" {
+			shortURL, err = h.tokenGen.Generate(ctx, cmd.OriginalURL, attempt)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		var url *model.ShortenedURL
+		url, err = model.NewShortenedURL(cmd.OriginalURL, shortURL, validUntil)
+		if err != nil {
+			return nil, err
+		}
+		if cmd.Tags != nil {
+			url.Tags = cmd.Tags
+		}
+		url.RedirectCode = h.redirectCode
+		if cmd.RedirectCode != 0 {
+			url.RedirectCode = cmd.RedirectCode
+		}
+		url.Preview = cmd.Preview
+		url.PasswordHash = cmd.PasswordHash
+		url.MaxClicks = cmd.MaxClicks
+		url.ValidFromUTC = utcOrNil(cmd.ValidFrom)
+		url.FallbackURL = cmd.FallbackURL
+		url.TargetingRules = cmd.TargetingRules
+		url.OwnerID = cmd.OwnerID
+
+		span.AddEvent("shortened url created")
+		h.log.Debug("shortened url", "short_url", url.ShortURL)
+
+		err = h.urlRepo.Save(ctx, url)
+		span.AddEvent("shortened url save attempt performed")
+		switch {
+		case err == nil:
+			return url, nil
+		case cmd.Alias != "" || !errors.Is(err, errs.ErrObjectAlreadyExists):
+			return nil, err
+		default:
+			h.log.Warn("generated short url collision", "short_url", url.ShortURL, "attempt", attempt)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %d attempts", ErrTokenGenerationExhausted, maxTokenGenerationAttempts)
+}
+
+// newTargetingRules validates rules and normalizes their target urls. Nil is returned for no rules.
+func newTargetingRules(rules []model.TargetingRule) ([]model.TargetingRule, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	if len(rules) > model.MaxTargetingRules {
+		return nil, errs.NewValueIsInvalidErrorWithCause(
+			"targeting",
+			fmt.Errorf("must contain at most %d rules", model.MaxTargetingRules),
+		)
+	}
+
+	validated := make([]model.TargetingRule, 0, len(rules))
+	for _, r := range rules {
+		targetURL := r.TargetURL
+		if targetURL != "" {
+			var err error
+			if targetURL, err = NormalizeURL(targetURL); err != nil {
+				return nil, err
+			}
+		}
+
+		rule, err := model.NewTargetingRule(r.Country, r.OS, r.Device, targetURL)
+		if err != nil {
+			return nil, err
+		}
+		validated = append(validated, rule)
+	}
+
+	return validated, nil
+}
+
+// activationStart returns time url's lifetime starts at: validFrom if url is scheduled, now otherwise.
+func activationStart(now time.Time, validFrom *time.Time) (time.Time, error) {
+	if validFrom == nil {
+		return now, nil
+	}
+
+	if !validFrom.After(now) {
+		return time.Time{}, errs.NewValueIsInvalidErrorWithCause("valid_from", errors.New("must be in the future"))
+	}
+
+	return *validFrom, nil
+}
+
+func utcOrNil(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	utc := t.UTC()
+	return &utc
 }
@@ -3,8 +3,11 @@ package commands
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
 	"github.com/dzhordano/urlshortener/internal/pkg/errs"
 	"github.com/dzhordano/urlshortener/internal/pkg/logger"
 	"github.com/dzhordano/urlshortener/mocks/core/ports_mocks"
@@ -13,6 +16,13 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func testExpirationPolicy() ExpirationPolicy {
+	return ExpirationPolicy{
+		DefaultTTL: 24 * time.Hour,
+		MaxTTL:     7 * 24 * time.Hour,
+	}
+}
+
 func TestShortenURLCommandHandler_SuccessSaved(t *testing.T) {
 	ctx := context.Background()
 	cmd := ShortenURLCommand{
@@ -21,17 +31,53 @@ func TestShortenURLCommandHandler_SuccessSaved(t *testing.T) {
 
 	rm := ports_mocks.NewURLRepositoryMock(t)
 	cm := ports_mocks.NewURLCacheMock(t)
+	tg := ports_mocks.NewTokenGeneratorMock(t)
 	l, err := logger.NewSlogLogger(true, "debug")
 	require.NoError(t, err)
 
+	tg.On("Generate", mock.Anything, cmd.OriginalURL, 0).Return("RAND0000", nil).Once()
 	rm.On("Save", mock.Anything, mock.Anything).Return(nil).Once()
 	cm.On("Set", mock.Anything, mock.Anything, mock.Anything).Return(nil).Times(1)
 
-	ch, _ := NewShortenURLCommandHandler(l, cm, rm)
+	ch, _ := NewShortenURLCommandHandler(l, cm, rm, tg, testExpirationPolicy(), model.RedirectCodeFound)
 	resp, err := ch.Handle(ctx, cmd)
 
 	require.NoError(t, err)
-	assert.NotNil(t, resp)
+	assert.Equal(t, "RAND0000", resp.ShortURL)
+}
+
+func TestShortenURLCommandHandler_RedirectCode(t *testing.T) {
+	tt := []struct {
+		name     string
+		code     model.RedirectCode
+		expected model.RedirectCode
+	}{
+		{name: "server default", code: 0, expected: model.RedirectCodeFound},
+		{name: "chosen", code: model.RedirectCodePermanentRedirect, expected: model.RedirectCodePermanentRedirect},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := ShortenURLCommand{OriginalURL: "https://example.com", RedirectCode: tc.code}
+
+			rm := ports_mocks.NewURLRepositoryMock(t)
+			cm := ports_mocks.NewURLCacheMock(t)
+			tg := ports_mocks.NewTokenGeneratorMock(t)
+			l, err := logger.NewSlogLogger(true, "debug")
+			require.NoError(t, err)
+
+			tg.On("Generate", mock.Anything, cmd.OriginalURL, 0).Return("RAND0000", nil).Once()
+			rm.On("Save", mock.Anything, mock.MatchedBy(func(url *model.ShortenedURL) bool {
+				return url.RedirectCode == tc.expected
+			})).Return(nil).Once()
+			cm.On("Set", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+			ch, _ := NewShortenURLCommandHandler(l, cm, rm, tg, testExpirationPolicy(), model.RedirectCodeFound)
+			_, err = ch.Handle(context.Background(), cmd)
+
+			require.NoError(t, err)
+		})
+	}
 }
 
 func TestShortenURLCommandHandler_InvalidCommand(t *testing.T) {
@@ -42,10 +88,13 @@ func TestShortenURLCommandHandler_InvalidCommand(t *testing.T) {
 
 	rm := ports_mocks.NewURLRepositoryMock(t)
 	cm := ports_mocks.NewURLCacheMock(t)
+	tg := ports_mocks.NewTokenGeneratorMock(t)
 	l, err := logger.NewSlogLogger(true, "debug")
 	require.NoError(t, err)
 
-	ch, _ := NewShortenURLCommandHandler(l, cm, rm)
+	tg.On("Generate", mock.Anything, mock.Anything, mock.Anything).Return("RAND0000", nil).Maybe()
+
+	ch, _ := NewShortenURLCommandHandler(l, cm, rm, tg, testExpirationPolicy(), model.RedirectCodeFound)
 	resp, err := ch.Handle(ctx, cmd)
 
 	require.ErrorIs(t, err, errs.ErrValueIsRequired)
@@ -60,14 +109,466 @@ func TestShortenURLCommandHandler_Internal(t *testing.T) {
 
 	rm := ports_mocks.NewURLRepositoryMock(t)
 	cm := ports_mocks.NewURLCacheMock(t)
+	tg := ports_mocks.NewTokenGeneratorMock(t)
 	l, err := logger.NewSlogLogger(true, "debug")
 	require.NoError(t, err)
 
+	tg.On("Generate", mock.Anything, cmd.OriginalURL, 0).Return("RAND0000", nil).Once()
 	rm.On("Save", mock.Anything, mock.Anything).Return(assert.AnError).Once()
 
-	ch, _ := NewShortenURLCommandHandler(l, cm, rm)
+	ch, _ := NewShortenURLCommandHandler(l, cm, rm, tg, testExpirationPolicy(), model.RedirectCodeFound)
 	resp, err := ch.Handle(ctx, cmd)
 
 	require.ErrorIs(t, assert.AnError, err)
 	assert.Empty(t, resp)
 }
+
+func TestShortenURLCommandHandler_CollisionRetried(t *testing.T) {
+	ctx := context.Background()
+	cmd := ShortenURLCommand{
+		OriginalURL: "https://example.com",
+	}
+
+	rm := ports_mocks.NewURLRepositoryMock(t)
+	cm := ports_mocks.NewURLCacheMock(t)
+	tg := ports_mocks.NewTokenGeneratorMock(t)
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	tg.On("Generate", mock.Anything, cmd.OriginalURL, 0).Return("TAKEN000", nil).Once()
+	tg.On("Generate", mock.Anything, cmd.OriginalURL, 1).Return("FREE0000", nil).Once()
+	rm.On("Save", mock.Anything, mock.Anything).
+		Return(errs.NewObjectAlreadyExistsError("shortURL", "TAKEN000")).
+		Once()
+	rm.On("Save", mock.Anything, mock.Anything).Return(nil).Once()
+	cm.On("Set", mock.Anything, "FREE0000", mock.Anything).Return(nil).Once()
+
+	ch, _ := NewShortenURLCommandHandler(l, cm, rm, tg, testExpirationPolicy(), model.RedirectCodeFound)
+	resp, err := ch.Handle(ctx, cmd)
+
+	require.NoError(t, err)
+	assert.Equal(t, "FREE0000", resp.ShortURL)
+}
+
+func TestShortenURLCommandHandler_CollisionExhausted(t *testing.T) {
+	ctx := context.Background()
+	cmd := ShortenURLCommand{
+		OriginalURL: "https://example.com",
+	}
+
+	rm := ports_mocks.NewURLRepositoryMock(t)
+	cm := ports_mocks.NewURLCacheMock(t)
+	tg := ports_mocks.NewTokenGeneratorMock(t)
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	tg.On("Generate", mock.Anything, cmd.OriginalURL, mock.Anything).
+		Return("TAKEN000", nil).
+		Times(maxTokenGenerationAttempts)
+	rm.On("Save", mock.Anything, mock.Anything).
+		Return(errs.NewObjectAlreadyExistsError("shortURL", "TAKEN000")).
+		Times(maxTokenGenerationAttempts)
+
+	ch, _ := NewShortenURLCommandHandler(l, cm, rm, tg, testExpirationPolicy(), model.RedirectCodeFound)
+	resp, err := ch.Handle(ctx, cmd)
+
+	require.ErrorIs(t, err, ErrTokenGenerationExhausted)
+	require.NotErrorIs(t, err, errs.ErrObjectAlreadyExists)
+	assert.Empty(t, resp)
+}
+
+func TestShortenURLCommandHandler_AliasSaved(t *testing.T) {
+	ctx := context.Background()
+	cmd := ShortenURLCommand{
+		OriginalURL: "https://example.com",
+		Alias:       "spring-sale",
+	}
+
+	rm := ports_mocks.NewURLRepositoryMock(t)
+	cm := ports_mocks.NewURLCacheMock(t)
+	tg := ports_mocks.NewTokenGeneratorMock(t)
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	rm.On("Save", mock.Anything, mock.Anything).Return(nil).Once()
+	cm.On("Set", mock.Anything, "spring-sale", mock.Anything).Return(nil).Once()
+
+	ch, _ := NewShortenURLCommandHandler(l, cm, rm, tg, testExpirationPolicy(), model.RedirectCodeFound)
+	resp, err := ch.Handle(ctx, cmd)
+
+	require.NoError(t, err)
+	assert.Equal(t, "spring-sale", resp.ShortURL)
+}
+
+func TestShortenURLCommandHandler_AliasTaken(t *testing.T) {
+	ctx := context.Background()
+	cmd := ShortenURLCommand{
+		OriginalURL: "https://example.com",
+		Alias:       "spring-sale",
+	}
+
+	rm := ports_mocks.NewURLRepositoryMock(t)
+	cm := ports_mocks.NewURLCacheMock(t)
+	tg := ports_mocks.NewTokenGeneratorMock(t)
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	// Taken alias is not retried.
+	rm.On("Save", mock.Anything, mock.Anything).
+		Return(errs.NewObjectAlreadyExistsError("shortURL", cmd.Alias)).
+		Once()
+
+	ch, _ := NewShortenURLCommandHandler(l, cm, rm, tg, testExpirationPolicy(), model.RedirectCodeFound)
+	resp, err := ch.Handle(ctx, cmd)
+
+	require.ErrorIs(t, err, errs.ErrObjectAlreadyExists)
+	assert.Empty(t, resp)
+}
+
+func TestNewShortenURLCommand_InvalidAlias(t *testing.T) {
+	for _, alias := range []string{"ab", "docs", "Shorten", "with space", "слово", strings.Repeat("a", 33)} {
+		_, err := NewShortenURLCommand(ShortenURLParams{URL: "https://example.com", Alias: alias})
+		require.ErrorIs(t, err, errs.ErrValueIsInvalid, alias)
+	}
+}
+
+func TestNewShortenURLCommand_Tags(t *testing.T) {
+	cmd, err := NewShortenURLCommand(ShortenURLParams{
+		URL:  "https://example.com",
+		Tags: []string{"Promo", "promo", "q4"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"promo", "q4"}, cmd.Tags)
+
+	_, err = NewShortenURLCommand(ShortenURLParams{URL: "https://example.com", Tags: []string{"with space"}})
+	require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
+
+func TestNewShortenURLCommand_AliasWithReuse(t *testing.T) {
+	_, err := NewShortenURLCommand(ShortenURLParams{
+		URL:           "https://example.com",
+		Alias:         "spring-sale",
+		ReuseExisting: true,
+	})
+	require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
+
+func TestShortenURLCommandHandler_ReuseExisting(t *testing.T) {
+	ctx := context.Background()
+	cmd := ShortenURLCommand{
+		OriginalURL:   "https://example.com",
+		ReuseExisting: true,
+	}
+
+	rm := ports_mocks.NewURLRepositoryMock(t)
+	cm := ports_mocks.NewURLCacheMock(t)
+	tg := ports_mocks.NewTokenGeneratorMock(t)
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	rm.On("GetByOriginalURL", mock.Anything, cmd.OriginalURL, cmd.OwnerID).
+		Return(&model.ShortenedURL{OriginalURL: cmd.OriginalURL, ShortURL: "EXIST000"}, nil).
+		Once()
+
+	ch, _ := NewShortenURLCommandHandler(l, cm, rm, tg, testExpirationPolicy(), model.RedirectCodeFound)
+	resp, err := ch.Handle(ctx, cmd)
+
+	require.NoError(t, err)
+	assert.Equal(t, "EXIST000", resp.ShortURL)
+	assert.False(t, resp.Created)
+}
+
+func TestShortenURLCommandHandler_ReuseExistingNotFound(t *testing.T) {
+	ctx := context.Background()
+	cmd := ShortenURLCommand{
+		OriginalURL:   "https://example.com",
+		ReuseExisting: true,
+	}
+
+	rm := ports_mocks.NewURLRepositoryMock(t)
+	cm := ports_mocks.NewURLCacheMock(t)
+	tg := ports_mocks.NewTokenGeneratorMock(t)
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	rm.On("GetByOriginalURL", mock.Anything, cmd.OriginalURL, cmd.OwnerID).
+		Return(nil, errs.NewObjectNotFoundError("originalURL", cmd.OriginalURL)).
+		Once()
+	tg.On("Generate", mock.Anything, cmd.OriginalURL, 0).Return("RAND0000", nil).Once()
+	rm.On("Save", mock.Anything, mock.Anything).Return(nil).Once()
+	cm.On("Set", mock.Anything, "RAND0000", mock.Anything).Return(nil).Once()
+
+	ch, _ := NewShortenURLCommandHandler(l, cm, rm, tg, testExpirationPolicy(), model.RedirectCodeFound)
+	resp, err := ch.Handle(ctx, cmd)
+
+	require.NoError(t, err)
+	assert.Equal(t, "RAND0000", resp.ShortURL)
+	assert.True(t, resp.Created)
+}
+
+func TestShortenURLCommandHandler_Expiration(t *testing.T) {
+	ctx := context.Background()
+	ttl := time.Hour
+	tooLongTTL := 30 * 24 * time.Hour
+
+	tt := []struct {
+		name       string
+		expiration Expiration
+		expectErr  bool
+		// checkValidUntil asserts valid until of saved url
+		checkValidUntil func(t *testing.T, validUntil *time.Time)
+	}{
+		{
+			name:       "default",
+			expiration: Expiration{},
+			checkValidUntil: func(t *testing.T, validUntil *time.Time) {
+				require.NotNil(t, validUntil)
+				assert.WithinDuration(t, time.Now().Add(24*time.Hour), *validUntil, time.Minute)
+			},
+		},
+		{
+			name:       "ttl",
+			expiration: Expiration{TTL: &ttl},
+			checkValidUntil: func(t *testing.T, validUntil *time.Time) {
+				require.NotNil(t, validUntil)
+				assert.WithinDuration(t, time.Now().Add(time.Hour), *validUntil, time.Minute)
+			},
+		},
+		{
+			name:       "never expires",
+			expiration: Expiration{Never: true},
+			checkValidUntil: func(t *testing.T, validUntil *time.Time) {
+				assert.Nil(t, validUntil)
+			},
+		},
+		{
+			name:       "ttl exceeds max",
+			expiration: Expiration{TTL: &tooLongTTL},
+			expectErr:  true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			rm := ports_mocks.NewURLRepositoryMock(t)
+			cm := ports_mocks.NewURLCacheMock(t)
+			tg := ports_mocks.NewTokenGeneratorMock(t)
+			l, err := logger.NewSlogLogger(true, "debug")
+			require.NoError(t, err)
+
+			if !tc.expectErr {
+				tg.On("Generate", mock.Anything, mock.Anything, 0).Return("RAND0000", nil).Once()
+				rm.On("Save", mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						tc.checkValidUntil(t, args.Get(1).(*model.ShortenedURL).ValidUntilUTC)
+					}).
+					Return(nil).
+					Once()
+				cm.On("Set", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+			}
+
+			ch, _ := NewShortenURLCommandHandler(l, cm, rm, tg, testExpirationPolicy(), model.RedirectCodeFound)
+			_, err = ch.Handle(ctx, ShortenURLCommand{OriginalURL: "https://example.com", Expiration: tc.expiration})
+
+			if tc.expectErr {
+				require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewExpiration_MutuallyExclusive(t *testing.T) {
+	ttl := time.Hour
+	expiresAt := time.Now().Add(time.Hour)
+
+	_, err := NewExpiration(&expiresAt, &ttl, false)
+	require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+
+	_, err = NewExpiration(nil, &ttl, true)
+	require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
+
+func TestNewShortenURLCommand_RedirectCode(t *testing.T) {
+	code := 307
+	cmd, err := NewShortenURLCommand(ShortenURLParams{URL: "https://example.com", RedirectCode: &code})
+	require.NoError(t, err)
+	assert.Equal(t, model.RedirectCodeTemporaryRedirect, cmd.RedirectCode)
+
+	code = 200
+	_, err = NewShortenURLCommand(ShortenURLParams{URL: "https://example.com", RedirectCode: &code})
+	require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
+
+func TestNewShortenURLCommand_Preview(t *testing.T) {
+	cmd, err := NewShortenURLCommand(ShortenURLParams{
+		URL:     "https://example.com",
+		Preview: &model.LinkPreview{Title: "  Spring sale ", ImageURL: "https://cdn.example.com/sale.png"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &model.LinkPreview{Title: "Spring sale", ImageURL: "https://cdn.example.com/sale.png"}, cmd.Preview)
+
+	for _, preview := range []*model.LinkPreview{
+		{Title: " "},
+		{Title: strings.Repeat("a", model.PreviewTitleMaxLength+1)},
+		{Title: "Sale", Description: strings.Repeat("a", model.PreviewDescriptionMaxLength+1)},
+		{Title: "Sale", ImageURL: "/sale.png"},
+		{Title: "Sale", ImageURL: "javascript:alert(1)"},
+	} {
+		_, err = NewShortenURLCommand(ShortenURLParams{URL: "https://example.com", Preview: preview})
+		require.ErrorIs(t, err, errs.ErrValueIsInvalid, preview)
+	}
+}
+
+func TestNewShortenURLCommand_Password(t *testing.T) {
+	cmd, err := NewShortenURLCommand(ShortenURLParams{URL: "https://example.com", Password: "s3cret!"})
+	require.NoError(t, err)
+	require.NotEmpty(t, cmd.PasswordHash)
+	assert.NotContains(t, cmd.PasswordHash, "s3cret!")
+
+	url := &model.ShortenedURL{PasswordHash: cmd.PasswordHash}
+	assert.True(t, url.IsPasswordProtected())
+	assert.True(t, url.CheckPassword("s3cret!"))
+	assert.False(t, url.CheckPassword("s3cret"))
+
+	cmd, err = NewShortenURLCommand(ShortenURLParams{URL: "https://example.com"})
+	require.NoError(t, err)
+	assert.Empty(t, cmd.PasswordHash)
+
+	for _, password := range []string{"short", strings.Repeat("a", model.LinkPasswordMaxLength+1)} {
+		_, err = NewShortenURLCommand(ShortenURLParams{URL: "https://example.com", Password: password})
+		require.ErrorIs(t, err, errs.ErrValueIsInvalid, password)
+	}
+
+	_, err = NewShortenURLCommand(ShortenURLParams{
+		URL:           "https://example.com",
+		ReuseExisting: true,
+		Password:      "s3cret!",
+	})
+	require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
+
+func TestNewShortenURLCommand_MaxClicks(t *testing.T) {
+	maxClicks := 1
+	cmd, err := NewShortenURLCommand(ShortenURLParams{URL: "https://example.com", MaxClicks: &maxClicks})
+	require.NoError(t, err)
+	assert.Equal(t, &maxClicks, cmd.MaxClicks)
+
+	_, err = NewShortenURLCommand(ShortenURLParams{
+		URL:           "https://example.com",
+		ReuseExisting: true,
+		MaxClicks:     &maxClicks,
+	})
+	require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+
+	maxClicks = 0
+	_, err = NewShortenURLCommand(ShortenURLParams{URL: "https://example.com", MaxClicks: &maxClicks})
+	require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
+
+func TestNewShortenURLCommand_ValidFrom(t *testing.T) {
+	validFrom := time.Now().Add(time.Hour)
+	cmd, err := NewShortenURLCommand(ShortenURLParams{
+		URL:         "https://example.com",
+		ValidFrom:   &validFrom,
+		FallbackURL: "HTTPS://Example.com/soon",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &validFrom, cmd.ValidFrom)
+	assert.Equal(t, "https://example.com/soon", cmd.FallbackURL)
+
+	_, err = NewShortenURLCommand(ShortenURLParams{
+		URL:           "https://example.com",
+		ReuseExisting: true,
+		ValidFrom:     &validFrom,
+	})
+	require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+
+	expiresAt := validFrom.Add(-time.Minute)
+	_, err = NewShortenURLCommand(ShortenURLParams{
+		URL:        "https://example.com",
+		Expiration: Expiration{ExpiresAt: &expiresAt},
+		ValidFrom:  &validFrom,
+	})
+	require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
+
+func TestShortenURLCommandHandler_ValidFrom(t *testing.T) {
+	ctx := context.Background()
+	validFrom := time.Now().Add(time.Hour)
+	ttl := 10 * time.Minute
+	cmd := ShortenURLCommand{
+		OriginalURL: "https://example.com",
+		Alias:       "launch",
+		Expiration:  Expiration{TTL: &ttl},
+		ValidFrom:   &validFrom,
+		FallbackURL: "https://example.com/soon",
+	}
+
+	cache := ports_mocks.NewURLCacheMock(t)
+	repo := ports_mocks.NewURLRepositoryMock(t)
+	repo.On("Save", mock.Anything, mock.MatchedBy(func(url *model.ShortenedURL) bool {
+		// Lifetime is counted from activation.
+		return url.ValidFromUTC.Equal(validFrom) &&
+			url.ValidUntilUTC.Equal(validFrom.Add(ttl)) &&
+			url.FallbackURL == "https://example.com/soon"
+	})).Return(nil).Once()
+	cache.On("Set", mock.Anything, "launch", mock.Anything).Return(nil).Once()
+
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	h, err := NewShortenURLCommandHandler(
+		l, cache, repo, ports_mocks.NewTokenGeneratorMock(t), testExpirationPolicy(), model.RedirectCodeFound,
+	)
+	require.NoError(t, err)
+
+	_, err = h.Handle(ctx, cmd)
+	require.NoError(t, err)
+
+	past := time.Now().Add(-time.Minute)
+	cmd.ValidFrom = &past
+	_, err = h.Handle(ctx, cmd)
+	require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
+
+func TestNewShortenURLCommand_Targeting(t *testing.T) {
+	cmd, err := NewShortenURLCommand(ShortenURLParams{
+		URL: "https://example.com",
+		Targeting: []model.TargetingRule{
+			{OS: "ios", TargetURL: "https://apps.apple.com/app/id1"},
+			{OS: "Android", Device: "Mobile", TargetURL: "HTTPS://play.google.com/store/apps/details?id=app"},
+			{Country: "de", TargetURL: "https://shop.example.de"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []model.TargetingRule{
+		{OS: "iOS", TargetURL: "https://apps.apple.com/app/id1"},
+		{OS: "Android", Device: model.DeviceMobile, TargetURL: "https://play.google.com/store/apps/details?id=app"},
+		{Country: "DE", TargetURL: "https://shop.example.de"},
+	}, cmd.TargetingRules)
+
+	for _, rule := range []model.TargetingRule{
+		{TargetURL: "https://example.com/app"},
+		{OS: "Symbian", TargetURL: "https://example.com/app"},
+		{Device: "watch", TargetURL: "https://example.com/app"},
+		{Country: "DEU", TargetURL: "https://example.com/app"},
+		{Country: "1A", TargetURL: "https://example.com/app"},
+		{OS: "iOS"},
+		{OS: "iOS", TargetURL: "itms-apps://apps.apple.com/app/id1"},
+	} {
+		_, err = NewShortenURLCommand(ShortenURLParams{
+			URL:       "https://example.com",
+			Targeting: []model.TargetingRule{rule},
+		})
+		require.ErrorIs(t, err, errs.ErrValueIsInvalid, rule)
+	}
+
+	_, err = NewShortenURLCommand(ShortenURLParams{
+		URL:           "https://example.com",
+		ReuseExisting: true,
+		Targeting:     []model.TargetingRule{{OS: "iOS", TargetURL: "https://apps.apple.com/app/id1"}},
+	})
+	require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
@@ -0,0 +1,252 @@
+//nolint:nolintlint,exhaustruct
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/core/ports"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/logger"
+	"github.com/dzhordano/urlshortener/internal/pkg/tracing"
+)
+
+// MaxShortenBatchSize bounds amount of urls shortened at once.
+const MaxShortenBatchSize = 1000
+
+type ShortenURLsBatchCommand struct {
+	Items []ShortenURLCommand
+}
+
+func NewShortenURLsBatchCommand(items []ShortenURLCommand) (ShortenURLsBatchCommand, error) {
+	if len(items) == 0 {
+		return ShortenURLsBatchCommand{}, errs.NewValueIsRequiredError("items")
+	}
+
+	if len(items) > MaxShortenBatchSize {
+		return ShortenURLsBatchCommand{}, errs.NewValueIsInvalidErrorWithCause(
+			"items",
+			fmt.Errorf("must contain at most %d items", MaxShortenBatchSize),
+		)
+	}
+
+	return ShortenURLsBatchCommand{Items: items}, nil
+}
+
+// ShortenURLsBatchItemResult is result of shortening single batch item. Err is set if item failed.
+type ShortenURLsBatchItemResult struct {
+	ShortenURLResult
+	Err error
+}
+
+type ShortenURLsBatchCommandHandler interface {
+	// Handle returns results in order of command items. Error is returned only if batch failed as a whole.
+	Handle(context.Context, ShortenURLsBatchCommand) ([]ShortenURLsBatchItemResult, error)
+}
+
+type shortenURLsBatchCommandHandler struct {
+	log          logger.Logger
+	cache        ports.URLCache
+	urlRepo      ports.URLRepository
+	tokenGen     ports.TokenGenerator
+	expiration   ExpirationPolicy
+	redirectCode model.RedirectCode
+}
+
+// NewShortenURLsBatchCommandHandler returns handler creating urls in batches. Urls created without redirect code
+// redirect with redirectCode.
+func NewShortenURLsBatchCommandHandler(
+	log logger.Logger,
+	cache ports.URLCache,
+	urlRepo ports.URLRepository,
+	tokenGen ports.TokenGenerator,
+	expiration ExpirationPolicy,
+	redirectCode model.RedirectCode,
+) (ShortenURLsBatchCommandHandler, error) {
+	if log == nil {
+		return nil, errs.NewValueIsRequiredError("log")
+	}
+
+	if cache == nil {
+		return nil, errs.NewValueIsRequiredError("cache")
+	}
+
+	if urlRepo == nil {
+		return nil, errs.NewValueIsRequiredError("urlRepo")
+	}
+
+	if tokenGen == nil {
+		return nil, errs.NewValueIsRequiredError("tokenGen")
+	}
+
+	if expiration.DefaultTTL <= 0 {
+		return nil, errs.NewValueIsInvalidError("expiration.DefaultTTL")
+	}
+
+	if _, err := model.ParseRedirectCode(int(redirectCode)); err != nil {
+		return nil, err
+	}
+
+	return &shortenURLsBatchCommandHandler{
+		log:          log,
+		cache:        cache,
+		urlRepo:      urlRepo,
+		tokenGen:     tokenGen,
+		expiration:   expiration,
+		redirectCode: redirectCode,
+	}, nil
+}
+
+func (h *shortenURLsBatchCommandHandler) Handle(
+	ctx context.Context,
+	cmd ShortenURLsBatchCommand,
+) ([]ShortenURLsBatchItemResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "ShortenURLsBatchCommandHandler.Handle")
+	defer span.End()
+
+	results := make([]ShortenURLsBatchItemResult, len(cmd.Items))
+	validUntil := make([]*time.Time, len(cmd.Items))
+	// pending holds indexes of items still to be saved.
+	pending := make([]int, 0, len(cmd.Items))
+
+	now := time.Now()
+	for i, item := range cmd.Items {
+		if item.ReuseExisting {
+			existing, err := h.urlRepo.GetByOriginalURL(ctx, item.OriginalURL, item.OwnerID)
+			switch {
+			case err == nil:
+				results[i].ShortenURLResult = ShortenURLResult{ShortURL: existing.ShortURL, Created: false}
+				continue
+			case !errors.Is(err, errs.ErrObjectNotFound):
+				h.log.Error("error getting existing url", "error", err)
+				results[i].Err = err
+				continue
+			}
+		}
+
+		start, err := activationStart(now, item.ValidFrom)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		vu, err := h.expiration.ValidUntil(start, item.Expiration)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		validUntil[i] = vu
+		pending = append(pending, i)
+	}
+
+	span.AddEvent("existing shortened urls reused")
+
+	saved, err := h.save(ctx, cmd, validUntil, pending, results)
+	if err != nil {
+		span.RecordError(err)
+		h.log.Error("error saving urls batch", "error", err)
+		return nil, err
+	}
+
+	span.AddEvent("shortened urls saved")
+	h.log.Debug("urls batch saved to db", "saved", len(saved), "total", len(cmd.Items))
+
+	if err = h.cache.SetMany(ctx, saved); err != nil {
+		span.RecordError(err)
+		h.log.Error("error saving urls to cache", "error", err)
+	}
+
+	return results, nil
+}
+
+// save saves items with given indexes in batches and fills their results.
+//
+// Aliases are saved as is, generated tokens colliding with existing ones are regenerated
+// and saved in the next batch up to maxTokenGenerationAttempts times.
+func (h *shortenURLsBatchCommandHandler) save(
+	ctx context.Context,
+	cmd ShortenURLsBatchCommand,
+	validUntil []*time.Time,
+	pending []int,
+	results []ShortenURLsBatchItemResult,
+) ([]*model.ShortenedURL, error) {
+	span := tracing.SpanFromContext(ctx)
+	saved := make([]*model.ShortenedURL, 0, len(pending))
+
+	for attempt := 0; attempt < maxTokenGenerationAttempts && len(pending) > 0; attempt++ {
+		urls := make([]*model.ShortenedURL, 0, len(pending))
+		indexes := make([]int, 0, len(pending))
+
+		for _, i := range pending {
+			item := cmd.Items[i]
+
+			shortURL := item.Alias
+			if shortURL == "" {
+				token, err := h.tokenGen.Generate(ctx, item.OriginalURL, attempt)
+				if err != nil {
+					results[i].Err = err
+					continue
+				}
+				shortURL = token
+			}
+
+			url, err := model.NewShortenedURL(item.OriginalURL, shortURL, validUntil[i])
+			if err != nil {
+				results[i].Err = err
+				continue
+			}
+			if item.Tags != nil {
+				url.Tags = item.Tags
+			}
+			url.RedirectCode = h.redirectCode
+			if item.RedirectCode != 0 {
+				url.RedirectCode = item.RedirectCode
+			}
+			url.Preview = item.Preview
+			url.PasswordHash = item.PasswordHash
+			url.MaxClicks = item.MaxClicks
+			url.ValidFromUTC = utcOrNil(item.ValidFrom)
+			url.FallbackURL = item.FallbackURL
+			url.TargetingRules = item.TargetingRules
+			url.OwnerID = item.OwnerID
+
+			urls = append(urls, url)
+			indexes = append(indexes, i)
+		}
+
+		if len(urls) == 0 {
+			return saved, nil
+		}
+
+		saveErrs, err := h.urlRepo.SaveBatch(ctx, urls)
+		span.AddEvent("shortened urls batch save attempt performed")
+		if err != nil {
+			return nil, err
+		}
+
+		pending = pending[:0]
+		for j, saveErr := range saveErrs {
+			i := indexes[j]
+			switch {
+			case saveErr == nil:
+				results[i].ShortenURLResult = ShortenURLResult{ShortURL: urls[j].ShortURL, Created: true}
+				saved = append(saved, urls[j])
+			case cmd.Items[i].Alias != "" || !errors.Is(saveErr, errs.ErrObjectAlreadyExists):
+				results[i].Err = saveErr
+			default:
+				h.log.Warn("generated short url collision", "short_url", urls[j].ShortURL, "attempt", attempt)
+				pending = append(pending, i)
+			}
+		}
+	}
+
+	for _, i := range pending {
+		results[i].Err = fmt.Errorf("%w: %d attempts", ErrTokenGenerationExhausted, maxTokenGenerationAttempts)
+	}
+
+	return saved, nil
+}
@@ -0,0 +1,100 @@
+//nolint:nolintlint,exhaustruct,testpackage
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/logger"
+	"github.com/dzhordano/urlshortener/mocks/core/ports_mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShortenURLsBatchCommandHandler_PerItemResults(t *testing.T) {
+	ctx := context.Background()
+	cmd := ShortenURLsBatchCommand{Items: []ShortenURLCommand{
+		{OriginalURL: "https://example.com/a"},
+		{OriginalURL: "https://example.com/b", Alias: "taken-alias"},
+		{OriginalURL: "https://example.com/c", ReuseExisting: true},
+	}}
+
+	rm := ports_mocks.NewURLRepositoryMock(t)
+	cm := ports_mocks.NewURLCacheMock(t)
+	tg := ports_mocks.NewTokenGeneratorMock(t)
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	rm.On("GetByOriginalURL", mock.Anything, "https://example.com/c", mock.Anything).
+		Return(&model.ShortenedURL{ShortURL: "EXIST000"}, nil).
+		Once()
+	tg.On("Generate", mock.Anything, "https://example.com/a", 0).Return("TAKEN000", nil).Once()
+	tg.On("Generate", mock.Anything, "https://example.com/a", 1).Return("FREE0000", nil).Once()
+	rm.On("SaveBatch", mock.Anything, mock.MatchedBy(func(urls []*model.ShortenedURL) bool {
+		return len(urls) == 2
+	})).
+		Return([]error{
+			errs.NewObjectAlreadyExistsError("shortURL", "TAKEN000"),
+			errs.NewObjectAlreadyExistsError("shortURL", "taken-alias"),
+		}, nil).
+		Once()
+	rm.On("SaveBatch", mock.Anything, mock.MatchedBy(func(urls []*model.ShortenedURL) bool {
+		return len(urls) == 1 && urls[0].ShortURL == "FREE0000"
+	})).
+		Return([]error{nil}, nil).
+		Once()
+	cm.On("SetMany", mock.Anything, mock.MatchedBy(func(urls []*model.ShortenedURL) bool {
+		return len(urls) == 1 && urls[0].ShortURL == "FREE0000"
+	})).
+		Return(nil).
+		Once()
+
+	ch, _ := NewShortenURLsBatchCommandHandler(l, cm, rm, tg, testExpirationPolicy(), model.RedirectCodeFound)
+	res, err := ch.Handle(ctx, cmd)
+
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+
+	require.NoError(t, res[0].Err)
+	assert.Equal(t, "FREE0000", res[0].ShortURL)
+	assert.True(t, res[0].Created)
+
+	require.ErrorIs(t, res[1].Err, errs.ErrObjectAlreadyExists)
+
+	require.NoError(t, res[2].Err)
+	assert.Equal(t, "EXIST000", res[2].ShortURL)
+	assert.False(t, res[2].Created)
+}
+
+func TestShortenURLsBatchCommandHandler_Internal(t *testing.T) {
+	ctx := context.Background()
+	cmd := ShortenURLsBatchCommand{Items: []ShortenURLCommand{
+		{OriginalURL: "https://example.com/a"},
+	}}
+
+	rm := ports_mocks.NewURLRepositoryMock(t)
+	cm := ports_mocks.NewURLCacheMock(t)
+	tg := ports_mocks.NewTokenGeneratorMock(t)
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	tg.On("Generate", mock.Anything, "https://example.com/a", 0).Return("RAND0000", nil).Once()
+	rm.On("SaveBatch", mock.Anything, mock.Anything).Return(nil, assert.AnError).Once()
+
+	ch, _ := NewShortenURLsBatchCommandHandler(l, cm, rm, tg, testExpirationPolicy(), model.RedirectCodeFound)
+	res, err := ch.Handle(ctx, cmd)
+
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Empty(t, res)
+}
+
+func TestNewShortenURLsBatchCommand_Size(t *testing.T) {
+	_, err := NewShortenURLsBatchCommand(nil)
+	require.ErrorIs(t, err, errs.ErrValueIsRequired)
+
+	_, err = NewShortenURLsBatchCommand(make([]ShortenURLCommand, MaxShortenBatchSize+1))
+	require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
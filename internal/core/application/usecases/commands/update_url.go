@@ -0,0 +1,198 @@
+//nolint:nolintlint,exhaustruct
+package commands
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/core/ports"
+	"github.com/dzhordano/urlshortener/internal/pkg/auth"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/logger"
+	"github.com/dzhordano/urlshortener/internal/pkg/tracing"
+)
+
+// UpdateURLCommand changes shortened url. Nil fields are left unchanged.
+type UpdateURLCommand struct {
+	// Principal is the one updating url. Only url owner or admin may update it.
+	Principal   auth.Principal
+	ShortURL    string
+	OriginalURL *string
+	// Expiration is resolved relative to the moment of update, or to activation of pending url.
+	Expiration *Expiration
+	Status     *model.URLStatus
+	// Tags replace current url tags.
+	Tags         *[]string
+	RedirectCode *model.RedirectCode
+}
+
+func NewUpdateURLCommand(
+	principal auth.Principal,
+	shortURL string,
+	originalURL *string,
+	expiration *Expiration,
+	status *string,
+	tags *[]string,
+	redirectCode *int,
+) (UpdateURLCommand, error) {
+	if shortURL == "" {
+		return UpdateURLCommand{}, errs.NewValueIsInvalidError("shortURL")
+	}
+
+	if originalURL == nil && expiration == nil && status == nil && tags == nil && redirectCode == nil {
+		return UpdateURLCommand{}, errs.NewValueIsInvalidErrorWithCause(
+			"update",
+			errors.New("at least one field must be changed"),
+		)
+	}
+
+	cmd := UpdateURLCommand{Principal: principal, ShortURL: shortURL, Expiration: expiration}
+
+	if originalURL != nil {
+		url, err := NormalizeURL(*originalURL)
+		if err != nil {
+			return UpdateURLCommand{}, err
+		}
+		cmd.OriginalURL = &url
+	}
+
+	if status != nil {
+		s, err := model.ParseURLStatus(*status)
+		if err != nil {
+			return UpdateURLCommand{}, err
+		}
+		cmd.Status = &s
+	}
+
+	if tags != nil {
+		t, err := model.NormalizeTags(*tags)
+		if err != nil {
+			return UpdateURLCommand{}, err
+		}
+		cmd.Tags = &t
+	}
+
+	if redirectCode != nil {
+		rc, err := model.ParseRedirectCode(*redirectCode)
+		if err != nil {
+			return UpdateURLCommand{}, err
+		}
+		cmd.RedirectCode = &rc
+	}
+
+	return cmd, nil
+}
+
+type UpdateURLCommandHandler interface {
+	Handle(context.Context, UpdateURLCommand) error
+}
+
+type updateURLCommandHandler struct {
+	log        logger.Logger
+	cache      ports.URLCache
+	urlRepo    ports.URLRepository
+	expiration ExpirationPolicy
+}
+
+func NewUpdateURLCommandHandler(
+	log logger.Logger,
+	cache ports.URLCache,
+	urlRepo ports.URLRepository,
+	expiration ExpirationPolicy,
+) (UpdateURLCommandHandler, error) {
+	if log == nil {
+		return nil, errs.NewValueIsRequiredError("log")
+	}
+
+	if cache == nil {
+		return nil, errs.NewValueIsRequiredError("cache")
+	}
+
+	if urlRepo == nil {
+		return nil, errs.NewValueIsRequiredError("urlRepo")
+	}
+
+	if expiration.DefaultTTL <= 0 {
+		return nil, errs.NewValueIsInvalidError("expiration.DefaultTTL")
+	}
+
+	return &updateURLCommandHandler{
+		log:        log,
+		cache:      cache,
+		urlRepo:    urlRepo,
+		expiration: expiration,
+	}, nil
+}
+
+func (h *updateURLCommandHandler) Handle(ctx context.Context, cmd UpdateURLCommand) error {
+	ctx, span := tracing.StartSpan(ctx, "UpdateURLCommandHandler.Handle")
+	defer span.End()
+
+	url, err := h.urlRepo.GetByShortenedURL(ctx, cmd.ShortURL)
+	span.AddEvent("url retrieval from db attempt performed")
+	if err != nil {
+		span.RecordError(err)
+		h.log.Error("error getting url", "error", err)
+		return err
+	}
+
+	// Not found is returned to not leak existence of someone else's url.
+	if !canManage(cmd.Principal, url) {
+		span.AddEvent("url update denied")
+		return errs.NewObjectNotFoundError("shortenedURL", cmd.ShortURL)
+	}
+
+	if cmd.OriginalURL != nil {
+		url.OriginalURL = *cmd.OriginalURL
+	}
+
+	if cmd.Expiration != nil {
+		// Lifetime of pending url is counted from its activation, as on creation.
+		start := time.Now()
+		if url.IsPending(start) {
+			start = *url.ValidFromUTC
+		}
+
+		url.ValidUntilUTC, err = h.expiration.ValidUntil(start, *cmd.Expiration)
+		if err != nil {
+			return err
+		}
+
+		if url.ValidUntilUTC != nil && url.ValidFromUTC != nil && !url.ValidUntilUTC.After(*url.ValidFromUTC) {
+			return errs.NewValueIsInvalidErrorWithCause("expiresAt", errors.New("must be after valid_from"))
+		}
+	}
+
+	if cmd.Status != nil {
+		url.Status = *cmd.Status
+	}
+
+	if cmd.Tags != nil {
+		url.Tags = *cmd.Tags
+	}
+
+	if cmd.RedirectCode != nil {
+		url.RedirectCode = *cmd.RedirectCode
+	}
+
+	err = h.urlRepo.Update(ctx, url)
+	span.AddEvent("url update attempt performed")
+	if err != nil {
+		span.RecordError(err)
+		h.log.Error("error updating url", "error", err)
+		return err
+	}
+
+	// Invalidate cache so redirects pick changes up right away.
+	err = h.cache.Delete(ctx, url.ShortURL)
+	if err != nil {
+		span.RecordError(err)
+		h.log.Error("error invalidating cached url", "short_url", url.ShortURL, "error", err)
+	}
+
+	h.log.Debug("url updated", "short_url", url.ShortURL)
+
+	return nil
+}
@@ -0,0 +1,177 @@
+//nolint:nolintlint,exhaustruct,testpackage
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/auth"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/logger"
+	"github.com/dzhordano/urlshortener/mocks/core/ports_mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUpdateURLCommand(t *testing.T) {
+	rawURL := "HTTPS://Example.com/new#frag"
+	status := "disabled"
+
+	cmd, err := NewUpdateURLCommand(testAdmin, "RAND0000", &rawURL, nil, &status, nil, nil)
+	require.NoError(t, err)
+	require.NotNil(t, cmd.OriginalURL)
+	assert.Equal(t, "https://example.com/new", *cmd.OriginalURL)
+	require.NotNil(t, cmd.Status)
+	assert.Equal(t, model.URLStatusDisabled, *cmd.Status)
+
+	_, err = NewUpdateURLCommand(testAdmin, "RAND0000", nil, nil, nil, nil, nil)
+	require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+
+	unknown := "paused"
+	_, err = NewUpdateURLCommand(testAdmin, "RAND0000", nil, nil, &unknown, nil, nil)
+	require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
+
+func TestUpdateURLCommandHandler_Success(t *testing.T) {
+	ctx := context.Background()
+	newURL := "https://example.com/new"
+	disabled := model.URLStatusDisabled
+	ttl := time.Hour
+	cmd := UpdateURLCommand{
+		Principal:   testAdmin,
+		ShortURL:    "RAND0000",
+		OriginalURL: &newURL,
+		Expiration:  &Expiration{TTL: &ttl},
+		Status:      &disabled,
+	}
+
+	rm := ports_mocks.NewURLRepositoryMock(t)
+	cm := ports_mocks.NewURLCacheMock(t)
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	rm.On("GetByShortenedURL", mock.Anything, cmd.ShortURL).
+		Return(&model.ShortenedURL{
+			OriginalURL: "https://example.com",
+			ShortURL:    cmd.ShortURL,
+			Status:      model.URLStatusActive,
+		}, nil).
+		Once()
+	rm.On("Update", mock.Anything, mock.MatchedBy(func(u *model.ShortenedURL) bool {
+		return u.OriginalURL == newURL &&
+			u.Status == model.URLStatusDisabled &&
+			u.ValidUntilUTC != nil &&
+			u.ValidUntilUTC.Sub(time.Now()) <= ttl
+	})).Return(nil).Once()
+	// Cached url must be invalidated.
+	cm.On("Delete", mock.Anything, cmd.ShortURL).Return(nil).Once()
+
+	ch, _ := NewUpdateURLCommandHandler(l, cm, rm, testExpirationPolicy())
+	err = ch.Handle(ctx, cmd)
+
+	require.NoError(t, err)
+}
+
+func TestUpdateURLCommandHandler_NotFound(t *testing.T) {
+	ctx := context.Background()
+	disabled := model.URLStatusDisabled
+	cmd := UpdateURLCommand{Principal: testAdmin, ShortURL: "RAND0000", Status: &disabled}
+
+	rm := ports_mocks.NewURLRepositoryMock(t)
+	cm := ports_mocks.NewURLCacheMock(t)
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	rm.On("GetByShortenedURL", mock.Anything, cmd.ShortURL).
+		Return(nil, errs.NewObjectNotFoundError("shortenedURL", cmd.ShortURL)).
+		Once()
+
+	ch, _ := NewUpdateURLCommandHandler(l, cm, rm, testExpirationPolicy())
+	err = ch.Handle(ctx, cmd)
+
+	require.ErrorIs(t, err, errs.ErrObjectNotFound)
+}
+
+func TestUpdateURLCommandHandler_ExpirationExceedsMax(t *testing.T) {
+	ctx := context.Background()
+	ttl := 30 * 24 * time.Hour
+	cmd := UpdateURLCommand{Principal: testAdmin, ShortURL: "RAND0000", Expiration: &Expiration{TTL: &ttl}}
+
+	rm := ports_mocks.NewURLRepositoryMock(t)
+	cm := ports_mocks.NewURLCacheMock(t)
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	rm.On("GetByShortenedURL", mock.Anything, cmd.ShortURL).
+		Return(&model.ShortenedURL{ShortURL: cmd.ShortURL, Status: model.URLStatusActive}, nil).
+		Once()
+
+	ch, _ := NewUpdateURLCommandHandler(l, cm, rm, testExpirationPolicy())
+	err = ch.Handle(ctx, cmd)
+
+	require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
+
+func TestUpdateURLCommandHandler_PendingURLExpiration(t *testing.T) {
+	ctx := context.Background()
+	validFrom := time.Now().Add(2 * time.Hour).UTC()
+	pending := func() *model.ShortenedURL {
+		return &model.ShortenedURL{ShortURL: "RAND0000", Status: model.URLStatusActive, ValidFromUTC: &validFrom}
+	}
+
+	rm := ports_mocks.NewURLRepositoryMock(t)
+	cm := ports_mocks.NewURLCacheMock(t)
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	ch, _ := NewUpdateURLCommandHandler(l, cm, rm, testExpirationPolicy())
+
+	// Lifetime is counted from activation.
+	ttl := time.Hour
+	rm.On("GetByShortenedURL", mock.Anything, "RAND0000").Return(pending(), nil).Once()
+	rm.On("Update", mock.Anything, mock.MatchedBy(func(u *model.ShortenedURL) bool {
+		return u.ValidUntilUTC != nil && u.ValidUntilUTC.Equal(validFrom.Add(ttl))
+	})).Return(nil).Once()
+	cm.On("Delete", mock.Anything, "RAND0000").Return(nil).Once()
+
+	err = ch.Handle(ctx, UpdateURLCommand{Principal: testAdmin, ShortURL: "RAND0000", Expiration: &Expiration{TTL: &ttl}})
+	require.NoError(t, err)
+
+	// Url can't expire before it's active.
+	for _, expiresAt := range []time.Time{validFrom.Add(-time.Hour), validFrom} {
+		rm.On("GetByShortenedURL", mock.Anything, "RAND0000").Return(pending(), nil).Once()
+
+		err = ch.Handle(ctx, UpdateURLCommand{
+			Principal:  testAdmin,
+			ShortURL:   "RAND0000",
+			Expiration: &Expiration{ExpiresAt: &expiresAt},
+		})
+		require.ErrorIs(t, err, errs.ErrValueIsInvalid, expiresAt)
+	}
+}
+
+func TestUpdateURLCommandHandler_NotOwner(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+	disabled := model.URLStatusDisabled
+	cmd := UpdateURLCommand{Principal: auth.Principal{KeyID: uuid.New()}, ShortURL: "RAND0000", Status: &disabled}
+
+	rm := ports_mocks.NewURLRepositoryMock(t)
+	cm := ports_mocks.NewURLCacheMock(t)
+	l, err := logger.NewSlogLogger(true, "debug")
+	require.NoError(t, err)
+
+	rm.On("GetByShortenedURL", mock.Anything, cmd.ShortURL).
+		Return(&model.ShortenedURL{ShortURL: cmd.ShortURL, Status: model.URLStatusActive, OwnerID: &ownerID}, nil).
+		Once()
+
+	ch, _ := NewUpdateURLCommandHandler(l, cm, rm, testExpirationPolicy())
+	err = ch.Handle(ctx, cmd)
+
+	// Someone else's url looks like a missing one.
+	require.ErrorIs(t, err, errs.ErrObjectNotFound)
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/core/ports"
+	"github.com/dzhordano/urlshortener/internal/pkg/auth"
 	"github.com/dzhordano/urlshortener/internal/pkg/errs"
 	"github.com/dzhordano/urlshortener/internal/pkg/logger"
 	"github.com/dzhordano/urlshortener/internal/pkg/tracing"
@@ -14,26 +16,48 @@ import (
 )
 
 type GetURLInfoQuery struct {
-	ShortURL string
+	// Principal is the one requesting info. Only url owner or admin may see it.
+	Principal auth.Principal
+	ShortURL  string
 }
 
-func NewGetURLInfoQuery(shortURL string) (GetURLInfoQuery, error) {
+func NewGetURLInfoQuery(principal auth.Principal, shortURL string) (GetURLInfoQuery, error) {
 	if shortURL == "" {
 		return GetURLInfoQuery{}, errs.NewValueIsInvalidError("shortURL")
 	}
 
 	return GetURLInfoQuery{
-		ShortURL: shortURL,
+		Principal: principal,
+		ShortURL:  shortURL,
 	}, nil
 }
 
 type GetURLInfoResponse struct {
-	ID            string
-	OriginalURL   string
-	ShortURL      string
-	Clicks        int
-	CreatedAtUTC  time.Time
-	ValidUntilUTC time.Time
+	ID           string
+	OriginalURL  string
+	ShortURL     string
+	Clicks       int
+	BotClicks    int
+	CreatedAtUTC time.Time
+	// ValidFromUTC is nil for urls active since creation.
+	ValidFromUTC *time.Time
+	// ValidUntilUTC is nil for never expiring urls.
+	ValidUntilUTC *time.Time
+	// Pending is set for urls not active yet.
+	Pending      bool
+	Status       string
+	Tags         []string
+	RedirectCode model.RedirectCode
+	// Preview is nil if url has no custom preview.
+	Preview           *model.LinkPreview
+	PasswordProtected bool
+	// MaxClicks is nil for urls without click limit.
+	MaxClicks   *int
+	FallbackURL string
+	// TargetingRules are nil for urls redirecting everyone to OriginalURL.
+	TargetingRules []model.TargetingRule
+	// UniqueVisitors is approximate amount of distinct visitors. Nil if it's not known.
+	UniqueVisitors *int
 }
 
 type GetURLInfoQueryHandler interface {
@@ -41,25 +65,32 @@ type GetURLInfoQueryHandler interface {
 }
 
 type getURLInfoQueryHandler struct {
-	log logger.Logger
-	db  *pgxpool.Pool
+	log      logger.Logger
+	visitors ports.VisitorCounter
+	db       *pgxpool.Pool
 }
 
 func NewGetURLInfoQueryHandler(
 	log logger.Logger,
+	visitors ports.VisitorCounter,
 	db *pgxpool.Pool,
 ) (GetURLInfoQueryHandler, error) {
 	if log == nil {
 		return nil, errs.NewValueIsRequiredError("log")
 	}
 
+	if visitors == nil {
+		return nil, errs.NewValueIsRequiredError("visitors")
+	}
+
 	if db == nil {
 		return nil, errs.NewValueIsRequiredError("db")
 	}
 
 	return &getURLInfoQueryHandler{
-		log: log,
-		db:  db,
+		log:      log,
+		visitors: visitors,
+		db:       db,
 	}, nil
 }
 
@@ -70,19 +101,41 @@ func (h *getURLInfoQueryHandler) Handle(
 	ctx, span := tracing.StartSpan(ctx, "GetURLInfoQueryHandler.Handle")
 	defer span.End()
 
-	// Get full url info using short url
+	// Get full url info using short url. Someone else's url is not found, so its existence isn't leaked.
 	query := `
-	SELECT id, original_url, short_url, clicks, created_at, valid_until 
+	SELECT id, original_url, short_url, clicks, bot_clicks, created_at, valid_until, status, tags, redirect_code,
+		preview_title, preview_description, preview_image_url, password_hash, max_clicks,
+		valid_from, fallback_url, ` + targetingRulesColumn + `
 	FROM urls
-	WHERE short_url = $1`
-	var url model.ShortenedURL
-	err := h.db.QueryRow(ctx, query, q.ShortURL).Scan(
+	WHERE short_url = $1 AND deleted_at IS NULL AND ($2 OR owner_id = $3)`
+	var (
+		url     model.ShortenedURL
+		preview model.LinkPreview
+		rules   []targetingRuleRow
+	)
+	err := h.db.QueryRow(
+		ctx,
+		query,
+		q.ShortURL, q.Principal.HasScope(model.ScopeAdmin), q.Principal.KeyID,
+	).Scan(
 		&url.ID,
 		&url.OriginalURL,
 		&url.ShortURL,
 		&url.Clicks,
+		&url.BotClicks,
 		&url.CreatedAtUTC,
 		&url.ValidUntilUTC,
+		&url.Status,
+		&url.Tags,
+		&url.RedirectCode,
+		&preview.Title,
+		&preview.Description,
+		&preview.ImageURL,
+		&url.PasswordHash,
+		&url.MaxClicks,
+		&url.ValidFromUTC,
+		&url.FallbackURL,
+		&rules,
 	)
 	span.AddEvent("url query db attempt performed")
 	if err != nil {
@@ -96,14 +149,44 @@ func (h *getURLInfoQueryHandler) Handle(
 	}
 
 	span.AddEvent("url query db succeeded")
-	h.log.Debug("url info", "url", url)
+	h.log.Debug("url info", "short_url", url.ShortURL)
+
+	// Info is still returned if visitors can't be counted.
+	var uniqueVisitors *int
+	if n, vErr := h.visitors.Count(ctx, q.ShortURL); vErr != nil {
+		span.RecordError(vErr)
+		h.log.Warn("error counting url visitors", "short_url", q.ShortURL, "error", vErr)
+	} else {
+		uniqueVisitors = &n
+	}
 
 	return GetURLInfoResponse{
-		ID:            url.ID.String(),
-		OriginalURL:   url.OriginalURL,
-		ShortURL:      url.ShortURL,
-		Clicks:        url.Clicks,
-		CreatedAtUTC:  url.CreatedAtUTC,
-		ValidUntilUTC: url.ValidUntilUTC,
+		ID:                url.ID.String(),
+		OriginalURL:       url.OriginalURL,
+		ShortURL:          url.ShortURL,
+		Clicks:            url.Clicks,
+		BotClicks:         url.BotClicks,
+		CreatedAtUTC:      url.CreatedAtUTC,
+		ValidFromUTC:      url.ValidFromUTC,
+		ValidUntilUTC:     url.ValidUntilUTC,
+		Pending:           url.IsPending(time.Now()),
+		Status:            string(url.Status),
+		Tags:              url.Tags,
+		RedirectCode:      url.RedirectCode,
+		Preview:           previewOrNil(preview),
+		PasswordProtected: url.IsPasswordProtected(),
+		MaxClicks:         url.MaxClicks,
+		FallbackURL:       url.FallbackURL,
+		TargetingRules:    toTargetingRules(rules),
+		UniqueVisitors:    uniqueVisitors,
 	}, nil
 }
+
+// previewOrNil returns nil for preview scanned from url without one, which has empty preview columns.
+func previewOrNil(preview model.LinkPreview) *model.LinkPreview {
+	if preview.Title == "" {
+		return nil
+	}
+
+	return &preview
+}
@@ -0,0 +1,354 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/auth"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/logger"
+	"github.com/dzhordano/urlshortener/internal/pkg/tracing"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// URLStatsDefaultRange is stats period ending now used if from isn't given.
+	URLStatsDefaultRange = 7 * 24 * time.Hour
+	// URLStatsMaxBuckets bounds amount of time series buckets returned at once.
+	URLStatsMaxBuckets = 1000
+	// URLStatsTopLimit is amount of top values returned per dimension.
+	URLStatsTopLimit = 10
+)
+
+// URLStatsInterval is width of time series bucket.
+type URLStatsInterval string
+
+const (
+	URLStatsIntervalHour URLStatsInterval = "hour"
+	URLStatsIntervalDay  URLStatsInterval = "day"
+	// URLStatsIntervalWeek buckets start on mondays.
+	URLStatsIntervalWeek URLStatsInterval = "week"
+)
+
+// Truncate returns start of bucket t is in.
+func (i URLStatsInterval) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+
+	switch i {
+	case URLStatsIntervalHour:
+		return t.Truncate(time.Hour)
+	case URLStatsIntervalWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		sinceMonday := (int(day.Weekday()) + 6) % 7 //nolint:mnd // Days of week.
+		return day.AddDate(0, 0, -sinceMonday)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// Next returns start of bucket following the one starting at start.
+func (i URLStatsInterval) Next(start time.Time) time.Time {
+	switch i {
+	case URLStatsIntervalHour:
+		return start.Add(time.Hour)
+	case URLStatsIntervalWeek:
+		return start.AddDate(0, 0, 7) //nolint:mnd // Days of week.
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+type GetURLStatsQuery struct {
+	// Principal is the one requesting stats. Only url owner or admin may see them.
+	Principal auth.Principal
+	ShortURL  string
+	// FromUTC and ToUTC bound stats period, to is exclusive. Both are whole hours, since stats are rolled up hourly.
+	FromUTC  time.Time
+	ToUTC    time.Time
+	Interval URLStatsInterval
+}
+
+// NewGetURLStatsQuery returns stats query for period [from, to) rounded down to hours.
+// Missing to defaults to now, missing from to URLStatsDefaultRange before to, empty interval to day.
+func NewGetURLStatsQuery(
+	principal auth.Principal,
+	shortURL string,
+	from, to *time.Time,
+	interval string,
+) (GetURLStatsQuery, error) {
+	if shortURL == "" {
+		return GetURLStatsQuery{}, errs.NewValueIsInvalidError("shortURL")
+	}
+
+	q := GetURLStatsQuery{
+		Principal: principal,
+		ShortURL:  shortURL,
+		Interval:  URLStatsIntervalDay,
+	}
+
+	switch URLStatsInterval(interval) {
+	case "", URLStatsIntervalDay:
+	case URLStatsIntervalHour, URLStatsIntervalWeek:
+		q.Interval = URLStatsInterval(interval)
+	default:
+		return GetURLStatsQuery{}, errs.NewValueIsInvalidErrorWithCause(
+			"interval",
+			fmt.Errorf("must be one of %q, %q, %q", URLStatsIntervalHour, URLStatsIntervalDay, URLStatsIntervalWeek),
+		)
+	}
+
+	q.ToUTC = time.Now()
+	if to != nil {
+		q.ToUTC = *to
+	}
+	q.ToUTC = q.ToUTC.UTC().Truncate(time.Hour)
+
+	q.FromUTC = q.ToUTC.Add(-URLStatsDefaultRange)
+	if from != nil {
+		q.FromUTC = from.UTC().Truncate(time.Hour)
+	}
+
+	if !q.FromUTC.Before(q.ToUTC) {
+		return GetURLStatsQuery{}, errs.NewValueIsInvalidErrorWithCause(
+			"from",
+			errors.New("must be at least an hour before to"),
+		)
+	}
+
+	buckets := 0
+	for start := q.Interval.Truncate(q.FromUTC); start.Before(q.ToUTC); start = q.Interval.Next(start) {
+		buckets++
+		if buckets > URLStatsMaxBuckets {
+			return GetURLStatsQuery{}, errs.NewValueIsInvalidErrorWithCause(
+				"from",
+				fmt.Errorf("period must fit in %d %s buckets", URLStatsMaxBuckets, q.Interval),
+			)
+		}
+	}
+
+	return q, nil
+}
+
+// ClicksBucket is amount of clicks made in bucket starting at StartUTC.
+type ClicksBucket struct {
+	StartUTC time.Time
+	Clicks   int
+}
+
+// StatsEntry is amount of clicks made with dimension (e.g. referrer) of value.
+type StatsEntry struct {
+	Value  string
+	Clicks int
+}
+
+type GetURLStatsResponse struct {
+	ShortURL string
+	FromUTC  time.Time
+	ToUTC    time.Time
+	Interval URLStatsInterval
+	// Clicks is amount of clicks made in the period.
+	Clicks int
+	// Buckets cover the whole period, buckets without clicks included. Edge buckets may be partial.
+	Buckets      []ClicksBucket
+	TopReferrers []StatsEntry
+	TopCountries []StatsEntry
+	Devices      []StatsEntry
+	Browsers     []StatsEntry
+	OSs          []StatsEntry
+	// UniqueVisitors is estimate of distinct visitors in days the period touches, summed up by day.
+	// Visitor is told by anonymized ip and user agent, so visitors sharing both count as one.
+	// Visitor coming back another day is counted again, since visitors aren't tracked across days.
+	UniqueVisitors int
+}
+
+type GetURLStatsQueryHandler interface {
+	Handle(context.Context, GetURLStatsQuery) (GetURLStatsResponse, error)
+}
+
+type getURLStatsQueryHandler struct {
+	log logger.Logger
+	db  *pgxpool.Pool
+}
+
+// NewGetURLStatsQueryHandler returns handler reading stats from click rollups.
+// Clicks appear in stats once rollup task processes them.
+func NewGetURLStatsQueryHandler(
+	log logger.Logger,
+	db *pgxpool.Pool,
+) (GetURLStatsQueryHandler, error) {
+	if log == nil {
+		return nil, errs.NewValueIsRequiredError("log")
+	}
+
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+
+	return &getURLStatsQueryHandler{
+		log: log,
+		db:  db,
+	}, nil
+}
+
+func (h *getURLStatsQueryHandler) Handle(
+	ctx context.Context,
+	q GetURLStatsQuery,
+) (GetURLStatsResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "GetURLStatsQueryHandler.Handle")
+	defer span.End()
+
+	// Someone else's url is not found, so its existence isn't leaked. Stats are read by url id, so url
+	// holding short url of purged one doesn't see its stats.
+	query := `
+	SELECT id
+	FROM urls
+	WHERE short_url = $1 AND deleted_at IS NULL AND ($2 OR owner_id = $3)`
+	var urlID uuid.UUID
+	err := h.db.QueryRow(
+		ctx,
+		query,
+		q.ShortURL, q.Principal.HasScope(model.ScopeAdmin), q.Principal.KeyID,
+	).Scan(&urlID)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return GetURLStatsResponse{}, errs.NewObjectNotFoundError("short url", q.ShortURL)
+		}
+
+		h.log.Error("error getting url", "error", err)
+		return GetURLStatsResponse{}, err
+	}
+
+	resp := GetURLStatsResponse{
+		ShortURL: q.ShortURL,
+		FromUTC:  q.FromUTC,
+		ToUTC:    q.ToUTC,
+		Interval: q.Interval,
+	}
+
+	if resp.Buckets, resp.Clicks, err = h.buckets(ctx, urlID, q); err != nil {
+		span.RecordError(err)
+		h.log.Error("error getting url clicks over time", "error", err)
+		return GetURLStatsResponse{}, err
+	}
+	span.AddEvent("clicks over time retrieved")
+
+	top, err := h.topValues(ctx, urlID, q)
+	if err != nil {
+		span.RecordError(err)
+		h.log.Error("error getting url top values", "error", err)
+		return GetURLStatsResponse{}, err
+	}
+	resp.TopReferrers = top["referrer"]
+	resp.TopCountries = top["country"]
+	resp.Devices = top["device"]
+	resp.Browsers = top["browser"]
+	resp.OSs = top["os"]
+	span.AddEvent("top values retrieved")
+
+	// Visitors of recent days are still kept as hashes, older ones as counts.
+	query = `
+	SELECT
+		(SELECT COUNT(*) FROM click_rollups_visitors WHERE url_id = $1 AND day >= $2::date AND day <= $3::date) +
+		(SELECT COALESCE(SUM(visitors), 0) FROM click_rollups_visitor_counts
+		 WHERE url_id = $1 AND day >= $2::date AND day <= $3::date)`
+	err = h.db.QueryRow(ctx, query, urlID, q.FromUTC, q.ToUTC.Add(-time.Hour)).Scan(&resp.UniqueVisitors)
+	if err != nil {
+		span.RecordError(err)
+		h.log.Error("error getting url unique visitors", "error", err)
+		return GetURLStatsResponse{}, err
+	}
+	span.AddEvent("unique visitors retrieved")
+
+	return resp, nil
+}
+
+// buckets returns clicks per bucket over the whole period and their total.
+func (h *getURLStatsQueryHandler) buckets(
+	ctx context.Context,
+	urlID uuid.UUID,
+	q GetURLStatsQuery,
+) ([]ClicksBucket, int, error) {
+	query := `
+	SELECT date_trunc($2, bucket_start, 'UTC'), SUM(clicks)
+	FROM click_rollups_hourly
+	WHERE url_id = $1 AND bucket_start >= $3 AND bucket_start < $4
+	GROUP BY 1`
+
+	rows, err := h.db.Query(ctx, query, urlID, string(q.Interval), q.FromUTC, q.ToUTC)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	clicks := make(map[time.Time]int)
+	for rows.Next() {
+		var (
+			start time.Time
+			n     int
+		)
+		if err = rows.Scan(&start, &n); err != nil {
+			rows.Close()
+			return nil, 0, err
+		}
+		clicks[start.UTC()] = n
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		buckets []ClicksBucket
+		total   int
+	)
+	for start := q.Interval.Truncate(q.FromUTC); start.Before(q.ToUTC); start = q.Interval.Next(start) {
+		buckets = append(buckets, ClicksBucket{StartUTC: start, Clicks: clicks[start]})
+		total += clicks[start]
+	}
+
+	return buckets, total, nil
+}
+
+// topValues returns values with the most clicks in the period per dimension.
+func (h *getURLStatsQueryHandler) topValues(
+	ctx context.Context,
+	urlID uuid.UUID,
+	q GetURLStatsQuery,
+) (map[string][]StatsEntry, error) {
+	query := `
+	SELECT dimension, value, clicks
+	FROM (
+		SELECT dimension, value, SUM(clicks) AS clicks,
+			ROW_NUMBER() OVER (PARTITION BY dimension ORDER BY SUM(clicks) DESC, value) AS rank
+		FROM click_rollups_dimensions
+		WHERE url_id = $1 AND bucket_start >= $2 AND bucket_start < $3
+		GROUP BY dimension, value
+	) AS ranked
+	WHERE rank <= $4
+	ORDER BY dimension, rank`
+
+	rows, err := h.db.Query(ctx, query, urlID, q.FromUTC, q.ToUTC, URLStatsTopLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	top := make(map[string][]StatsEntry)
+	for rows.Next() {
+		var (
+			dimension string
+			entry     StatsEntry
+		)
+		if err = rows.Scan(&dimension, &entry.Value, &entry.Clicks); err != nil {
+			return nil, err
+		}
+		top[dimension] = append(top[dimension], entry)
+	}
+
+	return top, rows.Err()
+}
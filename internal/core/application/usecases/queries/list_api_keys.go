@@ -0,0 +1,130 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/logger"
+	"github.com/dzhordano/urlshortener/internal/pkg/tracing"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ListAPIKeysQuery struct {
+	// IncludeRevoked makes revoked keys listed too.
+	IncludeRevoked bool
+}
+
+func NewListAPIKeysQuery(includeRevoked bool) (ListAPIKeysQuery, error) {
+	return ListAPIKeysQuery{IncludeRevoked: includeRevoked}, nil
+}
+
+// APIKeyInfo describes api key. The key itself is never returned.
+type APIKeyInfo struct {
+	ID            string
+	Prefix        string
+	Name          string
+	Role          string
+	Scopes        []string
+	CreatedAtUTC  time.Time
+	LastUsedAtUTC *time.Time
+	ExpiresAtUTC  *time.Time
+	Revoked       bool
+}
+
+type ListAPIKeysResponse struct {
+	Keys []APIKeyInfo
+}
+
+type ListAPIKeysQueryHandler interface {
+	Handle(context.Context, ListAPIKeysQuery) (ListAPIKeysResponse, error)
+}
+
+type listAPIKeysQueryHandler struct {
+	log logger.Logger
+	db  *pgxpool.Pool
+}
+
+func NewListAPIKeysQueryHandler(
+	log logger.Logger,
+	db *pgxpool.Pool,
+) (ListAPIKeysQueryHandler, error) {
+	if log == nil {
+		return nil, errs.NewValueIsRequiredError("log")
+	}
+
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+
+	return &listAPIKeysQueryHandler{
+		log: log,
+		db:  db,
+	}, nil
+}
+
+func (h *listAPIKeysQueryHandler) Handle(
+	ctx context.Context,
+	q ListAPIKeysQuery,
+) (ListAPIKeysResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "ListAPIKeysQueryHandler.Handle")
+	defer span.End()
+
+	query := `
+	SELECT id, prefix, name, role, scopes, created_at, last_used_at, expires_at, revoked
+	FROM api_keys
+	WHERE $1 OR NOT revoked
+	ORDER BY created_at DESC, id DESC`
+
+	rows, err := h.db.Query(ctx, query, q.IncludeRevoked)
+	span.AddEvent("api keys query db attempt performed")
+	if err != nil {
+		span.RecordError(err)
+		h.log.Error("error listing api keys", "error", err)
+		return ListAPIKeysResponse{}, err
+	}
+	defer rows.Close()
+
+	resp := ListAPIKeysResponse{Keys: []APIKeyInfo{}}
+	for rows.Next() {
+		var key model.APIKey
+		err = rows.Scan(
+			&key.ID,
+			&key.Prefix,
+			&key.Name,
+			&key.Role,
+			&key.Scopes,
+			&key.CreatedAtUTC,
+			&key.LastUsedAtUTC,
+			&key.ExpiresAtUTC,
+			&key.Revoked,
+		)
+		if err != nil {
+			span.RecordError(err)
+			h.log.Error("error scanning api key", "error", err)
+			return ListAPIKeysResponse{}, err
+		}
+
+		resp.Keys = append(resp.Keys, APIKeyInfo{
+			ID:            key.ID.String(),
+			Prefix:        key.Prefix,
+			Name:          key.Name,
+			Role:          string(key.Role),
+			Scopes:        key.Scopes,
+			CreatedAtUTC:  key.CreatedAtUTC,
+			LastUsedAtUTC: key.LastUsedAtUTC,
+			ExpiresAtUTC:  key.ExpiresAtUTC,
+			Revoked:       key.Revoked,
+		})
+	}
+	if err = rows.Err(); err != nil {
+		span.RecordError(err)
+		h.log.Error("error listing api keys", "error", err)
+		return ListAPIKeysResponse{}, err
+	}
+
+	span.AddEvent("api keys query db succeeded")
+
+	return resp, nil
+}
@@ -0,0 +1,418 @@
+package queries
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/auth"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/logger"
+	"github.com/dzhordano/urlshortener/internal/pkg/tracing"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	ListURLsDefaultLimit = 20
+	ListURLsMaxLimit     = 100
+)
+
+// URLState filters listed urls by their redirect state.
+type URLState string
+
+const (
+	// URLStateActive urls are enabled, already active and not expired.
+	URLStateActive URLState = "active"
+	// URLStateExpired urls are past their valid until.
+	URLStateExpired URLState = "expired"
+	// URLStateDisabled urls are disabled via update.
+	URLStateDisabled URLState = "disabled"
+	// URLStatePending urls aren't active yet.
+	URLStatePending URLState = "pending"
+)
+
+type ListURLsSortBy string
+
+const (
+	ListURLsSortByCreatedAt ListURLsSortBy = "created_at"
+	ListURLsSortByClicks    ListURLsSortBy = "clicks"
+)
+
+// ListURLsFilter narrows listed urls. Zero fields are not applied.
+type ListURLsFilter struct {
+	State       URLState
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// Host is substring of original url's host.
+	Host string
+	Tag  string
+	// OwnerID narrows admin's listing to urls created with api key. Others list only their own urls anyway.
+	OwnerID *uuid.UUID
+}
+
+type ListURLsQuery struct {
+	// Principal is the one listing urls. Admin lists all urls, others only their own ones.
+	Principal auth.Principal
+	Filter    ListURLsFilter
+	SortBy    ListURLsSortBy
+	Desc      bool
+	Limit     int
+	// Cursor is NextCursor of previous page. Empty for the first page.
+	Cursor string
+}
+
+func NewListURLsQuery(
+	principal auth.Principal,
+	filter ListURLsFilter,
+	sortBy string,
+	order string,
+	limit int,
+	cursor string,
+) (ListURLsQuery, error) {
+	switch filter.State {
+	case "", URLStateActive, URLStateExpired, URLStateDisabled, URLStatePending:
+	default:
+		return ListURLsQuery{}, errs.NewValueIsInvalidErrorWithCause(
+			"status",
+			fmt.Errorf(
+				"must be one of %q, %q, %q, %q",
+				URLStateActive, URLStateExpired, URLStateDisabled, URLStatePending,
+			),
+		)
+	}
+
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && filter.CreatedFrom.After(*filter.CreatedTo) {
+		return ListURLsQuery{}, errs.NewValueIsInvalidErrorWithCause(
+			"created_from",
+			errors.New("must not be after created_to"),
+		)
+	}
+
+	filter.Tag = strings.ToLower(filter.Tag)
+	filter.Host = strings.ToLower(filter.Host)
+
+	q := ListURLsQuery{
+		Principal: principal,
+		Filter:    filter,
+		SortBy:    ListURLsSortByCreatedAt,
+		Desc:      true,
+		Limit:     ListURLsDefaultLimit,
+		Cursor:    cursor,
+	}
+
+	switch ListURLsSortBy(sortBy) {
+	case "", ListURLsSortByCreatedAt:
+	case ListURLsSortByClicks:
+		q.SortBy = ListURLsSortByClicks
+	default:
+		return ListURLsQuery{}, errs.NewValueIsInvalidErrorWithCause(
+			"sort_by",
+			fmt.Errorf("must be one of %q, %q", ListURLsSortByCreatedAt, ListURLsSortByClicks),
+		)
+	}
+
+	switch order {
+	case "", "desc":
+	case "asc":
+		q.Desc = false
+	default:
+		return ListURLsQuery{}, errs.NewValueIsInvalidErrorWithCause("order", errors.New("must be asc or desc"))
+	}
+
+	if limit < 0 || limit > ListURLsMaxLimit {
+		return ListURLsQuery{}, errs.NewValueIsInvalidErrorWithCause(
+			"limit",
+			fmt.Errorf("must be between 1 and %d", ListURLsMaxLimit),
+		)
+	}
+	if limit > 0 {
+		q.Limit = limit
+	}
+
+	if cursor != "" {
+		c, err := decodeListCursor(cursor)
+		if err != nil {
+			return ListURLsQuery{}, err
+		}
+
+		// Keyset of cursor made for another sort points to wrong page.
+		if c.SortBy != q.SortBy || c.Desc != q.Desc {
+			return ListURLsQuery{}, errs.NewValueIsInvalidErrorWithCause(
+				"cursor",
+				errors.New("does not match sort_by and order"),
+			)
+		}
+	}
+
+	return q, nil
+}
+
+type ListURLsResponse struct {
+	URLs []GetURLInfoResponse
+	// NextCursor is empty for the last page.
+	NextCursor string
+}
+
+type ListURLsQueryHandler interface {
+	Handle(context.Context, ListURLsQuery) (ListURLsResponse, error)
+}
+
+type listURLsQueryHandler struct {
+	log logger.Logger
+	db  *pgxpool.Pool
+}
+
+func NewListURLsQueryHandler(
+	log logger.Logger,
+	db *pgxpool.Pool,
+) (ListURLsQueryHandler, error) {
+	if log == nil {
+		return nil, errs.NewValueIsRequiredError("log")
+	}
+
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+
+	return &listURLsQueryHandler{
+		log: log,
+		db:  db,
+	}, nil
+}
+
+func (h *listURLsQueryHandler) Handle(
+	ctx context.Context,
+	q ListURLsQuery,
+) (ListURLsResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "ListURLsQueryHandler.Handle")
+	defer span.End()
+
+	query, args, err := buildListURLsQuery(q)
+	if err != nil {
+		return ListURLsResponse{}, err
+	}
+
+	rows, err := h.db.Query(ctx, query, args...)
+	span.AddEvent("urls query db attempt performed")
+	if err != nil {
+		span.RecordError(err)
+		h.log.Error("error listing urls", "error", err)
+		return ListURLsResponse{}, err
+	}
+	defer rows.Close()
+
+	urls := make([]model.ShortenedURL, 0, q.Limit+1)
+	for rows.Next() {
+		var (
+			url     model.ShortenedURL
+			preview model.LinkPreview
+			rules   []targetingRuleRow
+		)
+		err = rows.Scan(
+			&url.ID,
+			&url.OriginalURL,
+			&url.ShortURL,
+			&url.Clicks,
+			&url.BotClicks,
+			&url.CreatedAtUTC,
+			&url.ValidUntilUTC,
+			&url.Status,
+			&url.Tags,
+			&url.RedirectCode,
+			&preview.Title,
+			&preview.Description,
+			&preview.ImageURL,
+			&url.PasswordHash,
+			&url.MaxClicks,
+			&url.ValidFromUTC,
+			&url.FallbackURL,
+			&rules,
+		)
+		if err != nil {
+			span.RecordError(err)
+			h.log.Error("error scanning url", "error", err)
+			return ListURLsResponse{}, err
+		}
+		url.Preview = previewOrNil(preview)
+		url.TargetingRules = toTargetingRules(rules)
+		urls = append(urls, url)
+	}
+	if err = rows.Err(); err != nil {
+		span.RecordError(err)
+		h.log.Error("error listing urls", "error", err)
+		return ListURLsResponse{}, err
+	}
+
+	span.AddEvent("urls query db succeeded")
+
+	var resp ListURLsResponse
+	// One extra row is fetched to find out whether there is next page.
+	if len(urls) > q.Limit {
+		urls = urls[:q.Limit]
+		last := urls[len(urls)-1]
+		resp.NextCursor = encodeListCursor(listCursor{
+			SortBy:    q.SortBy,
+			Desc:      q.Desc,
+			CreatedAt: last.CreatedAtUTC,
+			Clicks:    last.Clicks,
+			ID:        last.ID,
+		})
+	}
+
+	now := time.Now()
+	resp.URLs = make([]GetURLInfoResponse, 0, len(urls))
+	for _, url := range urls {
+		resp.URLs = append(resp.URLs, GetURLInfoResponse{
+			ID:                url.ID.String(),
+			OriginalURL:       url.OriginalURL,
+			ShortURL:          url.ShortURL,
+			Clicks:            url.Clicks,
+			BotClicks:         url.BotClicks,
+			CreatedAtUTC:      url.CreatedAtUTC,
+			ValidFromUTC:      url.ValidFromUTC,
+			ValidUntilUTC:     url.ValidUntilUTC,
+			Pending:           url.IsPending(now),
+			Status:            string(url.Status),
+			Tags:              url.Tags,
+			RedirectCode:      url.RedirectCode,
+			Preview:           url.Preview,
+			PasswordProtected: url.IsPasswordProtected(),
+			MaxClicks:         url.MaxClicks,
+			FallbackURL:       url.FallbackURL,
+			TargetingRules:    url.TargetingRules,
+		})
+	}
+
+	return resp, nil
+}
+
+// buildListURLsQuery builds keyset paginated query. Keyset is (sort column, id).
+func buildListURLsQuery(q ListURLsQuery) (string, []any, error) {
+	var (
+		conds = []string{"deleted_at IS NULL"}
+		args  []any
+	)
+
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if !q.Principal.HasScope(model.ScopeAdmin) {
+		conds = append(conds, "owner_id = "+arg(q.Principal.KeyID))
+	}
+
+	if q.Filter.OwnerID != nil {
+		conds = append(conds, "owner_id = "+arg(*q.Filter.OwnerID))
+	}
+
+	switch q.Filter.State {
+	case URLStateActive:
+		conds = append(conds, "status = 'active' AND (valid_until IS NULL OR valid_until > NOW()) AND "+
+			"(valid_from IS NULL OR valid_from <= NOW())")
+	case URLStateExpired:
+		conds = append(conds, "valid_until <= NOW()")
+	case URLStateDisabled:
+		conds = append(conds, "status = 'disabled'")
+	case URLStatePending:
+		conds = append(conds, "valid_from > NOW()")
+	}
+
+	if q.Filter.CreatedFrom != nil {
+		conds = append(conds, "created_at >= "+arg(*q.Filter.CreatedFrom))
+	}
+
+	if q.Filter.CreatedTo != nil {
+		conds = append(conds, "created_at < "+arg(*q.Filter.CreatedTo))
+	}
+
+	if q.Filter.Host != "" {
+		// Original urls are normalized, so host is lowercase and comes right after scheme.
+		conds = append(conds, fmt.Sprintf(
+			"substring(original_url from '^[a-z]+://([^/?#]+)') LIKE '%%' || %s || '%%'",
+			arg(escapeLike(q.Filter.Host)),
+		))
+	}
+
+	if q.Filter.Tag != "" {
+		conds = append(conds, arg(q.Filter.Tag)+" = ANY(tags)")
+	}
+
+	sortColumn := "created_at"
+	if q.SortBy == ListURLsSortByClicks {
+		sortColumn = "clicks"
+	}
+
+	order, cmp := "ASC", ">"
+	if q.Desc {
+		order, cmp = "DESC", "<"
+	}
+
+	if q.Cursor != "" {
+		c, err := decodeListCursor(q.Cursor)
+		if err != nil {
+			return "", nil, err
+		}
+
+		var sortValue any = c.CreatedAt
+		if q.SortBy == ListURLsSortByClicks {
+			sortValue = c.Clicks
+		}
+		conds = append(conds, fmt.Sprintf("(%s, id) %s (%s, %s)", sortColumn, cmp, arg(sortValue), arg(c.ID)))
+	}
+
+	query := fmt.Sprintf(`
+	SELECT id, original_url, short_url, clicks, bot_clicks, created_at, valid_until, status, tags, redirect_code,
+		preview_title, preview_description, preview_image_url, password_hash, max_clicks,
+		valid_from, fallback_url, %s
+	FROM urls
+	WHERE %s
+	ORDER BY %s %s, id %s
+	LIMIT %s`,
+		targetingRulesColumn,
+		strings.Join(conds, " AND "),
+		sortColumn, order, order,
+		arg(q.Limit+1),
+	)
+
+	return query, args, nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// listCursor points to the last url of a page listed with sort it keeps.
+type listCursor struct {
+	SortBy    ListURLsSortBy `json:"s"`
+	Desc      bool           `json:"d"`
+	CreatedAt time.Time      `json:"c"`
+	Clicks    int            `json:"k"`
+	ID        uuid.UUID      `json:"i"`
+}
+
+func encodeListCursor(c listCursor) string {
+	b, _ := json.Marshal(c) //nolint:errchkjson // Marshaling plain struct never fails.
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeListCursor(s string) (listCursor, error) {
+	var c listCursor
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return listCursor{}, errs.NewValueIsInvalidErrorWithCause("cursor", errors.New("malformed cursor"))
+	}
+
+	if err = json.Unmarshal(b, &c); err != nil {
+		return listCursor{}, errs.NewValueIsInvalidErrorWithCause("cursor", errors.New("malformed cursor"))
+	}
+
+	return c, nil
+}
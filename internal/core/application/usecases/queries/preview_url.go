@@ -0,0 +1,117 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/core/ports"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/logger"
+	"github.com/dzhordano/urlshortener/internal/pkg/tracing"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PreviewURLQuery asks where short url leads without following it.
+type PreviewURLQuery struct {
+	ShortURL string
+}
+
+func NewPreviewURLQuery(shortURL string) (PreviewURLQuery, error) {
+	if shortURL == "" {
+		return PreviewURLQuery{}, errs.NewValueIsInvalidError("shortURL")
+	}
+
+	return PreviewURLQuery{
+		ShortURL: shortURL,
+	}, nil
+}
+
+// PreviewURLResponse tells where url leads. Destination of password protected url is not revealed,
+// OriginalURL is empty and Safety is unknown for such urls. Destination of url with click limit isn't revealed
+// either, preview isn't counted as click, so it would let url be followed past its limit.
+type PreviewURLResponse struct {
+	ShortURL     string
+	OriginalURL  string
+	CreatedAtUTC time.Time
+	// ValidUntilUTC is nil for never expiring urls.
+	ValidUntilUTC     *time.Time
+	Safety            model.DestinationSafety
+	PasswordProtected bool
+	ClickLimited      bool
+}
+
+type PreviewURLQueryHandler interface {
+	Handle(context.Context, PreviewURLQuery) (PreviewURLResponse, error)
+}
+
+type previewURLQueryHandler struct {
+	urls *redirectableURLs
+}
+
+// NewPreviewURLQueryHandler returns handler looking urls up the same way redirect does, without counting clicks.
+func NewPreviewURLQueryHandler(
+	log logger.Logger,
+	cache ports.URLCache,
+	db *pgxpool.Pool,
+) (PreviewURLQueryHandler, error) {
+	if log == nil {
+		return nil, errs.NewValueIsRequiredError("log")
+	}
+
+	if cache == nil {
+		return nil, errs.NewValueIsRequiredError("cache")
+	}
+
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+
+	return &previewURLQueryHandler{
+		urls: &redirectableURLs{log: log, cache: cache, db: db},
+	}, nil
+}
+
+func (h *previewURLQueryHandler) Handle(
+	ctx context.Context,
+	q PreviewURLQuery,
+) (PreviewURLResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "PreviewURLQueryHandler.Handle")
+	defer span.End()
+
+	url, err := h.urls.find(ctx, q.ShortURL)
+	if err != nil {
+		return PreviewURLResponse{}, err
+	}
+
+	switch url.UnavailableReason(time.Now()) {
+	case model.UnavailableReasonDisabled, model.UnavailableReasonExpired:
+		return PreviewURLResponse{}, errs.NewObjectNotFoundError("short url", q.ShortURL)
+	case model.UnavailableReasonPending:
+		return PreviewURLResponse{}, ErrURLPending
+	case model.UnavailableReasonClickLimitReached:
+		return PreviewURLResponse{}, ErrClickLimitReached
+	}
+
+	if url.IsPasswordProtected() || url.MaxClicks != nil {
+		return PreviewURLResponse{
+			ShortURL:          url.ShortURL,
+			OriginalURL:       "",
+			CreatedAtUTC:      url.CreatedAtUTC,
+			ValidUntilUTC:     url.ValidUntilUTC,
+			Safety:            model.DestinationSafety{Verdict: model.SafetyVerdictUnknown, Warnings: nil},
+			PasswordProtected: url.IsPasswordProtected(),
+			ClickLimited:      url.MaxClicks != nil,
+		}, nil
+	}
+
+	return PreviewURLResponse{
+		ShortURL:          url.ShortURL,
+		OriginalURL:       url.OriginalURL,
+		CreatedAtUTC:      url.CreatedAtUTC,
+		ValidUntilUTC:     url.ValidUntilUTC,
+		Safety:            model.AssessDestination(url.OriginalURL),
+		PasswordProtected: false,
+		ClickLimited:      false,
+	}, nil
+}
@@ -3,31 +3,97 @@ package queries
 import (
 	"context"
 	"errors"
+	neturl "net/url"
+	"time"
 
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
 	"github.com/dzhordano/urlshortener/internal/core/ports"
 	"github.com/dzhordano/urlshortener/internal/pkg/errs"
 	"github.com/dzhordano/urlshortener/internal/pkg/logger"
 	"github.com/dzhordano/urlshortener/internal/pkg/tracing"
-	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// Visitor describes the one following short url as it's seen in request.
+type Visitor struct {
+	// Method is http method of request.
+	Method         string
+	Referrer       string
+	UserAgent      string
+	IP             string
+	Accept         string
+	AcceptLanguage string
+	// PlatformHint and MobileHint are Sec-CH-UA-Platform and Sec-CH-UA-Mobile client hints. Empty if not sent.
+	PlatformHint string
+	MobileHint   string
+}
+
+// IsBot reports whether visitor is bot and why, see model.DetectBot.
+func (v Visitor) IsBot() (bool, model.BotReason) {
+	return model.DetectBot(v.Method, v.UserAgent, v.Accept)
+}
+
+// UserAgentInfo returns what visitor's user agent and client hints tell about its software.
+func (v Visitor) UserAgentInfo() model.UserAgentInfo {
+	return model.ParseUserAgent(v.UserAgent).WithClientHints(v.PlatformHint, v.MobileHint)
+}
+
 type RedirectQuery struct {
 	ShortURL string
+	Visitor  Visitor
+	// Password is the one visitor follows password protected url with. Empty if not given.
+	Password string
 }
 
-func NewRedirectQuery(shortURL string) (RedirectQuery, error) {
+func NewRedirectQuery(shortURL string, visitor Visitor, password string) (RedirectQuery, error) {
 	if shortURL == "" {
 		return RedirectQuery{}, errs.NewValueIsInvalidError("shortURL")
 	}
 
 	return RedirectQuery{
 		ShortURL: shortURL,
+		Visitor:  visitor,
+		Password: password,
 	}, nil
 }
 
+var (
+	// ErrPasswordRequired is returned when password protected url is followed without password.
+	ErrPasswordRequired = errors.New("password required")
+	// ErrPasswordInvalid is returned when password protected url is followed with wrong password.
+	ErrPasswordInvalid = errors.New("invalid password")
+	// ErrTooManyPasswordAttempts is returned when too many wrong passwords were given for url lately.
+	ErrTooManyPasswordAttempts = errors.New("too many password attempts")
+	// ErrURLPending is returned when url without fallback url is followed before it becomes active.
+	ErrURLPending = errors.New("url is not active yet")
+	// ErrClickLimitReached is returned when url without fallback url was already followed the amount of times its
+	// click limit allows.
+	ErrClickLimitReached = errors.New("click limit reached")
+)
+
 type RedirectResponse struct {
-	OriginalURL string
+	// OriginalURL is url visitor is redirected to. Empty for bots following url with click limit, they aren't
+	// counted against the limit, so they don't get destination either.
+	OriginalURL  string
+	RedirectCode model.RedirectCode
+	// Preview is set for bots if url has preview. Such bots are to get preview page instead of redirect.
+	Preview *model.LinkPreview
+	// Previewed is set if url has preview. Bots get preview page and others get redirect, so response must be
+	// cached per user agent and accepted content.
+	Previewed bool
+	// PasswordProtected is set if url was followed with its password. Such redirect must not be cached.
+	PasswordProtected bool
+	// Targeted is set if OriginalURL depends on visitor's user agent. Such redirect must be cached per user agent.
+	Targeted bool
+	// GeoTargeted is set if OriginalURL depends on visitor's country. Such redirect must not be cached by shared
+	// caches, they can't tell visitors' countries apart.
+	GeoTargeted bool
+	// ValidUntilUTC is set if url expires. Cached redirect must not outlive url.
+	ValidUntilUTC *time.Time
+	// ClickLimited is set if url has click limit. Such redirect must not be cached, or clicks would be missed.
+	ClickLimited bool
 }
 
 type RedirectQueryHandler interface {
@@ -35,15 +101,32 @@ type RedirectQueryHandler interface {
 }
 
 type redirectQueryHandler struct {
-	log   logger.Logger
-	cache ports.URLCache
-	db    *pgxpool.Pool
+	log      logger.Logger
+	urls     *redirectableURLs
+	counter  ports.ClickCounter
+	visitors ports.VisitorCounter
+	clicks   ports.ClickRecorder
+	attempts ports.PasswordAttemptLimiter
+	metrics  ports.RedirectMetrics
+	geo      ports.GeoLocator
+	// defaultFallbackURL is redirected to when unavailable url has no fallback url. Pending urls are
+	// redirected to their own fallback url only. Empty if there is no default.
+	defaultFallbackURL string
 }
 
+// NewRedirectQueryHandler returns handler of redirects. Urls that can't be followed and have no fallback url
+// are redirected to defaultFallbackURL, unless it's empty. Visitors' countries are located with geo.
 func NewRedirectQueryHandler(
 	log logger.Logger,
 	cache ports.URLCache,
+	counter ports.ClickCounter,
+	visitors ports.VisitorCounter,
+	clicks ports.ClickRecorder,
+	attempts ports.PasswordAttemptLimiter,
+	metrics ports.RedirectMetrics,
+	geo ports.GeoLocator,
 	db *pgxpool.Pool,
+	defaultFallbackURL string,
 ) (RedirectQueryHandler, error) {
 	if log == nil {
 		return nil, errs.NewValueIsRequiredError("log")
@@ -53,14 +136,51 @@ func NewRedirectQueryHandler(
 		return nil, errs.NewValueIsRequiredError("cache")
 	}
 
+	if counter == nil {
+		return nil, errs.NewValueIsRequiredError("counter")
+	}
+
+	if visitors == nil {
+		return nil, errs.NewValueIsRequiredError("visitors")
+	}
+
+	if clicks == nil {
+		return nil, errs.NewValueIsRequiredError("clicks")
+	}
+
+	if attempts == nil {
+		return nil, errs.NewValueIsRequiredError("attempts")
+	}
+
+	if metrics == nil {
+		return nil, errs.NewValueIsRequiredError("metrics")
+	}
+
+	if geo == nil {
+		return nil, errs.NewValueIsRequiredError("geo")
+	}
+
 	if db == nil {
 		return nil, errs.NewValueIsRequiredError("db")
 	}
 
+	if defaultFallbackURL != "" {
+		if u, err := neturl.Parse(defaultFallbackURL); err != nil ||
+			(u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, errs.NewValueIsInvalidError("defaultFallbackURL")
+		}
+	}
+
 	return &redirectQueryHandler{
-		log:   log,
-		cache: cache,
-		db:    db,
+		log:                log,
+		urls:               &redirectableURLs{log: log, cache: cache, db: db},
+		counter:            counter,
+		visitors:           visitors,
+		clicks:             clicks,
+		attempts:           attempts,
+		metrics:            metrics,
+		geo:                geo,
+		defaultFallbackURL: defaultFallbackURL,
 	}, nil
 }
 
@@ -68,89 +188,256 @@ func (h *redirectQueryHandler) Handle(
 	ctx context.Context,
 	q RedirectQuery,
 ) (RedirectResponse, error) {
-	ctx, span := tracing.StartSpan(ctx, "GetURLInfoQueryHandler.Handle")
+	ctx, span := tracing.StartSpan(ctx, "RedirectQueryHandler.Handle")
 	defer span.End()
 
-	// Get shortened url from cache and if found - increment clicks in db.
-	// Otherwise, just log cache miss.
-	cachedVal, err := h.cache.Get(ctx, q.ShortURL)
-	span.AddEvent("retrieval from cache attempt performed")
-
-	// Pretty fried nesting.
-	// Basically:
-	// Not found? -> log cache miss
-	// Any other error? -> log error
-	// No error and value is "" (caching absence of value)? -> return ""
-	// No error and valud is NOT ""? -> increment click and return it.
-	switch {
-	case err != nil && errors.Is(err, errs.ErrObjectNotFound):
-		h.log.Warn("value not found in cache", "short_url", q.ShortURL)
-	case err != nil:
-		span.RecordError(err)
-		h.log.Error("error getting url from cache", "error", err)
-	default:
-		if cachedVal == "" {
-			return RedirectResponse{}, errs.NewObjectNotFoundError("short url", q.ShortURL)
-		}
+	url, err := h.urls.find(ctx, q.ShortURL)
+	if err != nil {
+		return RedirectResponse{}, err
+	}
+
+	// Unavailable url is neither counted nor revealed, not even to the ones knowing its password.
+	if reason := url.UnavailableReason(time.Now()); reason != "" {
+		return h.fallback(ctx, url, reason)
+	}
+
+	// Checked for bots too, preview page must not leak destination either.
+	if err := h.checkPassword(ctx, q, url); err != nil {
+		return RedirectResponse{}, err
+	}
+
+	resp, err := h.respond(ctx, q, url)
+	// Clicks of cached url are unknown, so its limit may turn out to be reached only now.
+	if errors.Is(err, ErrClickLimitReached) {
+		return h.fallback(ctx, url, model.UnavailableReasonClickLimitReached)
+	}
+
+	return resp, err
+}
 
-		// Increment url clicks here.
-		query := `
-		UPDATE urls
-		SET clicks = clicks + 1
-		WHERE short_url = $1`
-		_, err = h.db.Exec(ctx, query, q.ShortURL)
-		if err != nil {
-			// record since it's unexpected to happen
-			span.RecordError(err)
-			h.log.Warn("failed to increment clicks", "short_url", q.ShortURL)
+// fallback returns temporary redirect to fallback url of url which can't be followed for reason.
+// Error matching reason is returned if there is no fallback url.
+func (h *redirectQueryHandler) fallback(
+	ctx context.Context,
+	url *model.ShortenedURL,
+	reason model.UnavailableReason,
+) (RedirectResponse, error) {
+	span := tracing.SpanFromContext(ctx)
+	span.AddEvent("url is unavailable", trace.WithAttributes(attribute.String("url.unavailable_reason", string(reason))))
+
+	target := url.FallbackURL
+	if target == "" && reason != model.UnavailableReasonPending {
+		target = h.defaultFallbackURL
+	}
+
+	if target == "" {
+		switch reason {
+		case model.UnavailableReasonPending:
+			return RedirectResponse{}, ErrURLPending
+		case model.UnavailableReasonClickLimitReached:
+			return RedirectResponse{}, ErrClickLimitReached
+		default:
+			return RedirectResponse{}, errs.NewObjectNotFoundError("short url", url.ShortURL)
 		}
+	}
 
-		h.log.Debug("value found in cache", "short_url", q.ShortURL)
-		return RedirectResponse{OriginalURL: cachedVal}, nil
+	h.log.Debug("fallback redirect", "short_url", url.ShortURL, "reason", reason)
+	h.metrics.FallbackRedirect(reason)
+
+	return RedirectResponse{
+		OriginalURL:       target,
+		RedirectCode:      model.RedirectCodeFound,
+		Preview:           nil,
+		Previewed:         false,
+		PasswordProtected: false,
+		Targeted:          false,
+		GeoTargeted:       false,
+		ValidUntilUTC:     nil,
+		ClickLimited:      false,
+	}, nil
+}
+
+// checkPassword makes sure password protected url is followed with its password.
+// Wrong passwords are counted, and no more attempts are allowed once there were too many of them.
+func (h *redirectQueryHandler) checkPassword(ctx context.Context, q RedirectQuery, url *model.ShortenedURL) error {
+	if !url.IsPasswordProtected() {
+		return nil
 	}
 
-	// Update value if url's still valid and return it's original url.
-	query := `
-	UPDATE urls 
-	SET clicks = clicks + 1
-	WHERE short_url = $1 AND valid_until > NOW()
-	RETURNING original_url`
+	span := tracing.SpanFromContext(ctx)
 
-	var originalURL string
-	err = h.db.QueryRow(ctx, query, q.ShortURL).Scan(&originalURL)
-	span.AddEvent("retrieval from db attempt performed")
+	if q.Password == "" {
+		span.AddEvent("password required")
+		return ErrPasswordRequired
+	}
+
+	allowed, err := h.attempts.Allow(ctx, q.ShortURL)
 	if err != nil {
 		span.RecordError(err)
-		h.log.Error("error getting original url", "error", err)
-		if errors.Is(err, pgx.ErrNoRows) {
-			// Still cache nil result
-			err = h.cache.Set(ctx, q.ShortURL, "")
-			span.AddEvent("attempted to save empty value in cache")
-			if err != nil {
-				span.RecordError(err)
-				h.log.Error("error saving url to cache", "error", err)
+		h.log.Error("error checking password attempts", "short_url", q.ShortURL, "error", err)
+		return err
+	}
+
+	if !allowed {
+		span.AddEvent("too many password attempts")
+		h.log.Warn("password attempt rejected, too many attempts", "short_url", q.ShortURL)
+		return ErrTooManyPasswordAttempts
+	}
+
+	if url.CheckPassword(q.Password) {
+		return nil
+	}
+
+	span.AddEvent("invalid password")
+	h.log.Debug("invalid password", "short_url", q.ShortURL)
+
+	if err := h.attempts.Fail(ctx, q.ShortURL); err != nil {
+		span.RecordError(err)
+		h.log.Error("error recording failed password attempt", "short_url", q.ShortURL, "error", err)
+	}
+
+	return ErrPasswordInvalid
+}
+
+// respond records click of redirect to url and returns response for visitor. Visitor is redirected to url's
+// destination matching its country and user agent, see model.ShortenedURL.Destination. Bots get url's preview,
+// if it has one. Bots' redirects aren't counted against click limit, so link unfurlers and mail scanners don't
+// use links up. Bots don't get destination of url with click limit then, or it could be followed past the limit
+// by anyone posing as bot.
+func (h *redirectQueryHandler) respond(
+	ctx context.Context,
+	q RedirectQuery,
+	url *model.ShortenedURL,
+) (RedirectResponse, error) {
+	isBot, reason := q.Visitor.IsBot()
+	clickLimited := url.MaxClicks != nil
+
+	if isBot {
+		tracing.SpanFromContext(ctx).AddEvent("bot redirect", trace.WithAttributes(
+			attribute.String("bot.reason", string(reason)),
+		))
+		h.log.Debug("bot redirect", "short_url", q.ShortURL, "reason", reason)
+		h.counter.IncrementBot(q.ShortURL)
+
+		// Bots' country is needed for destination only, they're not recorded.
+		var destination string
+		if !clickLimited {
+			var country string
+			if url.IsGeoTargeted() {
+				country = h.locate(ctx, q.Visitor.IP)
 			}
+			destination = url.Destination(country, q.Visitor.UserAgentInfo())
+		}
+
+		return RedirectResponse{
+			OriginalURL:       destination,
+			RedirectCode:      url.RedirectCode,
+			Preview:           url.Preview,
+			Previewed:         url.Preview != nil,
+			PasswordProtected: url.IsPasswordProtected(),
+			Targeted:          destination != "" && len(url.TargetingRules) > 0,
+			GeoTargeted:       destination != "" && url.IsGeoTargeted(),
+			ValidUntilUTC:     url.ValidUntilUTC,
+			ClickLimited:      clickLimited,
+		}, nil
+	}
 
-			return RedirectResponse{}, errs.NewObjectNotFoundError("short url", q.ShortURL)
+	country := h.locate(ctx, q.Visitor.IP)
+	destination := url.Destination(country, q.Visitor.UserAgentInfo())
+
+	if clickLimited {
+		if err := h.takeCappedClick(ctx, q.ShortURL); err != nil {
+			return RedirectResponse{}, err
 		}
+	} else {
+		h.counter.Increment(q.ShortURL)
+	}
 
-		return RedirectResponse{}, err
+	h.recordClick(ctx, q, url, country)
+
+	return RedirectResponse{
+		OriginalURL:       destination,
+		RedirectCode:      url.RedirectCode,
+		Preview:           nil,
+		Previewed:         url.Preview != nil,
+		PasswordProtected: url.IsPasswordProtected(),
+		Targeted:          len(url.TargetingRules) > 0,
+		GeoTargeted:       url.IsGeoTargeted(),
+		ValidUntilUTC:     url.ValidUntilUTC,
+		ClickLimited:      clickLimited,
+	}, nil
+}
+
+// locate returns country of visitor with ip. Empty if it's unknown, failing to locate visitor doesn't fail
+// redirect.
+func (h *redirectQueryHandler) locate(ctx context.Context, ip string) string {
+	country, err := h.geo.Country(ctx, ip)
+	if err != nil {
+		tracing.SpanFromContext(ctx).RecordError(err)
+		h.log.Warn("failed to locate visitor", "error", err)
+		return ""
 	}
 
-	span.AddEvent("url found in db or cache")
-	h.log.Debug("got original url", "original_url", originalURL)
+	return country
+}
+
+// takeCappedClick counts click of url with click limit in db right away, so the limit holds for concurrent
+// redirects and redirects of cached url, whose clicks are unknown. Url is evicted from cache once the limit
+// is reached, so later redirects find it used up in db.
+func (h *redirectQueryHandler) takeCappedClick(ctx context.Context, shortURL string) error {
+	span := tracing.SpanFromContext(ctx)
 
-	// Cache value for faster next retrieval.
-	err = h.cache.Set(ctx, q.ShortURL, originalURL)
-	span.AddEvent("attempted to save new value in cache")
+	counted, left, err := h.counter.IncrementCapped(ctx, shortURL)
 	if err != nil {
 		span.RecordError(err)
-		h.log.Error("error saving url to cache", "error", err)
+		h.log.Error("error counting capped click", "short_url", shortURL, "error", err)
+		return err
 	}
 
-	span.AddEvent("value retrieved and saved to cache successfully")
+	if counted && left > 0 {
+		return nil
+	}
 
-	return RedirectResponse{
-		OriginalURL: originalURL,
-	}, nil
+	span.AddEvent("click limit reached")
+	if err := h.urls.cache.Delete(ctx, shortURL); err != nil {
+		span.RecordError(err)
+		h.log.Error("error evicting used up url from cache", "short_url", shortURL, "error", err)
+	}
+
+	if !counted {
+		return ErrClickLimitReached
+	}
+
+	return nil
+}
+
+// recordClick counts visitor of human's redirect and queues its click event along with visitor's country.
+// Bots' redirects are only counted as bot clicks, so they don't skew analytics.
+// Failing to record click doesn't fail redirect.
+func (h *redirectQueryHandler) recordClick(
+	ctx context.Context,
+	q RedirectQuery,
+	url *model.ShortenedURL,
+	country string,
+) {
+	if err := h.visitors.Add(ctx, q.ShortURL, q.Visitor.IP, q.Visitor.UserAgent); err != nil {
+		tracing.SpanFromContext(ctx).RecordError(err)
+		h.log.Warn("failed to count visitor", "short_url", q.ShortURL, "error", err)
+	}
+
+	event := model.NewClickEvent(
+		url.ID,
+		q.ShortURL,
+		time.Now(),
+		q.Visitor.Referrer,
+		q.Visitor.UserAgent,
+		q.Visitor.IP,
+		q.Visitor.AcceptLanguage,
+	)
+	event.Country = country
+
+	if err := h.clicks.Record(ctx, event); err != nil {
+		tracing.SpanFromContext(ctx).RecordError(err)
+		h.log.Warn("click event dropped", "short_url", q.ShortURL, "error", err)
+	}
 }
@@ -0,0 +1,125 @@
+package queries
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/core/ports"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/logger"
+	"github.com/dzhordano/urlshortener/internal/pkg/tracing"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// redirectableURLs finds urls followed by redirects. Urls are read from cache, falling back to db
+// and caching its result. Nothing else is written, so clicks aren't counted.
+type redirectableURLs struct {
+	log   logger.Logger
+	cache ports.URLCache
+	db    *pgxpool.Pool
+}
+
+// find returns url with shortURL, errs.ErrObjectNotFound is returned for missing or deleted one.
+// Url is returned even if it can't be followed now (see model.ShortenedURL.UnavailableReason), so it can be told
+// from missing one. Clicks of cached url are unknown, they're zero.
+func (r *redirectableURLs) find(ctx context.Context, shortURL string) (*model.ShortenedURL, error) {
+	span := tracing.SpanFromContext(ctx)
+
+	cachedURL, err := r.cache.Get(ctx, shortURL)
+	span.AddEvent("retrieval from cache attempt performed")
+
+	// Pretty fried nesting.
+	// Basically:
+	// Not found? -> log cache miss
+	// Any other error? -> log error
+	// No error and value is nil (caching absence of value)? -> return not found
+	// No error and value is valid? -> return it.
+	switch {
+	case err != nil && errors.Is(err, errs.ErrObjectNotFound):
+		r.log.Warn("value not found in cache", "short_url", shortURL)
+	case err != nil:
+		span.RecordError(err)
+		r.log.Error("error getting url from cache", "error", err)
+	default:
+		if cachedURL == nil {
+			return nil, errs.NewObjectNotFoundError("short url", shortURL)
+		}
+
+		r.log.Debug("value found in cache", "short_url", shortURL)
+
+		return cachedURL, nil
+	}
+
+	// Get value if url isn't deleted. Whether it can be followed is up to caller.
+	query := `
+	SELECT id, original_url, short_url, clicks, created_at, valid_until, status, redirect_code,
+		preview_title, preview_description, preview_image_url, password_hash, max_clicks,
+		valid_from, fallback_url, ` + targetingRulesColumn + `
+	FROM urls
+	WHERE short_url = $1 AND deleted_at IS NULL`
+
+	var (
+		url     model.ShortenedURL
+		preview model.LinkPreview
+		rules   []targetingRuleRow
+	)
+	err = r.db.QueryRow(ctx, query, shortURL).Scan(
+		&url.ID,
+		&url.OriginalURL,
+		&url.ShortURL,
+		&url.Clicks,
+		&url.CreatedAtUTC,
+		&url.ValidUntilUTC,
+		&url.Status,
+		&url.RedirectCode,
+		&preview.Title,
+		&preview.Description,
+		&preview.ImageURL,
+		&url.PasswordHash,
+		&url.MaxClicks,
+		&url.ValidFromUTC,
+		&url.FallbackURL,
+		&rules,
+	)
+	span.AddEvent("retrieval from db attempt performed")
+	if err != nil {
+		span.RecordError(err)
+		r.log.Error("error getting original url", "error", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Still cache nil result
+			err = r.cache.Set(ctx, shortURL, nil)
+			span.AddEvent("attempted to save empty value in cache")
+			if err != nil {
+				span.RecordError(err)
+				r.log.Error("error saving url to cache", "error", err)
+			}
+
+			return nil, errs.NewObjectNotFoundError("short url", shortURL)
+		}
+
+		return nil, err
+	}
+
+	span.AddEvent("url found in db")
+	r.log.Debug("got original url", "original_url", url.OriginalURL)
+
+	url.Preview = previewOrNil(preview)
+	url.TargetingRules = toTargetingRules(rules)
+
+	// Used up url isn't cached, cached one is evicted once its limit is reached.
+	if url.IsClickLimitReached() {
+		return &url, nil
+	}
+
+	// Cache value for faster next retrieval.
+	err = r.cache.Set(ctx, shortURL, &url)
+	span.AddEvent("attempted to save new value in cache")
+	if err != nil {
+		span.RecordError(err)
+		r.log.Error("error saving url to cache", "error", err)
+	}
+
+	return &url, nil
+}
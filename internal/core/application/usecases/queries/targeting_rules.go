@@ -0,0 +1,40 @@
+package queries
+
+import (
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+)
+
+// targetingRulesColumn selects targeting rules of url from urls table as json array, in order they're checked.
+const targetingRulesColumn = `COALESCE((
+		SELECT json_agg(json_build_object('country', r.country, 'os', r.os, 'device', r.device, 'target_url', r.target_url)
+			ORDER BY r.position)
+		FROM url_targeting_rules r
+		WHERE r.url_id = urls.id
+	), '[]')`
+
+// targetingRuleRow is targeting rule as selected by targetingRulesColumn.
+type targetingRuleRow struct {
+	Country   string `json:"country"`
+	OS        string `json:"os"`
+	Device    string `json:"device"`
+	TargetURL string `json:"target_url"`
+}
+
+// toTargetingRules maps selected rules to model ones. Nil is returned for no rules.
+func toTargetingRules(rows []targetingRuleRow) []model.TargetingRule {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	rules := make([]model.TargetingRule, 0, len(rows))
+	for _, r := range rows {
+		rules = append(rules, model.TargetingRule{
+			Country:   r.Country,
+			OS:        r.OS,
+			Device:    r.Device,
+			TargetURL: r.TargetURL,
+		})
+	}
+
+	return rules
+}
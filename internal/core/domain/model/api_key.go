@@ -0,0 +1,213 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+const (
+	// APIKeyMarker starts every api key, so leaked keys are easy to recognize.
+	APIKeyMarker = "usk_"
+	// APIKeyPrefixLength is length of key's beginning stored in plain text to look key up by.
+	APIKeyPrefixLength = len(APIKeyMarker) + 8
+
+	APIKeyNameMaxLength = 64
+
+	apiKeySecretBytes = 24
+)
+
+// Scopes grant access to api operations. Operations declare scopes they require in openapi spec.
+const (
+	// ScopeAdmin grants operator access: managing any url, not only own ones, and never expiring urls.
+	ScopeAdmin          = "admin"
+	ScopeLinksCreate    = "links:create"
+	ScopeLinksReadStats = "links:read_stats"
+	ScopeLinksUpdate    = "links:update"
+	ScopeLinksDelete    = "links:delete"
+	ScopeAdminKeys      = "admin:keys"
+)
+
+// Role is named set of scopes granted to api key.
+type Role string
+
+const (
+	// RoleViewer may only look at own urls.
+	RoleViewer Role = "viewer"
+	// RoleEditor may create and manage own urls.
+	RoleEditor Role = "editor"
+	// RoleAdmin may do anything.
+	RoleAdmin Role = "admin"
+)
+
+// ParseRole returns Role matching s.
+func ParseRole(s string) (Role, error) {
+	switch role := Role(s); role {
+	case RoleViewer, RoleEditor, RoleAdmin:
+		return role, nil
+	default:
+		return "", errs.NewValueIsInvalidErrorWithCause(
+			"role",
+			fmt.Errorf("must be one of %q, %q, %q", RoleViewer, RoleEditor, RoleAdmin),
+		)
+	}
+}
+
+// Scopes returns scopes granted by role.
+func (r Role) Scopes() []string {
+	switch r {
+	case RoleViewer:
+		return []string{ScopeLinksReadStats}
+	case RoleEditor:
+		return []string{ScopeLinksCreate, ScopeLinksReadStats, ScopeLinksUpdate, ScopeLinksDelete}
+	case RoleAdmin:
+		return []string{
+			ScopeAdmin, ScopeLinksCreate, ScopeLinksReadStats, ScopeLinksUpdate, ScopeLinksDelete, ScopeAdminKeys,
+		}
+	default:
+		return nil
+	}
+}
+
+// APIKey is api key record. The key itself is never stored, only its hash.
+type APIKey struct {
+	ID uuid.UUID
+	// Prefix is beginning of the key used to look it up.
+	Prefix string
+	// Hash is hex encoded sha256 of the key.
+	Hash string
+	Name string
+	Role Role
+	// Scopes are granted in addition to role's ones.
+	Scopes       []string
+	CreatedAtUTC time.Time
+	// LastUsedAtUTC is nil for never used keys.
+	LastUsedAtUTC *time.Time
+	// ExpiresAtUTC is nil for never expiring keys.
+	ExpiresAtUTC *time.Time
+	Revoked      bool
+}
+
+// NewAPIKey generates new api key. Returned key is in plain text and must be shown to its owner only once.
+func NewAPIKey(name string, role Role, scopes []string, expiresAt *time.Time) (*APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", errs.NewValueIsRequiredError("name")
+	}
+
+	if len(name) > APIKeyNameMaxLength {
+		return nil, "", errs.NewValueIsInvalidErrorWithCause(
+			"name",
+			fmt.Errorf("must be at most %d characters long", APIKeyNameMaxLength),
+		)
+	}
+
+	if _, err := ParseRole(string(role)); err != nil {
+		return nil, "", err
+	}
+
+	scopes, err := NormalizeScopes(scopes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	n := time.Now().UTC()
+
+	if expiresAt != nil {
+		if !expiresAt.After(n) {
+			return nil, "", errs.NewValueIsInvalidErrorWithCause("expiresAt", errors.New("must be in the future"))
+		}
+
+		utc := expiresAt.UTC()
+		expiresAt = &utc
+	}
+
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err = rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("error generating api key: %w", err)
+	}
+	key := APIKeyMarker + hex.EncodeToString(secret)
+
+	return &APIKey{
+		ID:            uuid.New(),
+		Prefix:        key[:APIKeyPrefixLength],
+		Hash:          HashAPIKey(key),
+		Name:          name,
+		Role:          role,
+		Scopes:        scopes,
+		CreatedAtUTC:  n,
+		LastUsedAtUTC: nil,
+		ExpiresAtUTC:  expiresAt,
+		Revoked:       false,
+	}, key, nil
+}
+
+// HashAPIKey returns hash of key as stored in APIKey.Hash.
+func HashAPIKey(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(h[:])
+}
+
+// APIKeyPrefix returns prefix to look key up by.
+func APIKeyPrefix(key string) (string, error) {
+	if !strings.HasPrefix(key, APIKeyMarker) || len(key) <= APIKeyPrefixLength {
+		return "", errs.NewValueIsInvalidError("apiKey")
+	}
+
+	return key[:APIKeyPrefixLength], nil
+}
+
+// Matches reports whether key is the one k was created for.
+func (k *APIKey) Matches(key string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashAPIKey(key)), []byte(k.Hash)) == 1
+}
+
+// IsUsable reports whether key can be used for authentication at the moment t.
+func (k *APIKey) IsUsable(t time.Time) bool {
+	return !k.Revoked && (k.ExpiresAtUTC == nil || k.ExpiresAtUTC.After(t))
+}
+
+// GrantedScopes returns all scopes granted to key: role's ones and additional ones.
+func (k *APIKey) GrantedScopes() []string {
+	granted := k.Role.Scopes()
+	for _, s := range k.Scopes {
+		if !slices.Contains(granted, s) {
+			granted = append(granted, s)
+		}
+	}
+
+	return granted
+}
+
+// NormalizeScopes deduplicates scopes and checks they are known. Nil is returned as empty slice.
+func NormalizeScopes(scopes []string) ([]string, error) {
+	res := make([]string, 0, len(scopes))
+	seen := make(map[string]struct{}, len(scopes))
+
+	for _, s := range scopes {
+		if !isKnownScope(s) {
+			return nil, errs.NewValueIsInvalidErrorWithCause("scopes", fmt.Errorf("unknown scope %q", s))
+		}
+
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+		res = append(res, s)
+	}
+
+	return res, nil
+}
+
+func isKnownScope(s string) bool {
+	return slices.Contains(RoleAdmin.Scopes(), s)
+}
@@ -0,0 +1,113 @@
+package model
+
+import (
+	_ "embed"
+	"net/http"
+	"strings"
+)
+
+// BotReason tells why request is considered bot's.
+type BotReason string
+
+const (
+	// BotReasonUserAgent is given for user agents matching bot signature or empty ones.
+	BotReasonUserAgent BotReason = "user_agent"
+	// BotReasonHeadRequest is given for HEAD requests, which link checkers and unfurlers make.
+	BotReasonHeadRequest BotReason = "head_request"
+	// BotReasonNoAccept is given for requests without Accept header, which browsers always send.
+	BotReasonNoAccept BotReason = "no_accept"
+)
+
+//go:embed bot_user_agents.txt
+var botUserAgentsFile string
+
+// botUserAgentMarker is lowercase user agent substring telling request is made by bot.
+type botUserAgentMarker struct {
+	text string
+	// wholeWord is set if marker matches only when it's not a part of longer word, e.g. "bot" of "cubot" phones.
+	wholeWord bool
+}
+
+// botUserAgentMarkers are markers telling request is made by bot.
+//
+//nolint:gochecknoglobals // Read only.
+var botUserAgentMarkers = parseBotUserAgents(botUserAgentsFile)
+
+// parseBotUserAgents returns markers listed in file skipping blank lines and # comments.
+// Markers in angle brackets match whole words only.
+func parseBotUserAgents(file string) []botUserAgentMarker {
+	var markers []botUserAgentMarker
+	for _, line := range strings.Split(file, "\n") {
+		line = strings.ToLower(strings.TrimSpace(line))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if word, ok := strings.CutPrefix(line, "<"); ok && strings.HasSuffix(word, ">") {
+			markers = append(markers, botUserAgentMarker{text: strings.TrimSuffix(word, ">"), wholeWord: true})
+			continue
+		}
+		markers = append(markers, botUserAgentMarker{text: line, wholeWord: false})
+	}
+
+	return markers
+}
+
+// DetectBot reports whether request made with method, user agent and Accept header is bot's and why.
+// User agent is checked first, so the most telling reason is returned.
+func DetectBot(method, userAgent, accept string) (bool, BotReason) {
+	switch {
+	case IsBotUserAgent(userAgent):
+		return true, BotReasonUserAgent
+	case method == http.MethodHead:
+		return true, BotReasonHeadRequest
+	case strings.TrimSpace(accept) == "":
+		return true, BotReasonNoAccept
+	default:
+		return false, ""
+	}
+}
+
+// IsBotUserAgent reports whether user agent belongs to a crawler or script.
+// Empty user agent is considered bot's, since browsers always send it.
+func IsBotUserAgent(userAgent string) bool {
+	ua := strings.ToLower(strings.TrimSpace(userAgent))
+	if ua == "" {
+		return true
+	}
+
+	for _, marker := range botUserAgentMarkers {
+		if marker.matches(ua) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matches reports whether lowercase user agent contains marker.
+func (m botUserAgentMarker) matches(ua string) bool {
+	if !m.wholeWord {
+		return strings.Contains(ua, m.text)
+	}
+
+	for i := strings.Index(ua, m.text); i >= 0; {
+		end := i + len(m.text)
+		if (i == 0 || !isWordByte(ua[i-1])) && (end == len(ua) || !isWordByte(ua[end])) {
+			return true
+		}
+
+		next := strings.Index(ua[i+1:], m.text)
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+
+	return false
+}
+
+// isWordByte reports whether b is lowercase ascii letter or digit.
+func isWordByte(b byte) bool {
+	return ('a' <= b && b <= 'z') || ('0' <= b && b <= '9')
+}
@@ -0,0 +1,63 @@
+//nolint:nolintlint,exhaustruct,testpackage
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsBotUserAgent(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		want      bool
+	}{
+		{
+			name:      "search engine",
+			userAgent: "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			want:      true,
+		},
+		{
+			name:      "generic bot word",
+			userAgent: "Mozilla/5.0 (compatible; Some-Bot/1.0)",
+			want:      true,
+		},
+		{
+			name:      "monitor word",
+			userAgent: "Site Monitor/2.0",
+			want:      true,
+		},
+		{
+			name:      "unfurler",
+			userAgent: "Slackbot-LinkExpanding 1.0 (+https://api.slack.com/robots)",
+			want:      true,
+		},
+		{
+			name:      "phone with bot inside model name",
+			userAgent: "Mozilla/5.0 (Linux; Android 10; CUBOT_X30) AppleWebKit/537.36 Chrome/120.0 Mobile Safari/537.36",
+			want:      false,
+		},
+		{
+			name:      "phone with bot ending model name",
+			userAgent: "Mozilla/5.0 (Linux; Android 11; CUBOT NOTE 20 Build/RP1A) Chrome/120.0 Mobile Safari/537.36",
+			want:      false,
+		},
+		{
+			name:      "browser",
+			userAgent: "Mozilla/5.0 (X11; Linux x86_64) Firefox/128.0",
+			want:      false,
+		},
+		{
+			name:      "empty",
+			userAgent: "",
+			want:      true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, IsBotUserAgent(tc.userAgent))
+		})
+	}
+}
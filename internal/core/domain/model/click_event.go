@@ -0,0 +1,112 @@
+package model
+
+import (
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ClickUserAgentMaxLength bounds stored user agent, longer ones are truncated.
+	ClickUserAgentMaxLength = 512
+	// ClickAcceptLanguageMaxLength bounds stored Accept-Language, longer ones are truncated.
+	ClickAcceptLanguageMaxLength = 128
+
+	// Visitor ips are stored truncated to these prefixes, so single visitor can't be identified.
+	clickIPv4PrefixBits = 24
+	clickIPv6PrefixBits = 48
+)
+
+// ClickEvent is single redirect made by visitor. Bots' redirects aren't recorded, see DetectBot.
+type ClickEvent struct {
+	// URLID is id of url redirected, stats are kept by it. ShortURL may be taken by another url later.
+	URLID         uuid.UUID
+	ShortURL      string
+	OccurredAtUTC time.Time
+	// ReferrerHost is host of referring page. Empty if referrer is not given or malformed.
+	ReferrerHost string
+	UserAgent    string
+	// IPPrefix is visitor ip truncated to /24 for ipv4 and to /48 for ipv6. Empty if ip is unknown.
+	IPPrefix       string
+	AcceptLanguage string
+	// Device, Browser and OS are recognized from user agent, see ParseUserAgent.
+	Device  string
+	Browser string
+	OS      string
+	// Country is ISO 3166-1 alpha-2 code of visitor's country. Empty if unknown.
+	Country string
+}
+
+// NewClickEvent returns click event of redirect to url with urlID and shortURL made at the moment t.
+// Visitor data is taken as is from request and anonymized.
+func NewClickEvent(
+	urlID uuid.UUID,
+	shortURL string,
+	t time.Time,
+	referrer, userAgent, ip, acceptLanguage string,
+) ClickEvent {
+	ua := ParseUserAgent(userAgent)
+
+	return ClickEvent{
+		URLID:          urlID,
+		ShortURL:       shortURL,
+		OccurredAtUTC:  t.UTC(),
+		ReferrerHost:   ReferrerHost(referrer),
+		UserAgent:      truncate(userAgent, ClickUserAgentMaxLength),
+		IPPrefix:       AnonymizeIP(ip),
+		AcceptLanguage: truncate(acceptLanguage, ClickAcceptLanguageMaxLength),
+		Device:         ua.Device,
+		Browser:        ua.Browser,
+		OS:             ua.OS,
+	}
+}
+
+// ReferrerHost returns lowercase host of referrer url. Empty string is returned for malformed ones.
+func ReferrerHost(referrer string) string {
+	u, err := url.Parse(strings.TrimSpace(referrer))
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(u.Hostname())
+}
+
+// AnonymizeIP truncates ip to /24 for ipv4 and to /48 for ipv6, e.g. 203.0.113.7 to 203.0.113.0/24.
+// Empty string is returned for malformed ips.
+func AnonymizeIP(ip string) string {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return ""
+	}
+
+	addr = addr.Unmap()
+	bits := clickIPv6PrefixBits
+	if addr.Is4() {
+		bits = clickIPv4PrefixBits
+	}
+
+	prefix, err := addr.WithZone("").Prefix(bits)
+	if err != nil {
+		return ""
+	}
+
+	return prefix.String()
+}
+
+// truncate cuts s to at most n bytes not splitting utf-8 characters.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	s = s[:n]
+	for !utf8.ValidString(s) {
+		s = s[:len(s)-1]
+	}
+
+	return s
+}
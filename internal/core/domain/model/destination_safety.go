@@ -0,0 +1,82 @@
+package model
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+// SafetyVerdict is outcome of destination safety assessment.
+type SafetyVerdict string
+
+const (
+	// SafetyVerdictOK means nothing suspicious was found. It doesn't guarantee destination is harmless.
+	SafetyVerdictOK SafetyVerdict = "ok"
+	// SafetyVerdictCaution means destination has traits often seen in phishing links.
+	SafetyVerdictCaution SafetyVerdict = "caution"
+	// SafetyVerdictUnknown means destination couldn't be assessed.
+	SafetyVerdictUnknown SafetyVerdict = "unknown"
+)
+
+// SafetyWarning is suspicious trait of destination.
+type SafetyWarning string
+
+const (
+	// SafetyWarningInsecureScheme is set for plain http destinations.
+	SafetyWarningInsecureScheme SafetyWarning = "insecure_scheme"
+	// SafetyWarningIPHost is set for destinations addressed by ip instead of domain name.
+	SafetyWarningIPHost SafetyWarning = "ip_host"
+	// SafetyWarningPunycodeHost is set for internationalized domain names, which may imitate well-known ones.
+	SafetyWarningPunycodeHost SafetyWarning = "punycode_host"
+	// SafetyWarningCredentials is set for destinations with user info, which may hide real host (user@host).
+	SafetyWarningCredentials SafetyWarning = "credentials"
+	// SafetyWarningNonStandardPort is set for destinations with explicit port.
+	SafetyWarningNonStandardPort SafetyWarning = "non_standard_port"
+)
+
+// DestinationSafety is heuristic assessment of url destination made from url alone, without visiting it.
+type DestinationSafety struct {
+	Verdict  SafetyVerdict
+	Warnings []SafetyWarning
+}
+
+// AssessDestination checks destination url for traits often seen in phishing links.
+// Verdict is SafetyVerdictCaution if any of them is found.
+func AssessDestination(destination string) DestinationSafety {
+	u, err := url.Parse(destination)
+	if err != nil || u.Host == "" {
+		return DestinationSafety{Verdict: SafetyVerdictUnknown, Warnings: nil}
+	}
+
+	var warnings []SafetyWarning
+
+	if !strings.EqualFold(u.Scheme, "https") {
+		warnings = append(warnings, SafetyWarningInsecureScheme)
+	}
+
+	host := u.Hostname()
+	if net.ParseIP(host) != nil {
+		warnings = append(warnings, SafetyWarningIPHost)
+	}
+
+	for label := range strings.SplitSeq(host, ".") {
+		if strings.HasPrefix(strings.ToLower(label), "xn--") {
+			warnings = append(warnings, SafetyWarningPunycodeHost)
+			break
+		}
+	}
+
+	if u.User != nil {
+		warnings = append(warnings, SafetyWarningCredentials)
+	}
+
+	if u.Port() != "" {
+		warnings = append(warnings, SafetyWarningNonStandardPort)
+	}
+
+	if len(warnings) > 0 {
+		return DestinationSafety{Verdict: SafetyVerdictCaution, Warnings: warnings}
+	}
+
+	return DestinationSafety{Verdict: SafetyVerdictOK, Warnings: nil}
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	LinkPasswordMinLength = 6
+	// LinkPasswordMaxLength is in bytes, bcrypt ignores anything past it.
+	LinkPasswordMaxLength = 72
+)
+
+// HashLinkPassword validates password protecting url and returns its bcrypt hash.
+//
+// Password must be LinkPasswordMinLength-LinkPasswordMaxLength bytes long.
+func HashLinkPassword(password string) (string, error) {
+	if len(password) < LinkPasswordMinLength || len(password) > LinkPasswordMaxLength {
+		return "", errs.NewValueIsInvalidErrorWithCause(
+			"password",
+			fmt.Errorf("must be %d-%d bytes long", LinkPasswordMinLength, LinkPasswordMaxLength),
+		)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("hash link password: %w", err)
+	}
+
+	return string(hash), nil
+}
+
+// IsPasswordProtected reports whether url may be followed only with password.
+func (u *ShortenedURL) IsPasswordProtected() bool {
+	return u.PasswordHash != ""
+}
+
+// CheckPassword reports whether password unlocks url. Unprotected urls are unlocked by anything.
+func (u *ShortenedURL) CheckPassword(password string) bool {
+	if !u.IsPasswordProtected() {
+		return true
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+)
+
+const (
+	PreviewTitleMaxLength       = 200
+	PreviewDescriptionMaxLength = 500
+	PreviewImageURLMaxLength    = 2048
+)
+
+// LinkPreview is Open Graph metadata shown by chat apps and social networks for short url
+// instead of metadata of the page it leads to.
+type LinkPreview struct {
+	Title       string
+	Description string
+	// ImageURL is absolute http(s) url of preview image. Empty if there is no image.
+	ImageURL string
+}
+
+// NewLinkPreview returns trimmed and validated preview. Title is required, the rest is optional.
+func NewLinkPreview(title, description, imageURL string) (*LinkPreview, error) {
+	p := &LinkPreview{
+		Title:       strings.TrimSpace(title),
+		Description: strings.TrimSpace(description),
+		ImageURL:    strings.TrimSpace(imageURL),
+	}
+
+	if p.Title == "" {
+		return nil, errs.NewValueIsInvalidErrorWithCause("preview.title", errors.New("must not be empty"))
+	}
+
+	if utf8.RuneCountInString(p.Title) > PreviewTitleMaxLength {
+		return nil, errs.NewValueIsInvalidErrorWithCause(
+			"preview.title",
+			fmt.Errorf("length must not exceed %d", PreviewTitleMaxLength),
+		)
+	}
+
+	if utf8.RuneCountInString(p.Description) > PreviewDescriptionMaxLength {
+		return nil, errs.NewValueIsInvalidErrorWithCause(
+			"preview.description",
+			fmt.Errorf("length must not exceed %d", PreviewDescriptionMaxLength),
+		)
+	}
+
+	if p.ImageURL != "" {
+		if err := validatePreviewImageURL(p.ImageURL); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+func validatePreviewImageURL(raw string) error {
+	if len(raw) > PreviewImageURLMaxLength {
+		return errs.NewValueIsInvalidErrorWithCause(
+			"preview.image_url",
+			fmt.Errorf("length must not exceed %d", PreviewImageURLMaxLength),
+		)
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errs.NewValueIsInvalidErrorWithCause("preview.image_url", errors.New("must be absolute http(s) url"))
+	}
+
+	return nil
+}
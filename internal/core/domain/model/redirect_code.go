@@ -0,0 +1,39 @@
+package model
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+)
+
+// RedirectCode is http status code url redirects with.
+type RedirectCode int
+
+const (
+	RedirectCodeMovedPermanently  RedirectCode = http.StatusMovedPermanently
+	RedirectCodeFound             RedirectCode = http.StatusFound
+	RedirectCodeTemporaryRedirect RedirectCode = http.StatusTemporaryRedirect
+	RedirectCodePermanentRedirect RedirectCode = http.StatusPermanentRedirect
+)
+
+// ParseRedirectCode returns RedirectCode matching code.
+func ParseRedirectCode(code int) (RedirectCode, error) {
+	switch rc := RedirectCode(code); rc {
+	case RedirectCodeMovedPermanently, RedirectCodeFound, RedirectCodeTemporaryRedirect, RedirectCodePermanentRedirect:
+		return rc, nil
+	default:
+		return 0, errs.NewValueIsInvalidErrorWithCause(
+			"redirectCode",
+			fmt.Errorf(
+				"must be one of %d, %d, %d, %d",
+				RedirectCodeMovedPermanently, RedirectCodeFound, RedirectCodeTemporaryRedirect, RedirectCodePermanentRedirect,
+			),
+		)
+	}
+}
+
+// IsPermanent reports whether clients may cache redirect and follow it without asking again.
+func (c RedirectCode) IsPermanent() bool {
+	return c == RedirectCodeMovedPermanently || c == RedirectCodePermanentRedirect
+}
@@ -0,0 +1,133 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+)
+
+// MaxTargetingRules bounds amount of targeting rules of url.
+const MaxTargetingRules = 10
+
+// countryCodeLength is length of ISO 3166-1 alpha-2 codes.
+const countryCodeLength = 2
+
+// TargetingRule redirects visitors whose country and user agent match it to TargetURL instead of url's
+// OriginalURL. Empty Country, OS or Device matches any one.
+type TargetingRule struct {
+	// Country is ISO 3166-1 alpha-2 code of visitor's country in upper case, e.g. "DE".
+	Country string
+	// OS is os name as recognized by ParseUserAgent, e.g. "iOS".
+	OS string
+	// Device is one of DeviceDesktop, DeviceMobile, DeviceTablet.
+	Device    string
+	TargetURL string
+}
+
+// NewTargetingRule returns rule with country code upper cased and os name and device kind brought to the form
+// ParseUserAgent recognizes them in. At least one of them is required. TargetURL is not validated.
+func NewTargetingRule(country, os, device, targetURL string) (TargetingRule, error) {
+	r := TargetingRule{
+		Country:   strings.ToUpper(strings.TrimSpace(country)),
+		OS:        "",
+		Device:    strings.ToLower(strings.TrimSpace(device)),
+		TargetURL: targetURL,
+	}
+
+	if r.Country != "" && !isCountryCode(r.Country) {
+		return TargetingRule{}, errs.NewValueIsInvalidErrorWithCause(
+			"targeting.country",
+			errors.New("must be ISO 3166-1 alpha-2 code"),
+		)
+	}
+
+	if os = strings.TrimSpace(os); os != "" {
+		r.OS = canonicalOS(os)
+		if r.OS == "" {
+			return TargetingRule{}, errs.NewValueIsInvalidErrorWithCause(
+				"targeting.os",
+				fmt.Errorf("must be one of %s", strings.Join(osNames(), ", ")),
+			)
+		}
+	}
+
+	switch r.Device {
+	case "", DeviceDesktop, DeviceMobile, DeviceTablet:
+	default:
+		return TargetingRule{}, errs.NewValueIsInvalidErrorWithCause(
+			"targeting.device",
+			fmt.Errorf("must be one of %s, %s, %s", DeviceDesktop, DeviceMobile, DeviceTablet),
+		)
+	}
+
+	if r.Country == "" && r.OS == "" && r.Device == "" {
+		return TargetingRule{}, errs.NewValueIsInvalidErrorWithCause(
+			"targeting",
+			errors.New("rule must have country, os or device"),
+		)
+	}
+
+	if targetURL == "" {
+		return TargetingRule{}, errs.NewValueIsInvalidErrorWithCause("targeting.url", errors.New("must not be empty"))
+	}
+
+	return r, nil
+}
+
+// Matches reports whether visitor from country with user agent ua is redirected by rule.
+// Country is empty if it's unknown, such visitor matches only rules without country.
+func (r TargetingRule) Matches(country string, ua UserAgentInfo) bool {
+	return (r.Country == "" || r.Country == country) &&
+		(r.OS == "" || r.OS == ua.OS) &&
+		(r.Device == "" || r.Device == ua.Device)
+}
+
+// Destination returns url visitor from country with user agent ua is redirected to: target url of the first
+// matching targeting rule, OriginalURL if none matches.
+func (u *ShortenedURL) Destination(country string, ua UserAgentInfo) string {
+	for _, r := range u.TargetingRules {
+		if r.Matches(country, ua) {
+			return r.TargetURL
+		}
+	}
+
+	return u.OriginalURL
+}
+
+// IsGeoTargeted reports whether destination of url depends on visitor's country.
+func (u *ShortenedURL) IsGeoTargeted() bool {
+	return slices.ContainsFunc(u.TargetingRules, func(r TargetingRule) bool { return r.Country != "" })
+}
+
+// isCountryCode reports whether code looks like upper case ISO 3166-1 alpha-2 code.
+func isCountryCode(code string) bool {
+	return len(code) == countryCodeLength &&
+		strings.IndexFunc(code, func(c rune) bool { return c < 'A' || c > 'Z' }) == -1
+}
+
+// canonicalOS returns os name the way ParseUserAgent recognizes it, matching case insensitively.
+// Empty if os is unknown.
+func canonicalOS(os string) string {
+	for _, name := range osNames() {
+		if strings.EqualFold(os, name) {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// osNames returns distinct os names ParseUserAgent recognizes.
+func osNames() []string {
+	names := make([]string, 0, len(osMarkers))
+	for _, m := range osMarkers {
+		if !slices.Contains(names, m.name) {
+			names = append(names, m.name)
+		}
+	}
+
+	return names
+}
@@ -1,40 +1,266 @@
 package model
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/dzhordano/urlshortener/internal/pkg/errs"
-	"github.com/dzhordano/urlshortener/internal/pkg/random"
 	"github.com/google/uuid"
 )
 
 const (
-	ShortURLLength   = 8
-	ShortURLValidFor = 14 * 24 * time.Hour // 2 weeks
+	AliasMinLength = 3
+	AliasMaxLength = 32
+
+	MaxTags      = 10
+	TagMaxLength = 32
 )
 
+// URLStatus tells whether shortened url can be used for redirects.
+type URLStatus string
+
+const (
+	URLStatusActive   URLStatus = "active"
+	URLStatusDisabled URLStatus = "disabled"
+)
+
+// ParseURLStatus returns URLStatus matching s.
+func ParseURLStatus(s string) (URLStatus, error) {
+	switch status := URLStatus(s); status {
+	case URLStatusActive, URLStatusDisabled:
+		return status, nil
+	default:
+		return "", errs.NewValueIsInvalidErrorWithCause(
+			"status",
+			fmt.Errorf("must be one of %q, %q", URLStatusActive, URLStatusDisabled),
+		)
+	}
+}
+
 type ShortenedURL struct {
-	ID            uuid.UUID
-	OriginalURL   string
-	ShortURL      string
-	Clicks        int
-	CreatedAtUTC  time.Time
-	ValidUntilUTC time.Time
+	ID          uuid.UUID
+	OriginalURL string
+	ShortURL    string
+	Clicks      int
+	// BotClicks are redirects made by bots. They're not counted as Clicks.
+	BotClicks    int
+	CreatedAtUTC time.Time
+	// ValidFromUTC is moment url becomes active at. Nil for urls active since creation.
+	ValidFromUTC *time.Time
+	// ValidUntilUTC is nil for never expiring urls.
+	ValidUntilUTC *time.Time
+	Status        URLStatus
+	Tags          []string
+	RedirectCode  RedirectCode
+	// Preview is shown to crawlers instead of redirect. Nil if url has no custom preview.
+	Preview *LinkPreview
+	// FallbackURL is redirected to instead of OriginalURL while url is unavailable. Empty if url has none.
+	FallbackURL string
+	// TargetingRules redirect matching visitors elsewhere than OriginalURL, see Destination.
+	TargetingRules []TargetingRule
+	// MaxClicks is amount of clicks url may be followed with. Nil for urls without click limit.
+	MaxClicks *int
+	// PasswordHash is bcrypt hash of password required to follow url. Empty if url isn't password protected.
+	PasswordHash string
+	// OwnerID is id of api key url was created with. Nil for anonymous urls.
+	OwnerID *uuid.UUID
+	// DeletedAtUTC is set for (soft) deleted urls.
+	DeletedAtUTC *time.Time
 }
 
-func NewShortenedURL(originalURL string) (*ShortenedURL, error) {
+// NewShortenedURL creates shortened url for originalURL using shortURL as redirect token.
+// Url redirects with RedirectCodeMovedPermanently unless RedirectCode is changed.
+//
+// Nil validUntil means url never expires.
+func NewShortenedURL(originalURL string, shortURL string, validUntil *time.Time) (*ShortenedURL, error) {
 	if originalURL == "" {
 		return nil, errs.NewValueIsRequiredError("originalURL")
 	}
 
-	n := time.Now()
+	if shortURL == "" {
+		return nil, errs.NewValueIsRequiredError("shortURL")
+	}
+
+	n := time.Now().UTC()
+
+	if validUntil != nil {
+		if !validUntil.After(n) {
+			return nil, errs.NewValueIsInvalidErrorWithCause("validUntil", errors.New("must be in the future"))
+		}
+
+		utc := validUntil.UTC()
+		validUntil = &utc
+	}
 
 	return &ShortenedURL{
-		ID:            uuid.New(),
-		OriginalURL:   originalURL,
-		ShortURL:      random.NewRandomString(ShortURLLength),
-		Clicks:        0,
-		CreatedAtUTC:  n.UTC(),
-		ValidUntilUTC: n.Add(ShortURLValidFor).UTC(),
+		ID:             uuid.New(),
+		OriginalURL:    originalURL,
+		ShortURL:       shortURL,
+		Clicks:         0,
+		CreatedAtUTC:   n,
+		ValidFromUTC:   nil,
+		ValidUntilUTC:  validUntil,
+		Status:         URLStatusActive,
+		Tags:           []string{},
+		RedirectCode:   RedirectCodeMovedPermanently,
+		Preview:        nil,
+		FallbackURL:    "",
+		TargetingRules: nil,
+		MaxClicks:      nil,
+		PasswordHash:   "",
+		OwnerID:        nil,
+		DeletedAtUTC:   nil,
 	}, nil
 }
+
+// IsExpired reports whether url is expired at the moment t.
+func (u *ShortenedURL) IsExpired(t time.Time) bool {
+	return u.ValidUntilUTC != nil && !u.ValidUntilUTC.After(t)
+}
+
+// IsOwnedBy reports whether url was created by owner ownerID. Anonymous urls are owned by nobody.
+func (u *ShortenedURL) IsOwnedBy(ownerID uuid.UUID) bool {
+	return u.OwnerID != nil && ownerID != uuid.Nil && *u.OwnerID == ownerID
+}
+
+// IsPending reports whether url isn't active yet at the moment t.
+func (u *ShortenedURL) IsPending(t time.Time) bool {
+	return u.ValidFromUTC != nil && t.Before(*u.ValidFromUTC)
+}
+
+// IsClickLimitReached reports whether url was followed the amount of times its click limit allows.
+func (u *ShortenedURL) IsClickLimitReached() bool {
+	return u.MaxClicks != nil && u.Clicks >= *u.MaxClicks
+}
+
+// UnavailableReason tells why existing url can't be followed to its original url.
+type UnavailableReason string
+
+const (
+	UnavailableReasonDisabled          UnavailableReason = "disabled"
+	UnavailableReasonExpired           UnavailableReason = "expired"
+	UnavailableReasonPending           UnavailableReason = "pending"
+	UnavailableReasonClickLimitReached UnavailableReason = "click_limit_reached"
+)
+
+// UnavailableReasons are all UnavailableReason values.
+//
+//nolint:gochecknoglobals // Read only.
+var UnavailableReasons = []UnavailableReason{
+	UnavailableReasonDisabled,
+	UnavailableReasonExpired,
+	UnavailableReasonPending,
+	UnavailableReasonClickLimitReached,
+}
+
+// UnavailableReason returns why url can't be followed at the moment t. Empty for url that can be.
+// Clicks of url must be known for click limit to be checked.
+func (u *ShortenedURL) UnavailableReason(t time.Time) UnavailableReason {
+	switch {
+	case u.Status != URLStatusActive:
+		return UnavailableReasonDisabled
+	case u.IsExpired(t):
+		return UnavailableReasonExpired
+	case u.IsPending(t):
+		return UnavailableReasonPending
+	case u.IsClickLimitReached():
+		return UnavailableReasonClickLimitReached
+	default:
+		return ""
+	}
+}
+
+// ValidateAlias checks whether alias can be used as custom short url.
+//
+// Alias must be AliasMinLength-AliasMaxLength long, consist of latin letters, digits, '-' or '_'
+// and must not be one of reserved words (those clash with api routes).
+func ValidateAlias(alias string) error {
+	if len(alias) < AliasMinLength || len(alias) > AliasMaxLength {
+		return errs.NewValueIsInvalidErrorWithCause(
+			"alias",
+			fmt.Errorf("length must be between %d and %d", AliasMinLength, AliasMaxLength),
+		)
+	}
+
+	for _, r := range alias {
+		if !isAliasRune(r) {
+			return errs.NewValueIsInvalidErrorWithCause(
+				"alias",
+				fmt.Errorf("character %q is not allowed", r),
+			)
+		}
+	}
+
+	if isReservedAlias(alias) {
+		return errs.NewValueIsInvalidErrorWithCause(
+			"alias",
+			fmt.Errorf("%q is reserved", alias),
+		)
+	}
+
+	return nil
+}
+
+// NormalizeTags validates tags and returns them lowercased and deduplicated.
+//
+// Nil is returned for no tags. There can be at most MaxTags tags, each 1-TagMaxLength long consisting of the same characters as alias.
+func NormalizeTags(tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || len(tag) > TagMaxLength {
+			return nil, errs.NewValueIsInvalidErrorWithCause(
+				"tags",
+				fmt.Errorf("tag length must be between 1 and %d", TagMaxLength),
+			)
+		}
+
+		for _, r := range tag {
+			if !isAliasRune(r) {
+				return nil, errs.NewValueIsInvalidErrorWithCause(
+					"tags",
+					fmt.Errorf("character %q is not allowed", r),
+				)
+			}
+		}
+
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > MaxTags {
+		return nil, errs.NewValueIsInvalidErrorWithCause(
+			"tags",
+			fmt.Errorf("at most %d tags are allowed", MaxTags),
+		)
+	}
+
+	return normalized, nil
+}
+
+func isAliasRune(r rune) bool {
+	return (r >= 'a' && r <= 'z') ||
+		(r >= 'A' && r <= 'Z') ||
+		(r >= '0' && r <= '9') ||
+		r == '-' || r == '_'
+}
+
+func isReservedAlias(alias string) bool {
+	switch strings.ToLower(alias) {
+	case "info", "shorten", "metrics", "docs", "links", "admin":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,108 @@
+package model
+
+import (
+	"strings"
+)
+
+// Device kinds user agents are classified into.
+const (
+	DeviceDesktop = "desktop"
+	DeviceMobile  = "mobile"
+	DeviceTablet  = "tablet"
+	DeviceBot     = "bot"
+	// UserAgentUnknown is used for every part of user agent that can't be recognized.
+	UserAgentUnknown = "unknown"
+)
+
+// userAgentMarker maps lowercase user agent substring to name it's recognized as.
+type userAgentMarker struct {
+	marker string
+	name   string
+}
+
+// Markers are checked in order, so more specific ones go first, e.g. edge and opera user agents mention chrome,
+// chrome ones mention safari.
+//
+//nolint:gochecknoglobals // Read only.
+var (
+	browserMarkers = []userAgentMarker{
+		{"edg/", "Edge"},
+		{"opr/", "Opera"},
+		{"yabrowser/", "Yandex Browser"},
+		{"samsungbrowser/", "Samsung Internet"},
+		{"firefox/", "Firefox"},
+		{"fxios/", "Firefox"},
+		{"crios/", "Chrome"},
+		{"chrome/", "Chrome"},
+		{"safari/", "Safari"},
+		{"msie ", "Internet Explorer"},
+		{"trident/", "Internet Explorer"},
+	}
+	osMarkers = []userAgentMarker{
+		{"windows", "Windows"},
+		{"iphone", "iOS"},
+		{"ipad", "iOS"},
+		{"android", "Android"},
+		// Trailing space keeps it from matching words like "microsoft".
+		{"cros ", "ChromeOS"},
+		{"mac os x", "macOS"},
+		{"linux", "Linux"},
+	}
+)
+
+// UserAgentInfo is what user agent tells about visitor's software.
+type UserAgentInfo struct {
+	Device  string
+	Browser string
+	OS      string
+}
+
+// ParseUserAgent recognizes device kind, browser and os of user agent. Unrecognized parts are UserAgentUnknown.
+// Bots get DeviceBot.
+func ParseUserAgent(userAgent string) UserAgentInfo {
+	ua := strings.ToLower(userAgent)
+
+	info := UserAgentInfo{
+		Device:  DeviceDesktop,
+		Browser: matchUserAgent(ua, browserMarkers),
+		OS:      matchUserAgent(ua, osMarkers),
+	}
+
+	switch {
+	case IsBotUserAgent(userAgent):
+		info.Device = DeviceBot
+	case strings.Contains(ua, "ipad") || strings.Contains(ua, "tablet") ||
+		(strings.Contains(ua, "android") && !strings.Contains(ua, "mobile")):
+		info.Device = DeviceTablet
+	case strings.Contains(ua, "mobile") || strings.Contains(ua, "iphone"):
+		info.Device = DeviceMobile
+	}
+
+	return info
+}
+
+func matchUserAgent(ua string, markers []userAgentMarker) string {
+	for _, m := range markers {
+		if strings.Contains(ua, m.marker) {
+			return m.name
+		}
+	}
+
+	return UserAgentUnknown
+}
+
+// WithClientHints refines info with Sec-CH-UA-Platform and Sec-CH-UA-Mobile client hints, browsers sending reduced
+// user agent tell real platform there. Empty or unrecognized hints are ignored.
+func (i UserAgentInfo) WithClientHints(platform, mobile string) UserAgentInfo {
+	// Hints are structured header strings, e.g. "Chrome OS" for ChromeOS.
+	platform = strings.NewReplacer(`"`, "", " ", "", "Chromium", "Chrome").Replace(platform)
+	if os := canonicalOS(platform); os != "" {
+		i.OS = os
+	}
+
+	if mobile == "?1" && i.Device == DeviceDesktop {
+		i.Device = DeviceMobile
+	}
+
+	return i
+}
@@ -0,0 +1,46 @@
+//nolint:nolintlint,exhaustruct,testpackage
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUserAgent(t *testing.T) {
+	tt := []struct {
+		name      string
+		userAgent string
+		expected  UserAgentInfo
+	}{
+		{
+			name: "chromeos",
+			userAgent: "Mozilla/5.0 (X11; CrOS x86_64 14541.0.0) AppleWebKit/537.36 (KHTML, like Gecko) " +
+				"Chrome/120.0.0.0 Safari/537.36",
+			expected: UserAgentInfo{Device: DeviceDesktop, Browser: "Chrome", OS: "ChromeOS"},
+		},
+		{
+			name: "microsoft app on macos",
+			userAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) " +
+				"Microsoft Outlook 16.78",
+			expected: UserAgentInfo{Device: DeviceDesktop, Browser: UserAgentUnknown, OS: "macOS"},
+		},
+		{
+			name: "iphone",
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 " +
+				"(KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1",
+			expected: UserAgentInfo{Device: DeviceMobile, Browser: "Safari", OS: "iOS"},
+		},
+		{
+			name:      "empty is bot",
+			userAgent: "",
+			expected:  UserAgentInfo{Device: DeviceBot, Browser: UserAgentUnknown, OS: UserAgentUnknown},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ParseUserAgent(tc.userAgent))
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/google/uuid"
+)
+
+type APIKeyRepository interface {
+	Save(ctx context.Context, key *model.APIKey) error
+	// GetByPrefix returns api key by its plain text prefix, revoked and expired ones included.
+	GetByPrefix(ctx context.Context, prefix string) (*model.APIKey, error)
+	// TouchLastUsed sets moment key was last used at.
+	TouchLastUsed(ctx context.Context, id uuid.UUID, t time.Time) error
+	// Revoke marks key revoked, so it can't be used anymore.
+	Revoke(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+)
+
+type ClickCounter interface {
+	// Increment counts click of short url in memory. Counted clicks are saved to url on Flush.
+	Increment(shortURL string)
+	// IncrementCapped counts click of short url with click limit in db right away, if the limit isn't reached yet.
+	// Comparing and counting is atomic, so the limit isn't exceeded by concurrent clicks. Reports whether click was
+	// counted and how many clicks are left after it.
+	IncrementCapped(ctx context.Context, shortURL string) (counted bool, left int, err error)
+	// IncrementBot counts redirect made by bot separately from clicks.
+	IncrementBot(shortURL string)
+	// Flush adds clicks counted since the last flush to urls. Clicks are kept for the next flush on error.
+	Flush(ctx context.Context) error
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+)
+
+type ClickRecorder interface {
+	// Record queues click event to be persisted in background, so it doesn't wait for storage.
+	// Error is returned if event can't be queued, it is lost then.
+	Record(ctx context.Context, event model.ClickEvent) error
+	// Close persists queued events and stops recording.
+	Close(ctx context.Context) error
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+)
+
+type GeoLocator interface {
+	// Country returns ISO 3166-1 alpha-2 code of country ip is located in, in upper case.
+	// Empty string is returned if country is unknown, including for malformed and private ips.
+	Country(ctx context.Context, ip string) (string, error)
+	// Close releases resources held by locator.
+	Close(ctx context.Context) error
+}
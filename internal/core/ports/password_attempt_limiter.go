@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+)
+
+// PasswordAttemptLimiter limits failed attempts to guess password of password protected url.
+type PasswordAttemptLimiter interface {
+	// Allow reports whether another password attempt may be made for short url.
+	Allow(ctx context.Context, shortURL string) (bool, error)
+	// Fail records failed password attempt made for short url.
+	Fail(ctx context.Context, shortURL string) error
+}
@@ -0,0 +1,10 @@
+package ports
+
+import (
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+)
+
+type RedirectMetrics interface {
+	// FallbackRedirect counts redirect to fallback url made since url couldn't be followed for reason.
+	FallbackRedirect(reason model.UnavailableReason)
+}
@@ -0,0 +1,11 @@
+package ports
+
+import "context"
+
+type TokenGenerator interface {
+	// Generate returns short url token for originalURL.
+	//
+	// Attempt is zero-based number of generation attempt for the same url,
+	// so deterministic generators could produce different token on retry.
+	Generate(ctx context.Context, originalURL string, attempt int) (string, error)
+}
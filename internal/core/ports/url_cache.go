@@ -1,8 +1,18 @@
 package ports
 
-import "context"
+import (
+	"context"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+)
 
 type URLCache interface {
-	Set(ctx context.Context, key string, value string) error
-	Get(ctx context.Context, key string) (string, error)
+	// Set caches url by key. Nil url caches absence of value.
+	Set(ctx context.Context, key string, url *model.ShortenedURL) error
+	// SetMany caches urls by their short urls in one round trip.
+	SetMany(ctx context.Context, urls []*model.ShortenedURL) error
+	// Get returns cached url. Nil url without error means absence of value is cached.
+	Get(ctx context.Context, key string) (*model.ShortenedURL, error)
+	// Delete invalidates cached value.
+	Delete(ctx context.Context, key string) error
 }
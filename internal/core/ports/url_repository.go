@@ -4,10 +4,26 @@ import (
 	"context"
 
 	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/google/uuid"
 )
 
 type URLRepository interface {
+	// Save saves url along with its targeting rules.
 	Save(ctx context.Context, url *model.ShortenedURL) error
+	// SaveBatch saves urls in one round trip. Returned slice holds per url errors in order of urls,
+	// taken short url results in errs.ErrObjectAlreadyExists. Error is returned if batch failed as a whole.
+	SaveBatch(ctx context.Context, urls []*model.ShortenedURL) ([]error, error)
+	// GetByShortenedURL returns url without its targeting rules, they're read by queries only.
 	GetByShortenedURL(ctx context.Context, shortenedURL string) (*model.ShortenedURL, error)
-	GetByOriginalURL(ctx context.Context, originalURL string) (*model.ShortenedURL, error)
+	// GetByOriginalURL returns the newest active and still valid shortened url for originalURL created by ownerID.
+	// Pending urls aren't returned, they can't be followed yet.
+	// Password protected urls are never returned, so they aren't handed out to the ones not knowing password.
+	// Urls with click limit aren't returned either, so their clicks aren't shared, nor are urls with targeting rules,
+	// which would send visitors elsewhere than originalURL.
+	// Nil ownerID looks among anonymous urls.
+	GetByOriginalURL(ctx context.Context, originalURL string, ownerID *uuid.UUID) (*model.ShortenedURL, error)
+	// Update saves changed original url, expiration and status of not deleted url.
+	Update(ctx context.Context, url *model.ShortenedURL) error
+	// Delete soft deletes url, so it's no longer accessible but its history is kept.
+	Delete(ctx context.Context, shortenedURL string) error
 }
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+)
+
+type VisitorCounter interface {
+	// Add counts visitor of short url told by ip and user agent. Visitor is counted once per day.
+	Add(ctx context.Context, shortURL, ip, userAgent string) error
+	// Count returns approximate amount of distinct visitors of short url.
+	Count(ctx context.Context, shortURL string) (int, error)
+	// Delete forgets visitors of short url, so url taking it later doesn't inherit them.
+	Delete(ctx context.Context, shortURL string) error
+}
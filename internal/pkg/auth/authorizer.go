@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/logger"
+	"github.com/dzhordano/urlshortener/internal/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	// ErrUnauthenticated is returned for requests made without principal.
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrForbidden is returned for principals not granted required scopes.
+	ErrForbidden = errors.New("forbidden")
+)
+
+// Authorizer decides whether request may be performed.
+type Authorizer interface {
+	// Authorize returns principal from ctx if it is granted all scopes.
+	// ErrUnauthenticated is returned for anonymous requests, ErrForbidden if some scope is missing.
+	Authorize(ctx context.Context, scopes ...string) (Principal, error)
+}
+
+type authorizer struct {
+	log logger.Logger
+}
+
+// NewAuthorizer returns Authorizer logging and tracing denials.
+func NewAuthorizer(log logger.Logger) (Authorizer, error) {
+	if log == nil {
+		return nil, errs.NewValueIsRequiredError("log")
+	}
+
+	return &authorizer{log: log}, nil
+}
+
+func (a *authorizer) Authorize(ctx context.Context, scopes ...string) (Principal, error) {
+	span := tracing.SpanFromContext(ctx)
+
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		span.AddEvent("access denied", trace.WithAttributes(attribute.String("auth.reason", "unauthenticated")))
+		a.log.Info("access denied", "reason", "unauthenticated", "scopes", scopes)
+		return Principal{}, ErrUnauthenticated
+	}
+
+	var missing []string
+	for _, s := range scopes {
+		if !p.HasScope(s) {
+			missing = append(missing, s)
+		}
+	}
+
+	if len(missing) > 0 {
+		span.AddEvent("access denied", trace.WithAttributes(
+			attribute.String("auth.reason", "forbidden"),
+			attribute.String("auth.key_id", p.KeyID.String()),
+			attribute.String("auth.role", p.Role),
+			attribute.StringSlice("auth.missing_scopes", missing),
+		))
+		a.log.Warn("access denied",
+			"reason", "forbidden",
+			"key_id", p.KeyID,
+			"name", p.Name,
+			"role", p.Role,
+			"missing_scopes", missing,
+		)
+		return Principal{}, fmt.Errorf("%w: missing scopes %v", ErrForbidden, missing)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Principal is authenticated api client.
+type Principal struct {
+	// KeyID is id of api key principal authenticated with. Nil for bootstrap admin key.
+	KeyID uuid.UUID
+	Name  string
+	Role  string
+	// Scopes are all scopes granted to principal including role's ones.
+	Scopes []string
+}
+
+// HasScope reports whether principal is granted scope.
+func (p Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns context carrying p.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns principal request is made by. False is returned for anonymous requests.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
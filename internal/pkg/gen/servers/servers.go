@@ -10,6 +10,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
@@ -20,74 +21,631 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/runtime"
 	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 const (
 	ApiKeyAuthScopes = "ApiKeyAuth.Scopes"
 )
 
+// Defines values for DestinationSafetyVerdict.
+const (
+	Caution DestinationSafetyVerdict = "caution"
+	Ok      DestinationSafetyVerdict = "ok"
+	Unknown DestinationSafetyVerdict = "unknown"
+)
+
+// Defines values for DestinationSafetyWarnings.
+const (
+	Credentials     DestinationSafetyWarnings = "credentials"
+	InsecureScheme  DestinationSafetyWarnings = "insecure_scheme"
+	IpHost          DestinationSafetyWarnings = "ip_host"
+	NonStandardPort DestinationSafetyWarnings = "non_standard_port"
+	PunycodeHost    DestinationSafetyWarnings = "punycode_host"
+)
+
+// Defines values for RedirectCode.
+const (
+	N301 RedirectCode = 301
+	N302 RedirectCode = 302
+	N307 RedirectCode = 307
+	N308 RedirectCode = 308
+)
+
+// Defines values for Role.
+const (
+	RoleAdmin  Role = "admin"
+	RoleEditor Role = "editor"
+	RoleViewer Role = "viewer"
+)
+
+// Defines values for Scope.
+const (
+	ScopeAdmin          Scope = "admin"
+	ScopeAdminKeys      Scope = "admin:keys"
+	ScopeLinksCreate    Scope = "links:create"
+	ScopeLinksDelete    Scope = "links:delete"
+	ScopeLinksReadStats Scope = "links:read_stats"
+	ScopeLinksUpdate    Scope = "links:update"
+)
+
+// Defines values for TargetingRuleDevice.
+const (
+	Desktop TargetingRuleDevice = "desktop"
+	Mobile  TargetingRuleDevice = "mobile"
+	Tablet  TargetingRuleDevice = "tablet"
+)
+
+// Defines values for TargetingRuleOs.
+const (
+	Android  TargetingRuleOs = "Android"
+	ChromeOS TargetingRuleOs = "ChromeOS"
+	IOS      TargetingRuleOs = "iOS"
+	Linux    TargetingRuleOs = "Linux"
+	MacOS    TargetingRuleOs = "macOS"
+	Windows  TargetingRuleOs = "Windows"
+)
+
+// Defines values for URLStatus.
+const (
+	URLStatusActive   URLStatus = "active"
+	URLStatusDisabled URLStatus = "disabled"
+)
+
+// Defines values for ListLinksParamsStatus.
+const (
+	ListLinksParamsStatusActive   ListLinksParamsStatus = "active"
+	ListLinksParamsStatusDisabled ListLinksParamsStatus = "disabled"
+	ListLinksParamsStatusExpired  ListLinksParamsStatus = "expired"
+	ListLinksParamsStatusPending  ListLinksParamsStatus = "pending"
+)
+
+// Defines values for ListLinksParamsSortBy.
+const (
+	Clicks    ListLinksParamsSortBy = "clicks"
+	CreatedAt ListLinksParamsSortBy = "created_at"
+)
+
+// Defines values for ListLinksParamsOrder.
+const (
+	Asc  ListLinksParamsOrder = "asc"
+	Desc ListLinksParamsOrder = "desc"
+)
+
+// Defines values for GetShortenedURLStatsParamsInterval.
+const (
+	Day  GetShortenedURLStatsParamsInterval = "day"
+	Hour GetShortenedURLStatsParamsInterval = "hour"
+	Week GetShortenedURLStatsParamsInterval = "week"
+)
+
+// APIKey defines model for APIKey.
+type APIKey struct {
+	// CreatedAt Key creation date
+	CreatedAt time.Time `json:"created_at"`
+
+	// ExpiresAt Moment key expires at. Null for never expiring key
+	ExpiresAt *time.Time `json:"expires_at"`
+
+	// Id Api key id
+	Id string `json:"id"`
+
+	// LastUsedAt Moment key was last used at (up to a minute). Null for never used key
+	LastUsedAt *time.Time `json:"last_used_at"`
+
+	// Name Human-readable key name
+	Name string `json:"name"`
+
+	// Prefix Beginning of the key
+	Prefix string `json:"prefix"`
+
+	// Revoked Revoked key can't be used
+	Revoked bool `json:"revoked"`
+
+	// Role Set of scopes granted to api key. Viewer may read stats of own urls, editor may create, update and delete own urls too. Admin is granted every scope
+	Role Role `json:"role"`
+
+	// Scopes Scopes granted to key in addition to role's ones
+	Scopes []string `json:"scopes"`
+}
+
+// ClicksBucket defines model for ClicksBucket.
+type ClicksBucket struct {
+	// Clicks Amount of clicks in bucket
+	Clicks int `json:"clicks"`
+
+	// Start Bucket start
+	Start time.Time `json:"start"`
+}
+
+// CreatedAPIKey defines model for CreatedAPIKey.
+type CreatedAPIKey struct {
+	ApiKey APIKey `json:"api_key"`
+
+	// Key Api key to be sent in X-Api-Key header. Shown only once
+	Key string `json:"key"`
+}
+
+// DestinationSafety Heuristic assessment made from destination url alone, without visiting it. Verdict ok doesn't guarantee destination is harmless
+type DestinationSafety struct {
+	// Verdict Caution if destination has traits often seen in phishing links
+	Verdict DestinationSafetyVerdict `json:"verdict"`
+
+	// Warnings Suspicious traits found
+	Warnings []DestinationSafetyWarnings `json:"warnings"`
+}
+
+// DestinationSafetyVerdict Caution if destination has traits often seen in phishing links
+type DestinationSafetyVerdict string
+
+// DestinationSafetyWarnings defines model for DestinationSafety.Warnings.
+type DestinationSafetyWarnings string
+
 // Error Error response
 type Error struct {
 	// Code Error code
 	Code string `json:"code"`
 
+	// Field Request field error relates to
+	Field *string `json:"field,omitempty"`
+
 	// Message Human-readable error message
 	Message string `json:"message"`
 }
 
+// LinkPreview Open Graph metadata shown by chat apps and social networks when short url is shared
+type LinkPreview struct {
+	// Description og:description, up to 500 characters
+	Description *string `json:"description,omitempty"`
+
+	// ImageUrl og:image, absolute http(s) url up to 2048 characters
+	ImageUrl *string `json:"image_url,omitempty"`
+
+	// Title og:title, up to 200 characters
+	Title string `json:"title"`
+}
+
+// LinksPage defines model for LinksPage.
+type LinksPage struct {
+	Items []URL `json:"items"`
+
+	// NextCursor Cursor of the next page. Null for the last page
+	NextCursor *string `json:"next_cursor"`
+}
+
+// RedirectCode HTTP status code of redirect. Server default is used if omitted on creation
+type RedirectCode int
+
+// Role Set of scopes granted to api key. Viewer may read stats of own urls, editor may create, update and delete own urls too. Admin is granted every scope
+type Role string
+
+// Scope Permission to perform operations. Admin scope allows managing any url, not only own ones
+type Scope string
+
+// ShortenBatchItemResult defines model for ShortenBatchItemResult.
+type ShortenBatchItemResult struct {
+	// Created False if existing url was reused. Set on success
+	Created *bool `json:"created,omitempty"`
+
+	// Error Error response
+	Error *Error `json:"error,omitempty"`
+
+	// Index Index of request item
+	Index int `json:"index"`
+
+	// ShortUrl Shortened url. Set on success
+	ShortUrl *string `json:"short_url,omitempty"`
+}
+
+// ShortenRequest defines model for ShortenRequest.
+type ShortenRequest struct {
+	// Alias Custom short url (3-32 characters of a-z, A-Z, 0-9, '-', '_'). Random one is generated if omitted
+	Alias *string `json:"alias,omitempty"`
+
+	// ExpiresAt Moment url expires at. Mutually exclusive with ttl and never_expires
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// FallbackUrl Url redirected to with 302 while url is not active yet, once it expires, is used up or disabled
+	FallbackUrl *string `json:"fallback_url,omitempty"`
+
+	// MaxClicks Amount of clicks url may be followed with, then it responds with 410. Bots' redirects are not counted. No limit if omitted
+	MaxClicks *int `json:"max_clicks,omitempty"`
+
+	// NeverExpires Makes url never expire. Allowed for operators only
+	NeverExpires *bool `json:"never_expires,omitempty"`
+
+	// Password Password url is followed with (6-72 bytes). Url is not password protected if omitted
+	Password *string `json:"password,omitempty"`
+
+	// Preview Open Graph metadata shown by chat apps and social networks when short url is shared
+	Preview *LinkPreview `json:"preview,omitempty"`
+
+	// RedirectCode HTTP status code of redirect. Server default is used if omitted on creation
+	RedirectCode *RedirectCode `json:"redirect_code,omitempty"`
+
+	// ReuseExisting Return still valid url already shortened for the same original url instead of creating new one. Reused url keeps its own expiration. Password protected urls and urls with click limit are never reused. Can not be used with alias, password or max_clicks
+	ReuseExisting *bool `json:"reuse_existing,omitempty"`
+
+	// Tags Tags to find url by (up to 10, each 1-32 characters of a-z, 0-9, '-', '_')
+	Tags *[]string `json:"tags,omitempty"`
+
+	// Targeting Rules redirecting visitors elsewhere than url by their os and device, checked in order, the first matching one wins. Visitors matching none are redirected to url. Can not be used with reuse_existing
+	Targeting *[]TargetingRule `json:"targeting,omitempty"`
+
+	// Ttl Url lifetime in seconds. Mutually exclusive with expires_at and never_expires
+	Ttl *int64 `json:"ttl,omitempty"`
+
+	// Url url to shorten
+	Url string `json:"url"`
+
+	// ValidFrom Moment url becomes active at. Url responds with 425 until then. Lifetime set with ttl is counted from it. Url is active right away if omitted
+	ValidFrom *time.Time `json:"valid_from,omitempty"`
+}
+
+// ShortenResponse defines model for ShortenResponse.
+type ShortenResponse struct {
+	// Created False if existing url was reused
+	Created *bool `json:"created,omitempty"`
+
+	// ShortUrl Shortened url
+	ShortUrl *string `json:"short_url,omitempty"`
+}
+
+// StatsEntry defines model for StatsEntry.
+type StatsEntry struct {
+	Clicks int    `json:"clicks"`
+	Value  string `json:"value"`
+}
+
+// TargetingRule Redirects visitors whose country, os and device match it to its url. Rule must have country, os or device, omitted one matches any
+type TargetingRule struct {
+	// Country ISO 3166-1 alpha-2 code of visitor's country, located by ip. Visitors of unknown country match rules without country only
+	Country *string `json:"country,omitempty"`
+
+	// Device Visitor's device kind
+	Device *TargetingRuleDevice `json:"device,omitempty"`
+
+	// Os Visitor's os
+	Os *TargetingRuleOs `json:"os,omitempty"`
+
+	// Url Url matching visitors are redirected to
+	Url string `json:"url"`
+}
+
+// TargetingRuleDevice Visitor's device kind
+type TargetingRuleDevice string
+
+// TargetingRuleOs Visitor's os
+type TargetingRuleOs string
+
 // URL defines model for URL.
 type URL struct {
-	// Clicks Amount of clicks
+	// BotClicks Amount of redirects made by bots (crawlers, link unfurlers, monitors). They are not counted as clicks
+	BotClicks *int `json:"bot_clicks,omitempty"`
+
+	// Clicks Amount of clicks. Bots' redirects are not counted
 	Clicks *int `json:"clicks,omitempty"`
 
 	// CreatedAtUtc Shortened URL creation date
 	CreatedAtUtc *time.Time `json:"created_at_utc,omitempty"`
 
+	// FallbackUrl Url redirected to while url can't be followed. Omitted if url has none
+	FallbackUrl *string `json:"fallback_url,omitempty"`
+
+	// MaxClicks Amount of clicks url may be followed with. Omitted for url without click limit
+	MaxClicks *int `json:"max_clicks,omitempty"`
+
 	// OriginalUrl Original URL
 	OriginalUrl *string `json:"original_url,omitempty"`
 
+	// PasswordProtected Whether url is followed only with password
+	PasswordProtected *bool `json:"password_protected,omitempty"`
+
+	// Pending Whether url is not active yet
+	Pending *bool `json:"pending,omitempty"`
+
+	// Preview Open Graph metadata shown by chat apps and social networks when short url is shared
+	Preview *LinkPreview `json:"preview,omitempty"`
+
+	// RedirectCode HTTP status code of redirect. Server default is used if omitted on creation
+	RedirectCode *RedirectCode `json:"redirect_code,omitempty"`
+
 	// ShortUrl Shortened URL
 	ShortUrl *string `json:"short_url,omitempty"`
 
-	// ValidUntilUtc Shortened URL ttl
-	ValidUntilUtc *time.Time `json:"valid_until_utc,omitempty"`
+	// Status Shortened URL status. Disabled url doesn't redirect
+	Status *URLStatus `json:"status,omitempty"`
+
+	// Tags Url tags
+	Tags *[]string `json:"tags,omitempty"`
+
+	// Targeting Rules redirecting visitors elsewhere than original url. Omitted if url has none
+	Targeting *[]TargetingRule `json:"targeting,omitempty"`
+
+	// UniqueVisitors Approximate amount of distinct visitors. Visitor coming back on another day is counted again. Returned by info only
+	UniqueVisitors *int `json:"unique_visitors,omitempty"`
+
+	// ValidFromUtc Moment url becomes active at. Null for url active since creation
+	ValidFromUtc *time.Time `json:"valid_from_utc"`
+
+	// ValidUntilUtc Shortened URL ttl. Null for never expiring url
+	ValidUntilUtc *time.Time `json:"valid_until_utc"`
+}
+
+// URLPreview defines model for URLPreview.
+type URLPreview struct {
+	// ClickLimited Whether url may be followed limited number of times. Destination of such url is not revealed, its safety is unknown
+	ClickLimited bool `json:"click_limited"`
+
+	// CreatedAtUtc Shortened URL creation date
+	CreatedAtUtc time.Time `json:"created_at_utc"`
+
+	// OriginalUrl Destination short url leads to. Empty for password protected url and url with click limit
+	OriginalUrl string `json:"original_url"`
+
+	// PasswordProtected Whether url is followed only with password. Destination of such url is not revealed, its safety is unknown
+	PasswordProtected bool `json:"password_protected"`
+
+	// Safety Heuristic assessment made from destination url alone, without visiting it. Verdict ok doesn't guarantee destination is harmless
+	Safety DestinationSafety `json:"safety"`
+
+	// ShortUrl Shortened URL
+	ShortUrl string `json:"short_url"`
+
+	// ValidUntilUtc Shortened URL ttl. Null for never expiring url
+	ValidUntilUtc *time.Time `json:"valid_until_utc"`
 }
 
+// URLStats defines model for URLStats.
+type URLStats struct {
+	// Browsers Clicks per browser
+	Browsers []StatsEntry `json:"browsers"`
+
+	// Buckets Clicks over time. Every bucket of the period is present, edge ones may be partial
+	Buckets []ClicksBucket `json:"buckets"`
+
+	// Clicks Amount of clicks in the period
+	Clicks int `json:"clicks"`
+
+	// Devices Clicks per device kind: desktop, mobile, tablet or bot
+	Devices []StatsEntry `json:"devices"`
+
+	// From Inclusive start of the period
+	From time.Time `json:"from"`
+
+	// Interval Width of time series bucket
+	Interval string `json:"interval"`
+
+	// Os Clicks per operating system
+	Os []StatsEntry `json:"os"`
+
+	// ShortUrl Shortened URL
+	ShortUrl string `json:"short_url"`
+
+	// To Exclusive end of the period
+	To time.Time `json:"to"`
+
+	// TopCountries Country codes with the most clicks. Visits from unknown countries are not included
+	TopCountries []StatsEntry `json:"top_countries"`
+
+	// TopReferrers Referrer hosts with the most clicks. Direct visits are not included
+	TopReferrers []StatsEntry `json:"top_referrers"`
+
+	// UniqueVisitors Estimate of distinct visitors in days the period touches. Visitors are told by anonymized ip and user agent. Visitor coming back on another day is counted again
+	UniqueVisitors int `json:"unique_visitors"`
+}
+
+// URLStatus Shortened URL status. Disabled url doesn't redirect
+type URLStatus string
+
 // BadRequestResponse Error response
 type BadRequestResponse = Error
 
 // ConflictResponse Error response
 type ConflictResponse = Error
 
+// ForbiddenResponse Error response
+type ForbiddenResponse = Error
+
+// GoneResponse Error response
+type GoneResponse = Error
+
+// LinkPasswordResponse Error response
+type LinkPasswordResponse = Error
+
 // NotFoundResponse Error response
 type NotFoundResponse = Error
 
+// TooEarlyResponse Error response
+type TooEarlyResponse = Error
+
+// TooManyPasswordAttemptsResponse Error response
+type TooManyPasswordAttemptsResponse = Error
+
 // UnauthorizedResponse Error response
 type UnauthorizedResponse = Error
 
 // UrlResponse defines model for UrlResponse.
 type UrlResponse = URL
 
-// ShortenURLJSONBody defines parameters for ShortenURL.
-type ShortenURLJSONBody struct {
-	// Url url to shorten
-	Url string `json:"url"`
+// ListAPIKeysParams defines parameters for ListAPIKeys.
+type ListAPIKeysParams struct {
+	// IncludeRevoked List revoked keys too
+	IncludeRevoked *bool `form:"include_revoked,omitempty" json:"include_revoked,omitempty"`
+}
+
+// CreateAPIKeyJSONBody defines parameters for CreateAPIKey.
+type CreateAPIKeyJSONBody struct {
+	// ExpiresAt Moment key expires at. Key never expires if omitted
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Name Human-readable key name
+	Name string `json:"name"`
+
+	// Role Set of scopes granted to api key. Viewer may read stats of own urls, editor may create, update and delete own urls too. Admin is granted every scope
+	Role Role `json:"role"`
+
+	// Scopes Scopes granted to key in addition to role's ones
+	Scopes *[]Scope `json:"scopes,omitempty"`
+}
+
+// ListLinksParams defines parameters for ListLinks.
+type ListLinksParams struct {
+	// Status Url state
+	Status *ListLinksParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// CreatedFrom Inclusive lower bound of url creation date
+	CreatedFrom *time.Time `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo Exclusive upper bound of url creation date
+	CreatedTo *time.Time `form:"created_to,omitempty" json:"created_to,omitempty"`
+
+	// Host Substring of original url's host
+	Host *string `form:"host,omitempty" json:"host,omitempty"`
+
+	// Tag Url tag
+	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// Owner Id of api key urls were created with. Admin only
+	Owner *openapi_types.UUID `form:"owner,omitempty" json:"owner,omitempty"`
+
+	// SortBy Sort field
+	SortBy *ListLinksParamsSortBy `form:"sort_by,omitempty" json:"sort_by,omitempty"`
+
+	// Order Sort order
+	Order *ListLinksParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+
+	// Limit Page size
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Cursor of the page to return. It must be used with sort_by and order of the page it came from
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListLinksParamsStatus defines parameters for ListLinks.
+type ListLinksParamsStatus string
+
+// ListLinksParamsSortBy defines parameters for ListLinks.
+type ListLinksParamsSortBy string
+
+// ListLinksParamsOrder defines parameters for ListLinks.
+type ListLinksParamsOrder string
+
+// ShortenURLsBatchJSONBody defines parameters for ShortenURLsBatch.
+type ShortenURLsBatchJSONBody struct {
+	Items []ShortenRequest `json:"items"`
+}
+
+// RedirectParams defines parameters for Redirect.
+type RedirectParams struct {
+	// XLinkPassword Password of password protected url
+	XLinkPassword *string `json:"X-Link-Password,omitempty"`
+}
+
+// UpdateURLJSONBody defines parameters for UpdateURL.
+type UpdateURLJSONBody struct {
+	// ExpiresAt New moment url expires at. Mutually exclusive with ttl and never_expires
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// NeverExpires Makes url never expire. Allowed for operators only
+	NeverExpires *bool `json:"never_expires,omitempty"`
+
+	// RedirectCode HTTP status code of redirect. Server default is used if omitted on creation
+	RedirectCode *RedirectCode `json:"redirect_code,omitempty"`
+
+	// Status Shortened URL status. Disabled url doesn't redirect
+	Status *URLStatus `json:"status,omitempty"`
+
+	// Tags Tags replacing current ones
+	Tags *[]string `json:"tags,omitempty"`
+
+	// Ttl New url lifetime in seconds counting from now. Mutually exclusive with expires_at and never_expires
+	Ttl *int64 `json:"ttl,omitempty"`
+
+	// Url New original url
+	Url *string `json:"url,omitempty"`
+}
+
+// RedirectWithPasswordFormdataBody defines parameters for RedirectWithPassword.
+type RedirectWithPasswordFormdataBody struct {
+	// Password Password of url
+	Password string `form:"password" json:"password"`
+}
+
+// GetShortenedURLStatsParams defines parameters for GetShortenedURLStats.
+type GetShortenedURLStatsParams struct {
+	// From Inclusive start of the period, rounded down to hour. Defaults to 7 days before to
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Exclusive end of the period, rounded down to hour. Defaults to now
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Interval Width of time series bucket. Weeks start on mondays. Up to 1000 buckets may be requested
+	Interval *GetShortenedURLStatsParamsInterval `form:"interval,omitempty" json:"interval,omitempty"`
 }
 
+// GetShortenedURLStatsParamsInterval defines parameters for GetShortenedURLStats.
+type GetShortenedURLStatsParamsInterval string
+
+// CreateAPIKeyJSONRequestBody defines body for CreateAPIKey for application/json ContentType.
+type CreateAPIKeyJSONRequestBody CreateAPIKeyJSONBody
+
 // ShortenURLJSONRequestBody defines body for ShortenURL for application/json ContentType.
-type ShortenURLJSONRequestBody ShortenURLJSONBody
+type ShortenURLJSONRequestBody = ShortenRequest
+
+// ShortenURLsBatchJSONRequestBody defines body for ShortenURLsBatch for application/json ContentType.
+type ShortenURLsBatchJSONRequestBody ShortenURLsBatchJSONBody
+
+// UpdateURLJSONRequestBody defines body for UpdateURL for application/json ContentType.
+type UpdateURLJSONRequestBody UpdateURLJSONBody
+
+// RedirectWithPasswordFormdataRequestBody defines body for RedirectWithPassword for application/x-www-form-urlencoded ContentType.
+type RedirectWithPasswordFormdataRequestBody RedirectWithPasswordFormdataBody
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Lists api keys
+	// (GET /api/v1/admin/keys)
+	ListAPIKeys(ctx echo.Context, params ListAPIKeysParams) error
+	// Creates api key
+	// (POST /api/v1/admin/keys)
+	CreateAPIKey(ctx echo.Context) error
+	// Revokes api key
+	// (DELETE /api/v1/admin/keys/{id})
+	RevokeAPIKey(ctx echo.Context, id string) error
+	// Lists shortened urls
+	// (GET /api/v1/links)
+	ListLinks(ctx echo.Context, params ListLinksParams) error
 	// Shorten URL
 	// (POST /api/v1/shorten)
 	ShortenURL(ctx echo.Context) error
+	// Shorten URLs in bulk
+	// (POST /api/v1/shorten/batch)
+	ShortenURLsBatch(ctx echo.Context) error
+	// Deletes shortened url
+	// (DELETE /api/v1/{token})
+	DeleteURL(ctx echo.Context, token string) error
 	// Redirect to original url using provided token (using short url)
 	// (GET /api/v1/{token})
-	Redirect(ctx echo.Context, token string) error
+	Redirect(ctx echo.Context, token string, params RedirectParams) error
+	// Redirect to original url using provided token for link checkers
+	// (HEAD /api/v1/{token})
+	RedirectHead(ctx echo.Context, token string) error
+	// Updates shortened url
+	// (PATCH /api/v1/{token})
+	UpdateURL(ctx echo.Context, token string) error
+	// Redirect to original url of password protected url
+	// (POST /api/v1/{token})
+	RedirectWithPassword(ctx echo.Context, token string) error
 	// Returns info about shortened url
 	// (GET /api/v1/{token}/info)
 	GetShortenedURLInfo(ctx echo.Context, token string) error
+	// Shows where short url leads without following it
+	// (GET /api/v1/{token}/preview)
+	PreviewURL(ctx echo.Context, token string) error
+	// Returns click statistics of shortened url
+	// (GET /api/v1/{token}/stats)
+	GetShortenedURLStats(ctx echo.Context, token string, params GetShortenedURLStatsParams) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -95,232 +653,1382 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
-// ShortenURL converts echo context to params.
-func (w *ServerInterfaceWrapper) ShortenURL(ctx echo.Context) error {
+// ListAPIKeys converts echo context to params.
+func (w *ServerInterfaceWrapper) ListAPIKeys(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(ApiKeyAuthScopes, []string{"admin:keys"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAPIKeysParams
+	// ------------- Optional query parameter "include_revoked" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_revoked", ctx.QueryParams(), &params.IncludeRevoked)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include_revoked: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.ShortenURL(ctx)
+	err = w.Handler.ListAPIKeys(ctx, params)
 	return err
 }
 
-// Redirect converts echo context to params.
-func (w *ServerInterfaceWrapper) Redirect(ctx echo.Context) error {
+// CreateAPIKey converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateAPIKey(ctx echo.Context) error {
 	var err error
-	// ------------- Path parameter "token" -------------
-	var token string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "token", ctx.Param("token"), &token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
-	}
+	ctx.Set(ApiKeyAuthScopes, []string{"admin:keys"})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.Redirect(ctx, token)
+	err = w.Handler.CreateAPIKey(ctx)
 	return err
 }
 
-// GetShortenedURLInfo converts echo context to params.
-func (w *ServerInterfaceWrapper) GetShortenedURLInfo(ctx echo.Context) error {
+// RevokeAPIKey converts echo context to params.
+func (w *ServerInterfaceWrapper) RevokeAPIKey(ctx echo.Context) error {
 	var err error
-	// ------------- Path parameter "token" -------------
-	var token string
+	// ------------- Path parameter "id" -------------
+	var id string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "token", ctx.Param("token"), &token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
-	ctx.Set(ApiKeyAuthScopes, []string{})
+	ctx.Set(ApiKeyAuthScopes, []string{"admin:keys"})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetShortenedURLInfo(ctx, token)
+	err = w.Handler.RevokeAPIKey(ctx, id)
 	return err
 }
 
-// This is a simple interface which specifies echo.Route addition functions which
-// are present on both echo.Echo and echo.Group, since we want to allow using
-// either of them for path registration
-type EchoRouter interface {
-	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-}
+// ListLinks converts echo context to params.
+func (w *ServerInterfaceWrapper) ListLinks(ctx echo.Context) error {
+	var err error
 
-// RegisterHandlers adds each server route to the EchoRouter.
-func RegisterHandlers(router EchoRouter, si ServerInterface) {
-	RegisterHandlersWithBaseURL(router, si, "")
-}
+	ctx.Set(ApiKeyAuthScopes, []string{"links:read_stats"})
 
-// Registers handlers, and prepends BaseURL to the paths, so that the paths
-// can be served under a prefix.
-func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListLinksParams
+	// ------------- Optional query parameter "status" -------------
 
-	wrapper := ServerInterfaceWrapper{
-		Handler: si,
+	err = runtime.BindQueryParameter("form", true, false, "status", ctx.QueryParams(), &params.Status)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter status: %s", err))
 	}
 
-	router.POST(baseURL+"/api/v1/shorten", wrapper.ShortenURL)
-	router.GET(baseURL+"/api/v1/:token", wrapper.Redirect)
-	router.GET(baseURL+"/api/v1/:token/info", wrapper.GetShortenedURLInfo)
+	// ------------- Optional query parameter "created_from" -------------
 
-}
+	err = runtime.BindQueryParameter("form", true, false, "created_from", ctx.QueryParams(), &params.CreatedFrom)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter created_from: %s", err))
+	}
 
-type BadRequestResponseJSONResponse Error
+	// ------------- Optional query parameter "created_to" -------------
 
-type ConflictResponseJSONResponse Error
+	err = runtime.BindQueryParameter("form", true, false, "created_to", ctx.QueryParams(), &params.CreatedTo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter created_to: %s", err))
+	}
 
-type NotFoundResponseJSONResponse Error
+	// ------------- Optional query parameter "host" -------------
 
-type UnauthorizedResponseJSONResponse Error
+	err = runtime.BindQueryParameter("form", true, false, "host", ctx.QueryParams(), &params.Host)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter host: %s", err))
+	}
 
-type UrlResponseJSONResponse URL
+	// ------------- Optional query parameter "tag" -------------
 
-type ShortenURLRequestObject struct {
+	err = runtime.BindQueryParameter("form", true, false, "tag", ctx.QueryParams(), &params.Tag)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tag: %s", err))
+	}
+
+	// ------------- Optional query parameter "owner" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "owner", ctx.QueryParams(), &params.Owner)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter owner: %s", err))
+	}
+
+	// ------------- Optional query parameter "sort_by" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_by", ctx.QueryParams(), &params.SortBy)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort_by: %s", err))
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", ctx.QueryParams(), &params.Order)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter order: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListLinks(ctx, params)
+	return err
+}
+
+// ShortenURL converts echo context to params.
+func (w *ServerInterfaceWrapper) ShortenURL(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyAuthScopes, []string{"links:create"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ShortenURL(ctx)
+	return err
+}
+
+// ShortenURLsBatch converts echo context to params.
+func (w *ServerInterfaceWrapper) ShortenURLsBatch(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyAuthScopes, []string{"links:create"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ShortenURLsBatch(ctx)
+	return err
+}
+
+// DeleteURL converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteURL(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "token" -------------
+	var token string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "token", ctx.Param("token"), &token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
+	}
+
+	ctx.Set(ApiKeyAuthScopes, []string{"links:delete"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteURL(ctx, token)
+	return err
+}
+
+// Redirect converts echo context to params.
+func (w *ServerInterfaceWrapper) Redirect(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "token" -------------
+	var token string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "token", ctx.Param("token"), &token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RedirectParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "X-Link-Password" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Link-Password")]; found {
+		var XLinkPassword string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Link-Password, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Link-Password", valueList[0], &XLinkPassword, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Link-Password: %s", err))
+		}
+
+		params.XLinkPassword = &XLinkPassword
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.Redirect(ctx, token, params)
+	return err
+}
+
+// RedirectHead converts echo context to params.
+func (w *ServerInterfaceWrapper) RedirectHead(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "token" -------------
+	var token string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "token", ctx.Param("token"), &token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RedirectHead(ctx, token)
+	return err
+}
+
+// UpdateURL converts echo context to params.
+func (w *ServerInterfaceWrapper) UpdateURL(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "token" -------------
+	var token string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "token", ctx.Param("token"), &token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
+	}
+
+	ctx.Set(ApiKeyAuthScopes, []string{"links:update"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.UpdateURL(ctx, token)
+	return err
+}
+
+// RedirectWithPassword converts echo context to params.
+func (w *ServerInterfaceWrapper) RedirectWithPassword(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "token" -------------
+	var token string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "token", ctx.Param("token"), &token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RedirectWithPassword(ctx, token)
+	return err
+}
+
+// GetShortenedURLInfo converts echo context to params.
+func (w *ServerInterfaceWrapper) GetShortenedURLInfo(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "token" -------------
+	var token string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "token", ctx.Param("token"), &token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
+	}
+
+	ctx.Set(ApiKeyAuthScopes, []string{"links:read_stats"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetShortenedURLInfo(ctx, token)
+	return err
+}
+
+// PreviewURL converts echo context to params.
+func (w *ServerInterfaceWrapper) PreviewURL(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "token" -------------
+	var token string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "token", ctx.Param("token"), &token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PreviewURL(ctx, token)
+	return err
+}
+
+// GetShortenedURLStats converts echo context to params.
+func (w *ServerInterfaceWrapper) GetShortenedURLStats(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "token" -------------
+	var token string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "token", ctx.Param("token"), &token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
+	}
+
+	ctx.Set(ApiKeyAuthScopes, []string{"links:read_stats"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetShortenedURLStatsParams
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// ------------- Optional query parameter "interval" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "interval", ctx.QueryParams(), &params.Interval)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter interval: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetShortenedURLStats(ctx, token, params)
+	return err
+}
+
+// This is a simple interface which specifies echo.Route addition functions which
+// are present on both echo.Echo and echo.Group, since we want to allow using
+// either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+	RegisterHandlersWithBaseURL(router, si, "")
+}
+
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET(baseURL+"/api/v1/admin/keys", wrapper.ListAPIKeys)
+	router.POST(baseURL+"/api/v1/admin/keys", wrapper.CreateAPIKey)
+	router.DELETE(baseURL+"/api/v1/admin/keys/:id", wrapper.RevokeAPIKey)
+	router.GET(baseURL+"/api/v1/links", wrapper.ListLinks)
+	router.POST(baseURL+"/api/v1/shorten", wrapper.ShortenURL)
+	router.POST(baseURL+"/api/v1/shorten/batch", wrapper.ShortenURLsBatch)
+	router.DELETE(baseURL+"/api/v1/:token", wrapper.DeleteURL)
+	router.GET(baseURL+"/api/v1/:token", wrapper.Redirect)
+	router.HEAD(baseURL+"/api/v1/:token", wrapper.RedirectHead)
+	router.PATCH(baseURL+"/api/v1/:token", wrapper.UpdateURL)
+	router.POST(baseURL+"/api/v1/:token", wrapper.RedirectWithPassword)
+	router.GET(baseURL+"/api/v1/:token/info", wrapper.GetShortenedURLInfo)
+	router.GET(baseURL+"/api/v1/:token/preview", wrapper.PreviewURL)
+	router.GET(baseURL+"/api/v1/:token/stats", wrapper.GetShortenedURLStats)
+
+}
+
+type BadRequestResponseJSONResponse Error
+
+type ConflictResponseJSONResponse Error
+
+type ForbiddenResponseJSONResponse Error
+
+type GoneResponseJSONResponse Error
+
+type LinkPasswordResponseJSONResponse Error
+type LinkPasswordResponseTexthtmlResponse struct {
+	Body io.Reader
+
+	ContentLength int64
+}
+
+type NotFoundResponseJSONResponse Error
+
+type TooEarlyResponseJSONResponse Error
+
+type TooManyPasswordAttemptsResponseJSONResponse Error
+type TooManyPasswordAttemptsResponseTexthtmlResponse struct {
+	Body io.Reader
+
+	ContentLength int64
+}
+
+type UnauthorizedResponseJSONResponse Error
+
+type UrlResponseJSONResponse URL
+
+type ListAPIKeysRequestObject struct {
+	Params ListAPIKeysParams
+}
+
+type ListAPIKeysResponseObject interface {
+	VisitListAPIKeysResponse(w http.ResponseWriter) error
+}
+
+type ListAPIKeys200JSONResponse struct {
+	Items []APIKey `json:"items"`
+}
+
+func (response ListAPIKeys200JSONResponse) VisitListAPIKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAPIKeys401JSONResponse struct {
+	UnauthorizedResponseJSONResponse
+}
+
+func (response ListAPIKeys401JSONResponse) VisitListAPIKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAPIKeys403JSONResponse struct{ ForbiddenResponseJSONResponse }
+
+func (response ListAPIKeys403JSONResponse) VisitListAPIKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAPIKeyRequestObject struct {
+	Body *CreateAPIKeyJSONRequestBody
+}
+
+type CreateAPIKeyResponseObject interface {
+	VisitCreateAPIKeyResponse(w http.ResponseWriter) error
+}
+
+type CreateAPIKey201JSONResponse CreatedAPIKey
+
+func (response CreateAPIKey201JSONResponse) VisitCreateAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAPIKey400JSONResponse struct{ BadRequestResponseJSONResponse }
+
+func (response CreateAPIKey400JSONResponse) VisitCreateAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAPIKey401JSONResponse struct {
+	UnauthorizedResponseJSONResponse
+}
+
+func (response CreateAPIKey401JSONResponse) VisitCreateAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAPIKey403JSONResponse struct{ ForbiddenResponseJSONResponse }
+
+func (response CreateAPIKey403JSONResponse) VisitCreateAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeAPIKeyRequestObject struct {
+	Id string `json:"id"`
+}
+
+type RevokeAPIKeyResponseObject interface {
+	VisitRevokeAPIKeyResponse(w http.ResponseWriter) error
+}
+
+type RevokeAPIKey204Response struct {
+}
+
+func (response RevokeAPIKey204Response) VisitRevokeAPIKeyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RevokeAPIKey400JSONResponse struct{ BadRequestResponseJSONResponse }
+
+func (response RevokeAPIKey400JSONResponse) VisitRevokeAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeAPIKey401JSONResponse struct {
+	UnauthorizedResponseJSONResponse
+}
+
+func (response RevokeAPIKey401JSONResponse) VisitRevokeAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeAPIKey403JSONResponse struct{ ForbiddenResponseJSONResponse }
+
+func (response RevokeAPIKey403JSONResponse) VisitRevokeAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeAPIKey404JSONResponse struct{ NotFoundResponseJSONResponse }
+
+func (response RevokeAPIKey404JSONResponse) VisitRevokeAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListLinksRequestObject struct {
+	Params ListLinksParams
+}
+
+type ListLinksResponseObject interface {
+	VisitListLinksResponse(w http.ResponseWriter) error
+}
+
+type ListLinks200JSONResponse LinksPage
+
+func (response ListLinks200JSONResponse) VisitListLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListLinks400JSONResponse struct{ BadRequestResponseJSONResponse }
+
+func (response ListLinks400JSONResponse) VisitListLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListLinks401JSONResponse struct {
+	UnauthorizedResponseJSONResponse
+}
+
+func (response ListLinks401JSONResponse) VisitListLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListLinks403JSONResponse struct{ ForbiddenResponseJSONResponse }
+
+func (response ListLinks403JSONResponse) VisitListLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShortenURLRequestObject struct {
 	Body *ShortenURLJSONRequestBody
 }
 
-type ShortenURLResponseObject interface {
-	VisitShortenURLResponse(w http.ResponseWriter) error
+type ShortenURLResponseObject interface {
+	VisitShortenURLResponse(w http.ResponseWriter) error
+}
+
+type ShortenURL200JSONResponse ShortenResponse
+
+func (response ShortenURL200JSONResponse) VisitShortenURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShortenURL400JSONResponse struct{ BadRequestResponseJSONResponse }
+
+func (response ShortenURL400JSONResponse) VisitShortenURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShortenURL401JSONResponse struct {
+	UnauthorizedResponseJSONResponse
+}
+
+func (response ShortenURL401JSONResponse) VisitShortenURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShortenURL403JSONResponse struct{ ForbiddenResponseJSONResponse }
+
+func (response ShortenURL403JSONResponse) VisitShortenURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShortenURL409JSONResponse struct{ ConflictResponseJSONResponse }
+
+func (response ShortenURL409JSONResponse) VisitShortenURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShortenURL503JSONResponse Error
+
+func (response ShortenURL503JSONResponse) VisitShortenURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShortenURLsBatchRequestObject struct {
+	Body *ShortenURLsBatchJSONRequestBody
+}
+
+type ShortenURLsBatchResponseObject interface {
+	VisitShortenURLsBatchResponse(w http.ResponseWriter) error
+}
+
+type ShortenURLsBatch200JSONResponse struct {
+	Items []ShortenBatchItemResult `json:"items"`
+}
+
+func (response ShortenURLsBatch200JSONResponse) VisitShortenURLsBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShortenURLsBatch400JSONResponse struct{ BadRequestResponseJSONResponse }
+
+func (response ShortenURLsBatch400JSONResponse) VisitShortenURLsBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShortenURLsBatch401JSONResponse struct {
+	UnauthorizedResponseJSONResponse
+}
+
+func (response ShortenURLsBatch401JSONResponse) VisitShortenURLsBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShortenURLsBatch403JSONResponse struct{ ForbiddenResponseJSONResponse }
+
+func (response ShortenURLsBatch403JSONResponse) VisitShortenURLsBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteURLRequestObject struct {
+	Token string `json:"token"`
+}
+
+type DeleteURLResponseObject interface {
+	VisitDeleteURLResponse(w http.ResponseWriter) error
+}
+
+type DeleteURL204Response struct {
+}
+
+func (response DeleteURL204Response) VisitDeleteURLResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteURL400JSONResponse struct{ BadRequestResponseJSONResponse }
+
+func (response DeleteURL400JSONResponse) VisitDeleteURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteURL401JSONResponse struct {
+	UnauthorizedResponseJSONResponse
+}
+
+func (response DeleteURL401JSONResponse) VisitDeleteURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteURL403JSONResponse struct{ ForbiddenResponseJSONResponse }
+
+func (response DeleteURL403JSONResponse) VisitDeleteURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteURL404JSONResponse struct{ NotFoundResponseJSONResponse }
+
+func (response DeleteURL404JSONResponse) VisitDeleteURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedirectRequestObject struct {
+	Token  string `json:"token"`
+	Params RedirectParams
+}
+
+type RedirectResponseObject interface {
+	VisitRedirectResponse(w http.ResponseWriter) error
+}
+
+type Redirect200TexthtmlResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response Redirect200TexthtmlResponse) VisitRedirectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type Redirect400JSONResponse struct{ BadRequestResponseJSONResponse }
+
+func (response Redirect400JSONResponse) VisitRedirectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Redirect401JSONResponse struct {
+	LinkPasswordResponseJSONResponse
+}
+
+func (response Redirect401JSONResponse) VisitRedirectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Redirect401TexthtmlResponse struct {
+	LinkPasswordResponseTexthtmlResponse
+}
+
+func (response Redirect401TexthtmlResponse) VisitRedirectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(401)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type Redirect404JSONResponse struct{ NotFoundResponseJSONResponse }
+
+func (response Redirect404JSONResponse) VisitRedirectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Redirect409JSONResponse struct{ ConflictResponseJSONResponse }
+
+func (response Redirect409JSONResponse) VisitRedirectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type ShortenURL200JSONResponse struct {
-	// ShortUrl Shortened url
-	ShortUrl *string `json:"short_url,omitempty"`
+type Redirect410JSONResponse struct{ GoneResponseJSONResponse }
+
+func (response Redirect410JSONResponse) VisitRedirectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(410)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (response ShortenURL200JSONResponse) VisitShortenURLResponse(w http.ResponseWriter) error {
+type Redirect425JSONResponse struct{ TooEarlyResponseJSONResponse }
+
+func (response Redirect425JSONResponse) VisitRedirectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(425)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Redirect429JSONResponse struct {
+	TooManyPasswordAttemptsResponseJSONResponse
+}
+
+func (response Redirect429JSONResponse) VisitRedirectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Redirect429TexthtmlResponse struct {
+	TooManyPasswordAttemptsResponseTexthtmlResponse
+}
+
+func (response Redirect429TexthtmlResponse) VisitRedirectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(429)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type RedirectHeadRequestObject struct {
+	Token string `json:"token"`
+}
+
+type RedirectHeadResponseObject interface {
+	VisitRedirectHeadResponse(w http.ResponseWriter) error
+}
+
+type RedirectHead400JSONResponse struct{ BadRequestResponseJSONResponse }
+
+func (response RedirectHead400JSONResponse) VisitRedirectHeadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedirectHead401JSONResponse struct {
+	LinkPasswordResponseJSONResponse
+}
+
+func (response RedirectHead401JSONResponse) VisitRedirectHeadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedirectHead401TexthtmlResponse struct {
+	LinkPasswordResponseTexthtmlResponse
+}
+
+func (response RedirectHead401TexthtmlResponse) VisitRedirectHeadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(401)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type RedirectHead404JSONResponse struct{ NotFoundResponseJSONResponse }
+
+func (response RedirectHead404JSONResponse) VisitRedirectHeadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedirectHead410JSONResponse struct{ GoneResponseJSONResponse }
+
+func (response RedirectHead410JSONResponse) VisitRedirectHeadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(410)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedirectHead425JSONResponse struct{ TooEarlyResponseJSONResponse }
+
+func (response RedirectHead425JSONResponse) VisitRedirectHeadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(425)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateURLRequestObject struct {
+	Token string `json:"token"`
+	Body  *UpdateURLJSONRequestBody
+}
+
+type UpdateURLResponseObject interface {
+	VisitUpdateURLResponse(w http.ResponseWriter) error
+}
+
+type UpdateURL204Response struct {
+}
+
+func (response UpdateURL204Response) VisitUpdateURLResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type UpdateURL400JSONResponse struct{ BadRequestResponseJSONResponse }
+
+func (response UpdateURL400JSONResponse) VisitUpdateURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateURL401JSONResponse struct {
+	UnauthorizedResponseJSONResponse
+}
+
+func (response UpdateURL401JSONResponse) VisitUpdateURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateURL403JSONResponse struct{ ForbiddenResponseJSONResponse }
+
+func (response UpdateURL403JSONResponse) VisitUpdateURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateURL404JSONResponse struct{ NotFoundResponseJSONResponse }
+
+func (response UpdateURL404JSONResponse) VisitUpdateURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedirectWithPasswordRequestObject struct {
+	Token string `json:"token"`
+	Body  *RedirectWithPasswordFormdataRequestBody
+}
+
+type RedirectWithPasswordResponseObject interface {
+	VisitRedirectWithPasswordResponse(w http.ResponseWriter) error
+}
+
+type RedirectWithPassword200TexthtmlResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response RedirectWithPassword200TexthtmlResponse) VisitRedirectWithPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type RedirectWithPassword400JSONResponse struct{ BadRequestResponseJSONResponse }
+
+func (response RedirectWithPassword400JSONResponse) VisitRedirectWithPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedirectWithPassword401JSONResponse struct {
+	LinkPasswordResponseJSONResponse
+}
+
+func (response RedirectWithPassword401JSONResponse) VisitRedirectWithPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedirectWithPassword401TexthtmlResponse struct {
+	LinkPasswordResponseTexthtmlResponse
+}
+
+func (response RedirectWithPassword401TexthtmlResponse) VisitRedirectWithPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(401)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type RedirectWithPassword404JSONResponse struct{ NotFoundResponseJSONResponse }
+
+func (response RedirectWithPassword404JSONResponse) VisitRedirectWithPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedirectWithPassword410JSONResponse struct{ GoneResponseJSONResponse }
+
+func (response RedirectWithPassword410JSONResponse) VisitRedirectWithPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(410)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedirectWithPassword425JSONResponse struct{ TooEarlyResponseJSONResponse }
+
+func (response RedirectWithPassword425JSONResponse) VisitRedirectWithPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(425)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedirectWithPassword429JSONResponse struct {
+	TooManyPasswordAttemptsResponseJSONResponse
+}
+
+func (response RedirectWithPassword429JSONResponse) VisitRedirectWithPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedirectWithPassword429TexthtmlResponse struct {
+	TooManyPasswordAttemptsResponseTexthtmlResponse
+}
+
+func (response RedirectWithPassword429TexthtmlResponse) VisitRedirectWithPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(429)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetShortenedURLInfoRequestObject struct {
+	Token string `json:"token"`
+}
+
+type GetShortenedURLInfoResponseObject interface {
+	VisitGetShortenedURLInfoResponse(w http.ResponseWriter) error
+}
+
+type GetShortenedURLInfo200JSONResponse struct{ UrlResponseJSONResponse }
+
+func (response GetShortenedURLInfo200JSONResponse) VisitGetShortenedURLInfoResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ShortenURL400JSONResponse struct{ BadRequestResponseJSONResponse }
+type GetShortenedURLInfo400JSONResponse struct{ BadRequestResponseJSONResponse }
 
-func (response ShortenURL400JSONResponse) VisitShortenURLResponse(w http.ResponseWriter) error {
+func (response GetShortenedURLInfo400JSONResponse) VisitGetShortenedURLInfoResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetShortenedURLInfo401JSONResponse struct {
+	UnauthorizedResponseJSONResponse
+}
+
+func (response GetShortenedURLInfo401JSONResponse) VisitGetShortenedURLInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetShortenedURLInfo403JSONResponse struct{ ForbiddenResponseJSONResponse }
+
+func (response GetShortenedURLInfo403JSONResponse) VisitGetShortenedURLInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetShortenedURLInfo404JSONResponse struct{ NotFoundResponseJSONResponse }
+
+func (response GetShortenedURLInfo404JSONResponse) VisitGetShortenedURLInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetShortenedURLInfo409JSONResponse struct{ ConflictResponseJSONResponse }
+
+func (response GetShortenedURLInfo409JSONResponse) VisitGetShortenedURLInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PreviewURLRequestObject struct {
+	Token string `json:"token"`
+}
+
+type PreviewURLResponseObject interface {
+	VisitPreviewURLResponse(w http.ResponseWriter) error
 }
 
-type ShortenURL409JSONResponse struct{ ConflictResponseJSONResponse }
+type PreviewURL200JSONResponse URLPreview
 
-func (response ShortenURL409JSONResponse) VisitShortenURLResponse(w http.ResponseWriter) error {
+func (response PreviewURL200JSONResponse) VisitPreviewURLResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RedirectRequestObject struct {
-	Token string `json:"token"`
+type PreviewURL200TexthtmlResponse struct {
+	Body          io.Reader
+	ContentLength int64
 }
 
-type RedirectResponseObject interface {
-	VisitRedirectResponse(w http.ResponseWriter) error
+func (response PreviewURL200TexthtmlResponse) VisitPreviewURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
 }
 
-type Redirect400JSONResponse struct{ BadRequestResponseJSONResponse }
+type PreviewURL400JSONResponse struct{ BadRequestResponseJSONResponse }
 
-func (response Redirect400JSONResponse) VisitRedirectResponse(w http.ResponseWriter) error {
+func (response PreviewURL400JSONResponse) VisitPreviewURLResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Redirect404JSONResponse struct{ NotFoundResponseJSONResponse }
+type PreviewURL404JSONResponse struct{ NotFoundResponseJSONResponse }
 
-func (response Redirect404JSONResponse) VisitRedirectResponse(w http.ResponseWriter) error {
+func (response PreviewURL404JSONResponse) VisitPreviewURLResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Redirect409JSONResponse struct{ ConflictResponseJSONResponse }
+type PreviewURL410JSONResponse struct{ GoneResponseJSONResponse }
 
-func (response Redirect409JSONResponse) VisitRedirectResponse(w http.ResponseWriter) error {
+func (response PreviewURL410JSONResponse) VisitPreviewURLResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	w.WriteHeader(410)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetShortenedURLInfoRequestObject struct {
-	Token string `json:"token"`
+type PreviewURL425JSONResponse struct{ TooEarlyResponseJSONResponse }
+
+func (response PreviewURL425JSONResponse) VisitPreviewURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(425)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetShortenedURLInfoResponseObject interface {
-	VisitGetShortenedURLInfoResponse(w http.ResponseWriter) error
+type GetShortenedURLStatsRequestObject struct {
+	Token  string `json:"token"`
+	Params GetShortenedURLStatsParams
 }
 
-type GetShortenedURLInfo200JSONResponse struct{ UrlResponseJSONResponse }
+type GetShortenedURLStatsResponseObject interface {
+	VisitGetShortenedURLStatsResponse(w http.ResponseWriter) error
+}
 
-func (response GetShortenedURLInfo200JSONResponse) VisitGetShortenedURLInfoResponse(w http.ResponseWriter) error {
+type GetShortenedURLStats200JSONResponse URLStats
+
+func (response GetShortenedURLStats200JSONResponse) VisitGetShortenedURLStatsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetShortenedURLInfo400JSONResponse struct{ BadRequestResponseJSONResponse }
+type GetShortenedURLStats400JSONResponse struct{ BadRequestResponseJSONResponse }
 
-func (response GetShortenedURLInfo400JSONResponse) VisitGetShortenedURLInfoResponse(w http.ResponseWriter) error {
+func (response GetShortenedURLStats400JSONResponse) VisitGetShortenedURLStatsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetShortenedURLInfo401JSONResponse struct {
+type GetShortenedURLStats401JSONResponse struct {
 	UnauthorizedResponseJSONResponse
 }
 
-func (response GetShortenedURLInfo401JSONResponse) VisitGetShortenedURLInfoResponse(w http.ResponseWriter) error {
+func (response GetShortenedURLStats401JSONResponse) VisitGetShortenedURLStatsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetShortenedURLInfo404JSONResponse struct{ NotFoundResponseJSONResponse }
+type GetShortenedURLStats403JSONResponse struct{ ForbiddenResponseJSONResponse }
 
-func (response GetShortenedURLInfo404JSONResponse) VisitGetShortenedURLInfoResponse(w http.ResponseWriter) error {
+func (response GetShortenedURLStats403JSONResponse) VisitGetShortenedURLStatsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetShortenedURLInfo409JSONResponse struct{ ConflictResponseJSONResponse }
+type GetShortenedURLStats404JSONResponse struct{ NotFoundResponseJSONResponse }
 
-func (response GetShortenedURLInfo409JSONResponse) VisitGetShortenedURLInfoResponse(w http.ResponseWriter) error {
+func (response GetShortenedURLStats404JSONResponse) VisitGetShortenedURLStatsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// Lists api keys
+	// (GET /api/v1/admin/keys)
+	ListAPIKeys(ctx context.Context, request ListAPIKeysRequestObject) (ListAPIKeysResponseObject, error)
+	// Creates api key
+	// (POST /api/v1/admin/keys)
+	CreateAPIKey(ctx context.Context, request CreateAPIKeyRequestObject) (CreateAPIKeyResponseObject, error)
+	// Revokes api key
+	// (DELETE /api/v1/admin/keys/{id})
+	RevokeAPIKey(ctx context.Context, request RevokeAPIKeyRequestObject) (RevokeAPIKeyResponseObject, error)
+	// Lists shortened urls
+	// (GET /api/v1/links)
+	ListLinks(ctx context.Context, request ListLinksRequestObject) (ListLinksResponseObject, error)
 	// Shorten URL
 	// (POST /api/v1/shorten)
 	ShortenURL(ctx context.Context, request ShortenURLRequestObject) (ShortenURLResponseObject, error)
+	// Shorten URLs in bulk
+	// (POST /api/v1/shorten/batch)
+	ShortenURLsBatch(ctx context.Context, request ShortenURLsBatchRequestObject) (ShortenURLsBatchResponseObject, error)
+	// Deletes shortened url
+	// (DELETE /api/v1/{token})
+	DeleteURL(ctx context.Context, request DeleteURLRequestObject) (DeleteURLResponseObject, error)
 	// Redirect to original url using provided token (using short url)
 	// (GET /api/v1/{token})
 	Redirect(ctx context.Context, request RedirectRequestObject) (RedirectResponseObject, error)
+	// Redirect to original url using provided token for link checkers
+	// (HEAD /api/v1/{token})
+	RedirectHead(ctx context.Context, request RedirectHeadRequestObject) (RedirectHeadResponseObject, error)
+	// Updates shortened url
+	// (PATCH /api/v1/{token})
+	UpdateURL(ctx context.Context, request UpdateURLRequestObject) (UpdateURLResponseObject, error)
+	// Redirect to original url of password protected url
+	// (POST /api/v1/{token})
+	RedirectWithPassword(ctx context.Context, request RedirectWithPasswordRequestObject) (RedirectWithPasswordResponseObject, error)
 	// Returns info about shortened url
 	// (GET /api/v1/{token}/info)
 	GetShortenedURLInfo(ctx context.Context, request GetShortenedURLInfoRequestObject) (GetShortenedURLInfoResponseObject, error)
+	// Shows where short url leads without following it
+	// (GET /api/v1/{token}/preview)
+	PreviewURL(ctx context.Context, request PreviewURLRequestObject) (PreviewURLResponseObject, error)
+	// Returns click statistics of shortened url
+	// (GET /api/v1/{token}/stats)
+	GetShortenedURLStats(ctx context.Context, request GetShortenedURLStatsRequestObject) (GetShortenedURLStatsResponseObject, error)
 }
 
 type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
@@ -335,6 +2043,110 @@ type strictHandler struct {
 	middlewares []StrictMiddlewareFunc
 }
 
+// ListAPIKeys operation middleware
+func (sh *strictHandler) ListAPIKeys(ctx echo.Context, params ListAPIKeysParams) error {
+	var request ListAPIKeysRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAPIKeys(ctx.Request().Context(), request.(ListAPIKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAPIKeys")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListAPIKeysResponseObject); ok {
+		return validResponse.VisitListAPIKeysResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// CreateAPIKey operation middleware
+func (sh *strictHandler) CreateAPIKey(ctx echo.Context) error {
+	var request CreateAPIKeyRequestObject
+
+	var body CreateAPIKeyJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateAPIKey(ctx.Request().Context(), request.(CreateAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateAPIKey")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(CreateAPIKeyResponseObject); ok {
+		return validResponse.VisitCreateAPIKeyResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// RevokeAPIKey operation middleware
+func (sh *strictHandler) RevokeAPIKey(ctx echo.Context, id string) error {
+	var request RevokeAPIKeyRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeAPIKey(ctx.Request().Context(), request.(RevokeAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeAPIKey")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(RevokeAPIKeyResponseObject); ok {
+		return validResponse.VisitRevokeAPIKeyResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ListLinks operation middleware
+func (sh *strictHandler) ListLinks(ctx echo.Context, params ListLinksParams) error {
+	var request ListLinksRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListLinks(ctx.Request().Context(), request.(ListLinksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListLinks")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListLinksResponseObject); ok {
+		return validResponse.VisitListLinksResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // ShortenURL operation middleware
 func (sh *strictHandler) ShortenURL(ctx echo.Context) error {
 	var request ShortenURLRequestObject
@@ -364,11 +2176,66 @@ func (sh *strictHandler) ShortenURL(ctx echo.Context) error {
 	return nil
 }
 
+// ShortenURLsBatch operation middleware
+func (sh *strictHandler) ShortenURLsBatch(ctx echo.Context) error {
+	var request ShortenURLsBatchRequestObject
+
+	var body ShortenURLsBatchJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ShortenURLsBatch(ctx.Request().Context(), request.(ShortenURLsBatchRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ShortenURLsBatch")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ShortenURLsBatchResponseObject); ok {
+		return validResponse.VisitShortenURLsBatchResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DeleteURL operation middleware
+func (sh *strictHandler) DeleteURL(ctx echo.Context, token string) error {
+	var request DeleteURLRequestObject
+
+	request.Token = token
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteURL(ctx.Request().Context(), request.(DeleteURLRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteURL")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteURLResponseObject); ok {
+		return validResponse.VisitDeleteURLResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // Redirect operation middleware
-func (sh *strictHandler) Redirect(ctx echo.Context, token string) error {
+func (sh *strictHandler) Redirect(ctx echo.Context, token string, params RedirectParams) error {
 	var request RedirectRequestObject
 
 	request.Token = token
+	request.Params = params
 
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.Redirect(ctx.Request().Context(), request.(RedirectRequestObject))
@@ -389,6 +2256,97 @@ func (sh *strictHandler) Redirect(ctx echo.Context, token string) error {
 	return nil
 }
 
+// RedirectHead operation middleware
+func (sh *strictHandler) RedirectHead(ctx echo.Context, token string) error {
+	var request RedirectHeadRequestObject
+
+	request.Token = token
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RedirectHead(ctx.Request().Context(), request.(RedirectHeadRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RedirectHead")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(RedirectHeadResponseObject); ok {
+		return validResponse.VisitRedirectHeadResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// UpdateURL operation middleware
+func (sh *strictHandler) UpdateURL(ctx echo.Context, token string) error {
+	var request UpdateURLRequestObject
+
+	request.Token = token
+
+	var body UpdateURLJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateURL(ctx.Request().Context(), request.(UpdateURLRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateURL")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(UpdateURLResponseObject); ok {
+		return validResponse.VisitUpdateURLResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// RedirectWithPassword operation middleware
+func (sh *strictHandler) RedirectWithPassword(ctx echo.Context, token string) error {
+	var request RedirectWithPasswordRequestObject
+
+	request.Token = token
+
+	if form, err := ctx.FormParams(); err == nil {
+		var body RedirectWithPasswordFormdataRequestBody
+		if err := runtime.BindForm(&body, form, nil, nil); err != nil {
+			return err
+		}
+		request.Body = &body
+	} else {
+		return err
+	}
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RedirectWithPassword(ctx.Request().Context(), request.(RedirectWithPasswordRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RedirectWithPassword")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(RedirectWithPasswordResponseObject); ok {
+		return validResponse.VisitRedirectWithPasswordResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // GetShortenedURLInfo operation middleware
 func (sh *strictHandler) GetShortenedURLInfo(ctx echo.Context, token string) error {
 	var request GetShortenedURLInfoRequestObject
@@ -414,26 +2372,163 @@ func (sh *strictHandler) GetShortenedURLInfo(ctx echo.Context, token string) err
 	return nil
 }
 
+// PreviewURL operation middleware
+func (sh *strictHandler) PreviewURL(ctx echo.Context, token string) error {
+	var request PreviewURLRequestObject
+
+	request.Token = token
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PreviewURL(ctx.Request().Context(), request.(PreviewURLRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PreviewURL")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PreviewURLResponseObject); ok {
+		return validResponse.VisitPreviewURLResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetShortenedURLStats operation middleware
+func (sh *strictHandler) GetShortenedURLStats(ctx echo.Context, token string, params GetShortenedURLStatsParams) error {
+	var request GetShortenedURLStatsRequestObject
+
+	request.Token = token
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetShortenedURLStats(ctx.Request().Context(), request.(GetShortenedURLStatsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetShortenedURLStats")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetShortenedURLStatsResponseObject); ok {
+		return validResponse.VisitGetShortenedURLStatsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/8xW32/bNhD+VwhuwFpAjpKuL9ObW+yHkawp3AQZEBgBI50lNhKpkcd0nqH/fThSsmxL",
-	"mV1ky/ZmU3f3fXf33ZFrnuqq1goUWp6suQFba2XB/3knsjn87sDivD2m01QrBIX0U9R1KVOBUqv4s9WK",
-	"zmxaQCXo17cGljzh38Q9RBy+2vhHY7ThTdNEPAObGllTEJ4QJjMBlE3Yoyhl5uMzCB4Rf6/VspTpC3Lq",
-	"EAn9g8aftFPZy6HPwWpnUmBKI1sSNvG4VsJhoY38E16QyzYqmzD6AwpbEN83aSDwM+U/Tut6fjFG6lOh",
-	"DYKCjDlTsgxQyNJysmsdKW5IKVnv+fpj1qmeR7w2ugaDMkxAqjN4ysl/iziuauAJt2ikyinzCqwV+Yjb",
-	"L64SamJAZOK+hCBo1lkPAjUR39QzueUtWme+iDhKLKFj0/vr+88QpErlStb7GZUyfbBDctNKO4VML1lr",
-	"sQkoFUIOfvRSAwIhuxN45zAdBuk7cT2/YN6adJEJJO5LbSqBPOH0f4KyGi2fNjKXSpR3zpRDhMv2KwFs",
-	"h2z9R+JZ4jQebIfucdH8QrpzCmV5TAkQyyMTbwYNJO6QOiNx9Yl0HPo3reU5rKYOi5EefpyxB1ixpTZ7",
-	"o8kjLsmiAJEBiUWJirB+m0xrOTmHVU9IeIAwZ1ItNcGUMoV2kFvHX2dXPOK+qrxArG0Sx7oGFVbViTZ5",
-	"3DrZmGybXq9Ul65Khk0/znjEH8HYkMPZyenJqddBDUrUkif8e38U8Vpg4WsQi1rGj2exDVG8yLXFYUHe",
-	"e71aJpjdtCUUnFYF9yDGF2iW9b0LYmgvoXc6W33VAtsdt1Hd0ZpC3XE6OPoUYzGUx8hFEa7NPjTrkDo9",
-	"92HROPA4W/f9m9PTZ6R61KCFsh/W/khy6IyyW42kUE3E3wbSY/fGJrl45CXjXX847Dp4bmwPJk9uFxG3",
-	"rqqEWfWJtvsERW7bBna0DV+Qf6fgNeoHUA2xyGFEwHPIpIEULXWx24xdV78UMi2Yj8CkZSWITKr8hF0V",
-	"0rKbyw/fXbGby/k5w8JolxfMfhF5DmbiJHvlVAnWMsmW8g8m8fVgFjpkP3dGVIBgKJmnGAYe3Z6hUe23",
-	"TPdpV33RlpL2BbHYU+azmvz2sOvgVfevqGOrVrvNdJZWUm30o8wga1v6Kpx65ZDV669RVNyt7lFZ3ciy",
-	"ZMZPFCNDJu61w73Z2lfEz4CbOb6eX8wI4H8hjjfHiGP7PfrcrXF2BNzY8/w/V+Pu8+F20ezJM6zYv1HE",
-	"U/o7DNN67itk5zHQi2InfrNo/goAAP//GvNZoKcOAAA=",
+	"H4sIAAAAAAAC/+x963IbN9Loq3TN2SrbVUOKknLZ6J/s9SY+cWyXJMenNuWjgmaaJFYzwCyAEc249O5f",
+	"dQNzI8GLZNlx9ssvmzMDoNHo+wX6mGS6rLRC5Wxy8jExaCutLPKPpyI/w//UaN1ZeExPM60cKkf/FVVV",
+	"yEw4qdXBv61W9MxmcywF/e9vBqfJSfJ/DrolDvxbe/DcGG2S29vbNMnRZkZWNElyQmuC8YvCCG5EIXOe",
+	"H9CPSJNnWk0LmX1BmJoVafV/anMl8xzVl1u+XRJGoLQDVLqezaFCU0prpVaWAPtRK/xyML01BSyEBVEY",
+	"FPkSproo9AJzcHMEUepaOdBTcLJEC9JZyAqZXUMhS+lA0LcM9Euprt8Iaxfa5J8N+DRx+MEdzF1ZDAe7",
+	"ZYXJSWKdkWq2aZfSQhUghMpoh5nDHITKu8fSAp+EmoE2sDBazcbw1OiFRWNhhq77dKpNSRt/pd0/da3y",
+	"L3diZ2h1bTJkEprS2gTHhdbPhSmWX5ZypGUoRObkDcISXQDlF6GWDTmcOodl5exXSBYXWkMp1NIfdXu4",
+	"FhZoEGbyBhUdNNSmgEI4LJa0v7dK1G6ujfwdv+Cx91eFEdAPVC4swpJWGmRSeGuKBwfr7dnLGFDnc20c",
+	"KswZRTk6IQvLRxIG0rynb178jEv6X2V0hcZJr5Uyg8JhfikYtuHEP+MS+D1tLhcOkzQhlqNvE/o9IomU",
+	"pKtnnCb4oZIGbXTWX3SJysE1LiF8BsKN4VVdFHzOCm/Q+FckA65xuWlZVReFuCowOXGmxggYMl9f/rSS",
+	"vLbMY4AXwrrL2mK+C3QS1/Qx0McgHDyuK3AaBJRS1Q6frO2IP/yU3ShR4jpMP9WlUCPSGzSYYeMPIxNU",
+	"Bqfyw/oUT3EmlWKBO2WV46FcG2/wRl9jBKdn/gUvngn1yMEV8n67Wa60LlAonkYXuIvUz+gbpmBdoV1f",
+	"8Zyfw8wIRQrEaX+mCkSeS/qGHtFCjyxohTZJE+mwtBGJ1IIojBFL5puWkU9+S5hOAubCGYQttNClfS7q",
+	"0PS+nVlf/Ru9wfOMFLd9WmfX6CLMyG8jNNuaAP4L2uiVn6NdQyqHM2SbzjphItTrVwX/dj9OXsFFMzTA",
+	"Gd2gx8QmcSMqeXmNy13HH4bfpkn4OM7DThOhWeJJqeD/jU4rOSKhNUeRoxnD+VwvFGhVLEGrbPf+PN03",
+	"MMa29w+0TiqWiOdiii4C3E9YG2mdzEBYi9ayyChFjjA1uoS8m4HltSi0whQW0s117eBGWumIFaUbw69o",
+	"cpk50NeQa7TEWLNaMM3jYCJpYS5MWaC1SbqC8hs/yTqgz0TtB08Hc82FBWeEdBb01KECi6gIv9Vc2jmB",
+	"Vkh1TeugqktCm74mmvCzJWlSq2ulFyp5v4buNFkIQ4ImxtG1rWQmdd2u7s2qHuM260llMasNXjK50LHK",
+	"6nKuraO912qZ6Ryb35nBnFS0KAhipdWldULlwuSXlTYuCuRWedCgs7eXGKF4E2Jtl/wYGr9w7awI8k2D",
+	"+F0E2qnEIiqTvdvHr72/BwbJgLLgdGyiEq0Vs90qxs/VfL2LpwLYzeeEK+kKbLaVRHDHfozBG4mLdWBe",
+	"V6jgRyOqOZToRC6cAMt8frWEbC4ciKqy7FJYnUlRgEK30ObawmJO1EzWEnOetGDnwmC+dgyDFVd+Jnp2",
+	"0nuQgtf7304mtLoRmUNjY+iVpZjhZW2K6JT8NgVxZXVRO4S5c9Vj+4QB9SscTb75+44lAmYj0/ObtJ1p",
+	"O6wrJ+hnfb/hoOybQDNDFLZM2/5np2W7ynhpovCDu8xqY2Os9IyfNyYLfQqVmGHP7qLnbKNVYraHhbWq",
+	"+Bnw2LbPMJcGM/csyq0/XVy8ISXrass8SxCaMGIM52jIGMxxKurCEQ2yXSinoEvpHOagVWt0dzL2eHKY",
+	"Hk+O0uPJ9+nx5O/vY2r/TMcO/xzZcLBrBpPwSnQMv0pcoIFSLIF4nGEn2Q/EVLUpbAqYS6f9JwwbkxLZ",
+	"DcxnORbosP0cnNZjOM1LyYqpWZKM4KWHo9tXcsOL0wNeIkkTQQOjcpmNvvUdvmlDN7StCg1ZNkDEyEi0",
+	"DSy8dAiXkMcpZqTMyPOsTZGyD+1NBbYZsK/hWOOd+K0nafhJyLpkZLWPPFLanx4xzZ5OrnFpt2/Qe3JP",
+	"hcvmLxyWZ2jrwm102tZR8U9RWCRiwg9kgqgZyw/yVQwSnRH90S7B1lnmjYV1+xwb1bWHi5wmUuUY8Sle",
+	"0GNP+l4PETvFrVXadFwuDjzbzbBvYmCGLMbAYd6gIiNWaiGFjUkc63TZ0yCPj0fHRz1RSvsVo99TOB39",
+	"K4XJ6IcUHo0epfDo8tGTMZwJleuSSIvZAhUR6ID3kzSphHNoaLX//5sY/X46+tdk9MPl6P3H4/T46PZv",
+	"93S2Cdi+s/1L7WpRFOSBZ0VtKW5EBig4VzBHs8d6GUbs7fVPRVFciew6fpgUqmrkoJdAvOTx5AgWc1lg",
+	"o5OHsayULXeQroE/bWVmXYE2kEtLMj3qzZfiw+XePhUtTwLuCrv4K0GYkh5RBIE323LrAf/mcDKGp9rZ",
+	"R+22LAjjQ4IZTU789kqHMO3glEupZEmi5TDGEUPsr5+puEYPbi9UgmM4DUCT6vPSTxNJqmIZZfMm0BYR",
+	"qOFNcyIDdMDj70bfH8HV0qF9MoZeADIS2B1seng8abIw0uFrAo/1sQ9QNEbfNtnTtw+Z5z32LxvreWtg",
+	"oa+7eXBt8bIRlzEz2tVGgXWyKHwCJXhtPlJvWwnVGBxWlAjayJlUovAYVNaRXiVSY8WuZqBwAVrhGM7Q",
+	"07Ip4Bqx8uF90kB8qqzAxvBmHbOsaIUK/+GDGSQFDAbqaAT/M6H4lEJkxg9hQZd2J8c6vuWZGNU4EXPe",
+	"LsTMEkdPpYeIbPEQEDucpIAim8PhBlk5FJN3CNQQMGaGG86tLtC2jEkoZ8+aOAILi4s5GgQ3F6oB181R",
+	"GtA2WDQ3MsMUsjlmFNiSCrTJ0bAsgKk0llx6l7E7rBVJT7Izfm2WaN8pekmHMZR8rM6iJ7JCj+l+9vNF",
+	"gwjadpB8L/zAw0kEb26DfC7kFEm604YtZiTsNiuLTu9s1xlSue++SRgmL/R+ODo6Pv7+aHL83S5JGFUk",
+	"dGBON6wXk/vMp5cUbtmqEa8w0yXaRtuQYvRKaiDmj76FWjlZsBoYw8sGRRZdpzSlbUS+j/JI14rGMLuR",
+	"s7kDsRDLoVS8RxiOsLLVrOnyDg9kN0ZFwb6GW3Q/68A74exz5cxyW1x0nURuRFFjLNG0Erbhz7aGLodM",
+	"FNEEjZJvJcliri36gzfLdCg8vAwA6YhWSagzz9PUUNbWwVzcDIdq04qdzhUM0xCRqmUkXFQ3CFuxvs9f",
+	"w/Hhd9+NDkEU1VyMjlpXNAD/yHaLFzpjQ/RqCbLqSTE9hRDLa74NmzIsXJuQZfMuWBp9C/Z09C8x+v39",
+	"xw2Wq9/vOvi/tjAGXF5LlffcsRzttdMVyRR9JTkU78gIjIfztN22gu77ee+kyimZniby9XmSJqcqN5oz",
+	"AM/mRpfID0uR8b8vpao/RFfcaP+2eqEloTXdcG/upxjKGudcabeHBdzZrxynvlrClXYWHmdGLAo0NuWQ",
+	"L9RqWhv/u9SK4X8yhos5LlftXhChRsFGXb59bfKd9nV89jYZc1m7bJtwenv28p4Jzju7Oq2H0+bHGqt6",
+	"DK8Du8spf0EBeKUVfjaPpluxyau3rNyZkFHUNnZtfNuvw1vCax+TrcW/tp3G8LxsDdv1Wd/N0c3RrDkj",
+	"HKthBdzMEndzUOVRC3Fl3pUSiuhUX9492UvH7o1uH5XcIxh77j/caO8TgfObL26t9z2rbZxzP7N5FeRa",
+	"yf/UeNkAE8tEVkZ/kCWHQlv+y9mIyly7i1apQqZL2h7JDtAKhNJMhDmZhZ0NKWZCqjF45zNoZjXVK878",
+	"0AoKFm9c5m23etuIOb0Pz61UGfYj0fcrWvCAsQG9jzR2rthcCuJtyfvAcRvXl70kU8TgvGQ5uEskrQrY",
+	"MAhUXV6hacv2xtBLHdNTW2fzvuwxeIOiwDxlY9FycpneNdnUmED6Eppuu8jvb6qLixYocgtOj+F5Wbkl",
+	"n2UVjWE0IYy1CMbn1xaf5URsWxSwTe6sVxHcTdR/vVw2qBZpN7RCRWuE2+ItesbpCjtuMH7ZgYxYwKFy",
+	"NBLO91ZShQbCR/vqjZ6vGlEavjJn84Ka0E0YHcNzzon5AU0ms0IjNZfBVgYtKpcC5jPklFQjbSphqKRh",
+	"X3gHVUcRiO9Sd9SBGFVE3mHbju2eU3cCwZdLwbtyKXhPDrQhJ+RhTiQeCXqhmnAW1zUN8b+3fKS9mxsR",
+	"4dp3MnfzRgGARSPRrpVtbXdTe0gL+Uw1A7u0Ppf2AJj5FKHjdKRUpQ0RosrviVGnq0sfU5BRQgrhBjKn",
+	"Q4yOFim1da3byNaW9aG4YQhDYudESiKAHPOHQSWBbXCKxkSlzVl4BXNt3Sa4/8H2rzcbPxecO23a59Z5",
+	"gzZmyZIIyMXS9kWV0zWFqHqRI4Lc6YINV6G0WpZcMS0rr+8tGhAzVO5ednFE7mzRPMz7TKw9Xm1F3jo6",
+	"Ovm9eqSrlNkJu7TTMszHWzRUbXcpZ++kES34xKav6g7lf42L1ItZeXudoAkDIiEp4nTMaiPd8pyIxPPV",
+	"aSV/xuVp7eYR0f/mBZdYkoEwLHMfA5VYSguavxVFL+EotWpsJzoNX8eYwlXN+U9fy8/Rzyv0ybQxhEre",
+	"QloXEi3NTI8sNECHMJk/4VD2Oaz95dNNThJf+tlU6p4kbVFoRzWCt+0r6cmros0XMsMQMg8Df3lxkYRI",
+	"XjJ3rrInBwe6QuX7PsbazA7CIHtA33blV3TU0JypgdM3L5I0uUFjPWYPx5PxhD6n2UQlk5PkmB9x6HTO",
+	"J3MgKnlwc3jApSIHXDdy8jGZoduUoLRNOY+l1KKv+zPW8Vkxr5YWi5tG9oXEoHcuU28b+/yXL3Rmkm7P",
+	"4UWenCQvpXW+KtcyoEaU6FjQ/bYKEX0KpisJ54Kg5nz+U6NZdscTRNtl+DxJe60QoUYqOZlSdmLd2r59",
+	"nw477I4mkzv1WnxKxVpXoby9enxDEdl6F0eoaeagyzeTw03rtxs+iHbA8ODj3YPXG+/6MoIPtS8dfutX",
+	"ML0nxNu6LIVZhuPuyC9pQka/NXVO5LhpG6s/ZifAdoVoF771gGRLQ5xd8TZrjjZ4apBk8A3528Ixvw/J",
+	"1c8dDskfCVr3VOfLT6CQe3S1kKjsV2nYuyf/HqDx44/uuNhqrNC8O/mo33dxB24imNqqvW6+UG6yIjwO",
+	"H6xRa9gEEeuB9R80pO/ZdrKbbSM9xH82cbHC9RF5cZtG9N/BR5nfeqrkmsoNPUjtvClYDdIN25EoiVpq",
+	"g2viwg9uxcVW9TZoIGOdRmq7p9LyNVpLt/RDriuxbzY3vDRq8k9ILzTym90j11p5701oKwSxndB8S8su",
+	"G4vqyDlS2C9vsEHC5J1P12o0X3hceA1ZcNLCjuFVU6vuNR2fTzO8V/Leus8UpdY1i9WoXfYy9ONsJVtK",
+	"2VjnJWHMEgu5oT6prnkYXoflfV+jS7DFvI7NIReKylKEp/ZhAs6MrsSnY1A2gcPg1XWw7ldGszlcUVfV",
+	"p8Dj9ANAc15f+ZcEQT/R9chCaGeKwRBebRYx6Yb03Yb5/Js7TPeCURaIPhQkosEBXzS8EDJYsXX1QqEZ",
+	"rNyisa5j7boRDGoTOp42UTkFBq6WcT9j2MbZUP/g4Vr50A5YuGpw037DuxgkNFXfx+df/HCfpaklB6z8",
+	"fRPVNhmWyMpHk16h3uFksr1C7zbd3pXDQs7pYM6P4YVr3f+u5DGcCRv4jJPBaNbhpW+e3MSEvGZyNy07",
+	"eTBrr+uCilh6b6Iq47/P4FtriYl6iStY6FQy/QzvzFAzh8fsjW13I3uzBzHKNQKhDJOkSx4KfVtZJR0X",
+	"OfYF1ZqGDZEcHwS/ryO51QMadqNEbxvhV72qV2iKYJtaj13OzeThwW0JIwKvt5cGx/1nNVp/2D1y7RKl",
+	"2zT5dnL8YCjfeB/JKw0+ft1Lvi9Er7kobfuvQubQB07yNXa+TeMcHbznFW4ONNBkhvZl4oMrqn/czcor",
+	"9jVXpoRmgsnEPxOOo0JNCpVCDb4xDHMupZ0KWVjQqumnIN2DpR2WAISw8r85z5xuKFHwMdMOJK24QjJm",
+	"jHeiwnIP34NFnu4Wm1wVKMN2gKDTm98PFsB861s/e3X5DyuTHgAjq42VDxe9fYPG06DhmW3bMbLaAvnf",
+	"oPrvLSvCxSXF9V5C46PT16j2CvoMBEaI/CgNhVYzNG3SzLIxQM6UtE4bDjRfY+XGQF1onc+nDWgDHCVg",
+	"selX913CnOtyczSW1giVQs1NFUNp8A8e5kXkVte8KfoE3nA8qtS8euDA0urdUQTxX+GlVcIO5LdC2P58",
+	"V62cjZSdbootBeIEp4d9g75sPJt7siBao7o6SdfhNYMG9wzQB1JdP7IwKDlO+W5DQZhYKaTPRDb3NnFW",
+	"SEKUz/ZS0j0Fh2WljeC+jl7NhB/TQbDSMsDThmS9RaJ6h8UShKXXg6p+vsnvp4tfXno3j/Vy724PQiJ7",
+	"hCU6AQanBu28baXs1fuGouxNTZIbigCl611E6PPSfIMQ+XKjdiafTE6b8rDI7YNg66tQg8zzvnl9ftFA",
+	"6V2PtdbMtS7ipsP5UdvX7EcSa+d10SS8M11iU5zLSa8djWo0hZsLt95xAI9DIC9tu6fTfkv1sBT+SY9q",
+	"QitTv/uh6+LuHY50fDbc4fp8da2mBoSXa9tXde2G85pOKoLlWzO4H8jfm9F86Y946rGXaTWVs9pgH/Vt",
+	"1XnoWjLrbVyDTs6uW6vZ0GpjlzcaMz1TXNTCRUZvLZrR6Yx4jL48x2z07KfR29PRm0I4IpS09+wXrnQL",
+	"TAdzqUgzXcylhXevXz26gHevz34GNzd8MaldiNkMzaiW8LhWBVoLEqbyA0j3JJLHaMtD/gCNk25sJNfT",
+	"Dcb15rKNASd+YmznvldT9uRREDNeWLXZaWJM7WxEHn0JHRq97/X+mvATnN1vDvfY6eBOXRp09O3uQWtX",
+	"qvLAH/YauPUC1BXFv5I66kTPQCXXfC1tZfSNzDEPivmxf9p64E+22QBE6BEzjCKcwsKPzy/SVqFyz53v",
+	"QDeh179pwBvDORWrB8diqHYHunajhPiJ4Pgq7NI/H5d8QWJ/MCIly25AUNuItGrCNCvhmblQM7T92wLT",
+	"3j0VaWON6s7latuO7fAqn7YBkXI1nn4LnDqoVcaL5Lt8snD91F19src87I/1yT53KdIrXED5Je/9+TL3",
+	"1XxaH+VDdT7yTScGq0JkxGVZbQwh+o53zG64goNOro5fw+HFOy3J9qbSi6/vYg4Cvy+J9rn4IXJBvxcJ",
+	"ToMXBXsEEncGNry0+CuwsRrY8HhZDWx4IXmHwEY8mn/OrrFddZjJlcu3tAT2YwuFvEYyirj+nazs/l8J",
+	"4BtVUj+h70fgBbhHwOuDhbQ4ho0XzDcdr1eFzq5hWhsaAyJYiiEYwp37G82od9LNe07K16xSPowWi8WI",
+	"UDQiI1KRGM236Zg9LgjT04Y0tnYCtjPFQ+l3zRP85c59fYbqH+mVbYsu7J1mOGhaQaJR2nd0/ZunE9+J",
+	"L6507das2q0mq0Xkbpg7mKs/omsV2duzly8IxK8kmbAHafX/Bsb/Js1770DKp5XYNFUXm+jzTtzQu+tk",
+	"a0nswBdsKybJ7OwcQ/DmBD2ct5fjh376m+aK+2kXQBkD9702rNHepdms2WUNQkD0/56/fhUaprlZj3Ma",
+	"p1mGlYM2sjjkrHD1w1eUnZs85N+Jae+f+YQ/zHPenMeDKcE/d8CF/poEXyJvcO2uizaDwSkW3/d4J36z",
+	"ze0FO9VPaMVfjal01wqk4HQFbd+qb5xqulbT9pK5kNTi19qCrQrpQpSRK4q6G3hCP/AYuLE45D84IYd5",
+	"6AGWGTmi3HThb1132LY1N6VH4a967aMmqWFxoCh713LspSnPw7XcX0MWZOs9AykY2g/mkFOTutMw17Wh",
+	"u0k40cV+8Pe+4/oKp5r7qjeUwT54TfxaC/8+wCq92ADfg9TIb7lUYQzvEK9tg2UFpVaEuDG87YrH/Ke2",
+	"K4cL3Rcb+1PbjvFombZY9qq0CRlJGh4uEK9jxdqfWfB7wt/wl+JIyLD2tX9FQu5sVvkcfofCNQm8Rd5v",
+	"hYDXCyPXOjX6reQdWQ7mj7QeoPGZahKq0jrT3OEVhvPz5Pb97f8MAGxsT/6xdAAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
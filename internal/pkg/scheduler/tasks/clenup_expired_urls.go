@@ -2,23 +2,46 @@ package tasks
 
 import (
 	"context"
+	"errors"
+	"time"
 
-	"github.com/dzhordano/urlshortener/internal/core/domain/model"
 	"github.com/dzhordano/urlshortener/internal/pkg/scheduler"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// URLEvicter evicts url cached by short url.
+type URLEvicter interface {
+	Delete(ctx context.Context, shortURL string) error
+}
+
+// VisitorForgetter forgets visitors counted for short url.
+type VisitorForgetter interface {
+	Delete(ctx context.Context, shortURL string) error
+}
+
 type CleanupExpiredURLsTask struct {
-	db *pgxpool.Pool
+	db        *pgxpool.Pool
+	cache     URLEvicter
+	visitors  VisitorForgetter
+	retention time.Duration
 }
 
 // NewCleanupExpiredURLsTask returns cleanup task for expired urls.
-// Deletes all non-valid entries (expired after model.ShortURLValidFor duration).
+// Deletes all entries whose valid_until has passed more than retention ago, so expired urls still redirect to
+// their fallback url and are listed as expired meanwhile. Never expiring urls (NULL valid_until) are kept.
+// Deleted urls are evicted from cache, so they aren't redirected until cache expires, and their visitors are forgotten.
 func NewCleanupExpiredURLsTask(
 	db *pgxpool.Pool,
+	cache URLEvicter,
+	visitors VisitorForgetter,
+	retention time.Duration,
 ) scheduler.Task {
 	return &CleanupExpiredURLsTask{
-		db: db,
+		db:        db,
+		cache:     cache,
+		visitors:  visitors,
+		retention: retention,
 	}
 }
 
@@ -30,12 +53,24 @@ func (t *CleanupExpiredURLsTask) Execute(ctx context.Context) error {
 	query := `
 	DELETE FROM urls
 	WHERE valid_until < NOW() - make_interval(secs => $1)
+	RETURNING short_url
 	`
 
-	_, err := t.db.Exec(ctx, query, model.ShortURLValidFor.Seconds())
+	rows, err := t.db.Query(ctx, query, t.retention.Seconds())
+	if err != nil {
+		return err
+	}
+
+	shortURLs, err := pgx.CollectRows(rows, pgx.RowTo[string])
 	if err != nil {
 		return err
 	}
 
-	return nil
+	// Urls are gone already, so all of them are tried to be evicted and their visitors forgotten.
+	var errs []error
+	for _, shortURL := range shortURLs {
+		errs = append(errs, t.cache.Delete(ctx, shortURL), t.visitors.Delete(ctx, shortURL))
+	}
+
+	return errors.Join(errs...)
 }
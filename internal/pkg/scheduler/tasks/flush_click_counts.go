@@ -0,0 +1,33 @@
+package tasks
+
+import (
+	"context"
+
+	"github.com/dzhordano/urlshortener/internal/pkg/scheduler"
+)
+
+// ClickFlusher saves clicks counted in memory.
+type ClickFlusher interface {
+	Flush(ctx context.Context) error
+}
+
+type FlushClickCountsTask struct {
+	flusher ClickFlusher
+}
+
+// NewFlushClickCountsTask returns task saving clicks counted on redirects to urls.
+func NewFlushClickCountsTask(
+	flusher ClickFlusher,
+) scheduler.Task {
+	return &FlushClickCountsTask{
+		flusher: flusher,
+	}
+}
+
+func (t *FlushClickCountsTask) Name() string {
+	return "flush_click_counts"
+}
+
+func (t *FlushClickCountsTask) Execute(ctx context.Context) error {
+	return t.flusher.Flush(ctx)
+}
@@ -0,0 +1,163 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dzhordano/urlshortener/internal/pkg/scheduler"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// rollupBatchSize bounds amount of click events rolled up by single run, so it doesn't hold transaction for long.
+	rollupBatchSize = 100000
+	// visitorHashesKeptDays is amount of days before the current one (UTC) visitor hashes are kept for,
+	// so late events of the previous day still count visitors once.
+	visitorHashesKeptDays = 1
+)
+
+type RollupClickEventsTask struct {
+	db *pgxpool.Pool
+}
+
+// NewRollupClickEventsTask returns task aggregating click events recorded since its previous run into rollup tables
+// stats are read from. Progress is kept in click_rollups_state, so every event is rolled up once.
+// Bots' events and events of urls which are gone are skipped.
+//
+// Visitors are hashed with random salt of their day. Once the day is over for visitorHashesKeptDays,
+// its hashes are replaced by visitors count and its salt is removed.
+func NewRollupClickEventsTask(
+	db *pgxpool.Pool,
+) scheduler.Task {
+	return &RollupClickEventsTask{
+		db: db,
+	}
+}
+
+func (t *RollupClickEventsTask) Name() string {
+	return "rollup_click_events"
+}
+
+// Execute rolls up events inserted by transactions from the last rolled up one up to xmin of current snapshot.
+// Transactions below xmin are all finished, so no events below it appear later, however concurrently inserting
+// transactions commit. Xids are passed as text, since pgx doesn't know xid8.
+func (t *RollupClickEventsTask) Execute(ctx context.Context) error {
+	return pgx.BeginFunc(ctx, t.db, func(tx pgx.Tx) error {
+		// Locking state row keeps concurrent runs (e.g. of other app instances) from rolling up same events.
+		var lastXID string
+		err := tx.QueryRow(ctx, `SELECT last_xid::text FROM click_rollups_state FOR UPDATE`).Scan(&lastXID)
+		if err != nil {
+			return fmt.Errorf("error getting rollup state: %w", err)
+		}
+
+		if err = t.countOldVisitors(ctx, tx); err != nil {
+			return err
+		}
+
+		// Batch ends at transaction of rollupBatchSize-th event, or at xmin if there are fewer events.
+		var (
+			upperXID string
+			empty    bool
+		)
+		err = tx.QueryRow(ctx, `
+		SELECT upper::text, NOT EXISTS (SELECT 1 FROM click_events WHERE xid >= $1::xid8 AND xid < upper)
+		FROM (
+			SELECT COALESCE(
+				(SELECT xid FROM click_events WHERE xid > $1::xid8 AND xid < s.xmin ORDER BY xid OFFSET $2 LIMIT 1),
+				s.xmin
+			) AS upper
+			FROM (SELECT pg_snapshot_xmin(pg_current_snapshot()) AS xmin) AS s
+		) AS batch`,
+			lastXID, rollupBatchSize,
+		).Scan(&upperXID, &empty)
+		if err != nil {
+			return fmt.Errorf("error getting click events batch: %w", err)
+		}
+
+		if empty {
+			return nil
+		}
+
+		queries := []string{
+			`
+			INSERT INTO click_rollups_hourly (url_id, bucket_start, clicks)
+			SELECT e.url_id, date_trunc('hour', e.occurred_at, 'UTC'), COUNT(*)
+			FROM click_events e
+			JOIN urls u ON u.id = e.url_id
+			WHERE e.xid >= $1::xid8 AND e.xid < $2::xid8
+			GROUP BY 1, 2
+			ON CONFLICT (url_id, bucket_start) DO UPDATE
+			SET clicks = click_rollups_hourly.clicks + EXCLUDED.clicks`,
+			`
+			INSERT INTO click_rollups_dimensions (url_id, bucket_start, dimension, value, clicks)
+			SELECT e.url_id, date_trunc('hour', e.occurred_at, 'UTC'), d.dimension, d.value, COUNT(*)
+			FROM click_events e
+			JOIN urls u ON u.id = e.url_id
+			CROSS JOIN LATERAL (VALUES
+				('referrer', e.referrer_host),
+				('country', e.country),
+				('device', e.device),
+				('browser', e.browser),
+				('os', e.os)
+			) AS d(dimension, value)
+			WHERE e.xid >= $1::xid8 AND e.xid < $2::xid8 AND d.value <> ''
+			GROUP BY 1, 2, 3, 4
+			ON CONFLICT (url_id, bucket_start, dimension, value) DO UPDATE
+			SET clicks = click_rollups_dimensions.clicks + EXCLUDED.clicks`,
+			`
+			INSERT INTO click_rollups_salts (day, salt)
+			SELECT d.day, gen_random_uuid()::text
+			FROM (
+				SELECT DISTINCT (occurred_at AT TIME ZONE 'UTC')::date AS day
+				FROM click_events
+				WHERE xid >= $1::xid8 AND xid < $2::xid8
+			) AS d
+			ON CONFLICT (day) DO NOTHING`,
+			`
+			INSERT INTO click_rollups_visitors (url_id, day, visitor)
+			SELECT DISTINCT e.url_id, s.day, encode(sha256(convert_to(
+				s.salt || '|' || e.ip_prefix || '|' || e.user_agent, 'UTF8'
+			)), 'hex')
+			FROM click_events e
+			JOIN urls u ON u.id = e.url_id
+			JOIN click_rollups_salts s ON s.day = (e.occurred_at AT TIME ZONE 'UTC')::date
+			WHERE e.xid >= $1::xid8 AND e.xid < $2::xid8
+			ON CONFLICT DO NOTHING`,
+			`UPDATE click_rollups_state SET last_xid = $2::xid8 WHERE last_xid = $1::xid8`,
+		}
+
+		for _, query := range queries {
+			if _, err = tx.Exec(ctx, query, lastXID, upperXID); err != nil {
+				return fmt.Errorf("error rolling up click events: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// countOldVisitors replaces visitor hashes of days older than visitorHashesKeptDays by their counts and removes
+// salts of those days.
+func (t *RollupClickEventsTask) countOldVisitors(ctx context.Context, tx pgx.Tx) error {
+	queries := []string{
+		`
+		INSERT INTO click_rollups_visitor_counts (url_id, day, visitors)
+		SELECT url_id, day, COUNT(*)
+		FROM click_rollups_visitors
+		WHERE day < (NOW() AT TIME ZONE 'UTC')::date - $1::int
+		GROUP BY 1, 2
+		ON CONFLICT (url_id, day) DO UPDATE
+		SET visitors = click_rollups_visitor_counts.visitors + EXCLUDED.visitors`,
+		`DELETE FROM click_rollups_visitors WHERE day < (NOW() AT TIME ZONE 'UTC')::date - $1::int`,
+		`DELETE FROM click_rollups_salts WHERE day < (NOW() AT TIME ZONE 'UTC')::date - $1::int`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.Exec(ctx, query, visitorHashesKeptDays); err != nil {
+			return fmt.Errorf("error counting old visitors: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Used by 'sequence' token generation strategy.
+CREATE SEQUENCE IF NOT EXISTS short_url_seq START WITH 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP SEQUENCE IF EXISTS short_url_seq;
+-- +goose StatementEnd
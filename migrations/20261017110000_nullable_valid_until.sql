@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- NULL valid_until means url never expires.
+ALTER TABLE urls ALTER COLUMN valid_until DROP NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DELETE FROM urls WHERE valid_until IS NULL;
+ALTER TABLE urls ALTER COLUMN valid_until SET NOT NULL;
+-- +goose StatementEnd
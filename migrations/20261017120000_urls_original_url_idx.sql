@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Backs lookup of existing shortened url when reusing links.
+CREATE INDEX IF NOT EXISTS urls_original_url_idx ON urls (original_url);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS urls_original_url_idx;
+-- +goose StatementEnd
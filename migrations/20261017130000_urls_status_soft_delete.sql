@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Disabled urls are kept but don't redirect.
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'active';
+-- Deleted urls are kept so analytics history survives.
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE urls DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE urls DROP COLUMN IF EXISTS status;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+-- Keyset pagination indexes for links listing.
+CREATE INDEX IF NOT EXISTS urls_created_at_id_idx ON urls (created_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS urls_clicks_id_idx ON urls (clicks, id) WHERE deleted_at IS NULL;
+-- Filtering by tag.
+CREATE INDEX IF NOT EXISTS urls_tags_idx ON urls USING GIN (tags);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS urls_tags_idx;
+DROP INDEX IF EXISTS urls_clicks_id_idx;
+DROP INDEX IF EXISTS urls_created_at_id_idx;
+ALTER TABLE urls DROP COLUMN IF EXISTS tags;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Api key url was created with. Null for anonymous urls.
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS owner_id UUID;
+
+-- Listing owner's urls.
+CREATE INDEX IF NOT EXISTS urls_owner_id_created_at_id_idx ON urls (owner_id, created_at, id) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS urls_owner_id_created_at_id_idx;
+ALTER TABLE urls DROP COLUMN IF EXISTS owner_id;
+-- +goose StatementEnd
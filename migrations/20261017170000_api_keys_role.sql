@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Role granting set of scopes. Keys created before roles could create and manage own urls, so they are editors.
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'editor';
+
+-- Admin scope used to grant managing api keys too, admin role keeps it that way.
+UPDATE api_keys SET role = 'admin' WHERE 'admin' = ANY(scopes);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+UPDATE api_keys SET scopes = array_append(scopes, 'admin') WHERE role = 'admin' AND NOT 'admin' = ANY(scopes);
+ALTER TABLE api_keys DROP COLUMN IF EXISTS role;
+-- +goose StatementEnd
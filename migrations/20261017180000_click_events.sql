@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS click_events (
+    id BIGSERIAL PRIMARY KEY,
+    short_url TEXT NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    -- Empty if referrer wasn't sent.
+    referrer_host TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    -- Visitor ip truncated to /24 (ipv4) or /48 (ipv6), full ips are never stored.
+    ip_prefix TEXT NOT NULL DEFAULT '',
+    accept_language TEXT NOT NULL DEFAULT '',
+    is_bot BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+-- Url's clicks over time.
+CREATE INDEX IF NOT EXISTS click_events_short_url_occurred_at_idx ON click_events (short_url, occurred_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS click_events;
+-- +goose StatementEnd
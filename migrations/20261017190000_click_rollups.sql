@@ -0,0 +1,57 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE click_events
+    ADD COLUMN IF NOT EXISTS device TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS browser TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS os TEXT NOT NULL DEFAULT '',
+    -- ISO 3166-1 alpha-2 code. Empty if unknown.
+    ADD COLUMN IF NOT EXISTS country TEXT NOT NULL DEFAULT '';
+
+-- Url's clicks per hour.
+CREATE TABLE IF NOT EXISTS click_rollups_hourly (
+    short_url TEXT NOT NULL,
+    bucket_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    clicks BIGINT NOT NULL,
+    PRIMARY KEY (short_url, bucket_start)
+);
+
+-- Url's clicks per hour split by referrer, country, device, browser and os.
+CREATE TABLE IF NOT EXISTS click_rollups_dimensions (
+    short_url TEXT NOT NULL,
+    bucket_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    dimension TEXT NOT NULL,
+    value TEXT NOT NULL,
+    clicks BIGINT NOT NULL,
+    PRIMARY KEY (short_url, bucket_start, dimension, value)
+);
+
+-- Distinct url's visitors per day. Visitor is hash of ip prefix and user agent.
+CREATE TABLE IF NOT EXISTS click_rollups_visitors (
+    short_url TEXT NOT NULL,
+    day DATE NOT NULL,
+    visitor TEXT NOT NULL,
+    PRIMARY KEY (short_url, day, visitor)
+);
+
+-- Single row holding id of the last click event rolled up.
+CREATE TABLE IF NOT EXISTS click_rollups_state (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    last_event_id BIGINT NOT NULL
+);
+INSERT INTO click_rollups_state (last_event_id) VALUES (0) ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS click_rollups_state;
+DROP TABLE IF EXISTS click_rollups_visitors;
+DROP TABLE IF EXISTS click_rollups_dimensions;
+DROP TABLE IF EXISTS click_rollups_hourly;
+ALTER TABLE click_events
+    DROP COLUMN IF EXISTS device,
+    DROP COLUMN IF EXISTS browser,
+    DROP COLUMN IF EXISTS os,
+    DROP COLUMN IF EXISTS country;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Redirects made by bots, they're not counted as clicks.
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS bot_clicks INTEGER NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE urls DROP COLUMN IF EXISTS bot_clicks;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Open Graph metadata shown to crawlers. Empty preview_title means url has no custom preview.
+ALTER TABLE urls
+    ADD COLUMN IF NOT EXISTS preview_title TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS preview_description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS preview_image_url TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE urls
+    DROP COLUMN IF EXISTS preview_title,
+    DROP COLUMN IF EXISTS preview_description,
+    DROP COLUMN IF EXISTS preview_image_url;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Existing urls keep redirecting with 301 they always did.
+ALTER TABLE urls
+    ADD COLUMN IF NOT EXISTS redirect_code SMALLINT NOT NULL DEFAULT 301
+        CHECK (redirect_code IN (301, 302, 307, 308));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE urls DROP COLUMN IF EXISTS redirect_code;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Null means url may be clicked any number of times.
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS max_clicks INTEGER CHECK (max_clicks > 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE urls DROP COLUMN IF EXISTS max_clicks;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- NULL valid_from means url is active since creation. Empty fallback_url means there is none.
+ALTER TABLE urls
+    ADD COLUMN IF NOT EXISTS valid_from TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS fallback_url TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE urls
+    DROP COLUMN IF EXISTS valid_from,
+    DROP COLUMN IF EXISTS fallback_url;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Rules redirecting visitors whose user agent matches them to target_url instead of url's original_url.
+-- Rules of url are checked in order of position, the first matching one wins.
+CREATE TABLE IF NOT EXISTS url_targeting_rules (
+    url_id UUID NOT NULL REFERENCES urls (id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    -- Os name as recognized from user agent, empty matches any os.
+    os TEXT NOT NULL DEFAULT '',
+    -- Device kind as recognized from user agent, empty matches any device.
+    device TEXT NOT NULL DEFAULT '',
+    target_url TEXT NOT NULL,
+    PRIMARY KEY (url_id, position)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS url_targeting_rules;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- ISO 3166-1 alpha-2 code of visitor's country, empty matches any country.
+ALTER TABLE url_targeting_rules ADD COLUMN IF NOT EXISTS country TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE url_targeting_rules DROP COLUMN IF EXISTS country;
+-- +goose StatementEnd
@@ -0,0 +1,96 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Events are attributed to url by id, so url taking short url of purged one doesn't inherit its stats.
+ALTER TABLE click_events ADD COLUMN IF NOT EXISTS url_id UUID;
+-- Events recorded before are attributed to url holding their short url, unless they predate it.
+UPDATE click_events e
+SET url_id = u.id
+FROM urls u
+WHERE u.short_url = e.short_url AND u.created_at <= e.occurred_at;
+DROP INDEX IF EXISTS click_events_short_url_occurred_at_idx;
+CREATE INDEX IF NOT EXISTS click_events_url_id_occurred_at_idx ON click_events (url_id, occurred_at);
+
+-- Transaction event was inserted in. Rollups progress by it, since ids are taken before commit
+-- and concurrently inserted events become visible out of id order.
+ALTER TABLE click_events ADD COLUMN IF NOT EXISTS xid XID8 NOT NULL DEFAULT pg_current_xact_id();
+CREATE INDEX IF NOT EXISTS click_events_xid_idx ON click_events (xid);
+
+-- Rollups are derived from events, so they're rebuilt keyed by url id. They're removed along with url.
+DROP TABLE IF EXISTS click_rollups_state, click_rollups_visitors, click_rollups_dimensions, click_rollups_hourly;
+
+-- Url's clicks per hour.
+CREATE TABLE click_rollups_hourly (
+    url_id UUID NOT NULL REFERENCES urls (id) ON DELETE CASCADE,
+    bucket_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    clicks BIGINT NOT NULL,
+    PRIMARY KEY (url_id, bucket_start)
+);
+
+-- Url's clicks per hour split by referrer, country, device, browser and os.
+CREATE TABLE click_rollups_dimensions (
+    url_id UUID NOT NULL REFERENCES urls (id) ON DELETE CASCADE,
+    bucket_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    dimension TEXT NOT NULL,
+    value TEXT NOT NULL,
+    clicks BIGINT NOT NULL,
+    PRIMARY KEY (url_id, bucket_start, dimension, value)
+);
+
+-- Distinct url's visitors per day. Visitor is hash of ip prefix and user agent.
+CREATE TABLE click_rollups_visitors (
+    url_id UUID NOT NULL REFERENCES urls (id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    visitor TEXT NOT NULL,
+    PRIMARY KEY (url_id, day, visitor)
+);
+
+-- Single row holding transaction id events below which are rolled up.
+CREATE TABLE click_rollups_state (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    last_xid XID8 NOT NULL
+);
+INSERT INTO click_rollups_state (last_xid) VALUES ('0');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS click_rollups_state, click_rollups_visitors, click_rollups_dimensions, click_rollups_hourly;
+
+CREATE TABLE click_rollups_hourly (
+    short_url TEXT NOT NULL,
+    bucket_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    clicks BIGINT NOT NULL,
+    PRIMARY KEY (short_url, bucket_start)
+);
+
+CREATE TABLE click_rollups_dimensions (
+    short_url TEXT NOT NULL,
+    bucket_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    dimension TEXT NOT NULL,
+    value TEXT NOT NULL,
+    clicks BIGINT NOT NULL,
+    PRIMARY KEY (short_url, bucket_start, dimension, value)
+);
+
+CREATE TABLE click_rollups_visitors (
+    short_url TEXT NOT NULL,
+    day DATE NOT NULL,
+    visitor TEXT NOT NULL,
+    PRIMARY KEY (short_url, day, visitor)
+);
+
+-- Events are rolled up again from the start.
+CREATE TABLE click_rollups_state (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    last_event_id BIGINT NOT NULL
+);
+INSERT INTO click_rollups_state (last_event_id) VALUES (0);
+
+DROP INDEX IF EXISTS click_events_xid_idx;
+ALTER TABLE click_events DROP COLUMN IF EXISTS xid;
+DROP INDEX IF EXISTS click_events_url_id_occurred_at_idx;
+CREATE INDEX IF NOT EXISTS click_events_short_url_occurred_at_idx ON click_events (short_url, occurred_at);
+ALTER TABLE click_events DROP COLUMN IF EXISTS url_id;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Random salt visitors of the day are hashed with. Salt is removed once the day's visitors are counted,
+-- so hashes can't be traced back to visitors afterward.
+CREATE TABLE IF NOT EXISTS click_rollups_salts (
+    day DATE PRIMARY KEY,
+    salt TEXT NOT NULL
+);
+
+-- Url's distinct visitors per day, counted once the day's visitor hashes are removed.
+CREATE TABLE IF NOT EXISTS click_rollups_visitor_counts (
+    url_id UUID NOT NULL REFERENCES urls (id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    visitors BIGINT NOT NULL,
+    PRIMARY KEY (url_id, day)
+);
+
+-- Visitors hashed without salt are stable ids, so they're dropped. They're counted first to keep stats.
+INSERT INTO click_rollups_visitor_counts (url_id, day, visitors)
+SELECT url_id, day, COUNT(*)
+FROM click_rollups_visitors
+GROUP BY 1, 2;
+TRUNCATE click_rollups_visitors;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS click_rollups_visitor_counts, click_rollups_salts;
+-- +goose StatementEnd
@@ -39,22 +39,22 @@ func (_m *ShortenURLCommandHandlerMock) EXPECT() *ShortenURLCommandHandlerMock_E
 }
 
 // Handle provides a mock function for the type ShortenURLCommandHandlerMock
-func (_mock *ShortenURLCommandHandlerMock) Handle(context1 context.Context, shortenURLCommand commands.ShortenURLCommand) (string, error) {
+func (_mock *ShortenURLCommandHandlerMock) Handle(context1 context.Context, shortenURLCommand commands.ShortenURLCommand) (commands.ShortenURLResult, error) {
 	ret := _mock.Called(context1, shortenURLCommand)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Handle")
 	}
 
-	var r0 string
+	var r0 commands.ShortenURLResult
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, commands.ShortenURLCommand) (string, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, commands.ShortenURLCommand) (commands.ShortenURLResult, error)); ok {
 		return returnFunc(context1, shortenURLCommand)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, commands.ShortenURLCommand) string); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, commands.ShortenURLCommand) commands.ShortenURLResult); ok {
 		r0 = returnFunc(context1, shortenURLCommand)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(commands.ShortenURLResult)
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, commands.ShortenURLCommand) error); ok {
 		r1 = returnFunc(context1, shortenURLCommand)
@@ -94,12 +94,12 @@ func (_c *ShortenURLCommandHandlerMock_Handle_Call) Run(run func(context1 contex
 	return _c
 }
 
-func (_c *ShortenURLCommandHandlerMock_Handle_Call) Return(s string, err error) *ShortenURLCommandHandlerMock_Handle_Call {
-	_c.Call.Return(s, err)
+func (_c *ShortenURLCommandHandlerMock_Handle_Call) Return(shortenURLResult commands.ShortenURLResult, err error) *ShortenURLCommandHandlerMock_Handle_Call {
+	_c.Call.Return(shortenURLResult, err)
 	return _c
 }
 
-func (_c *ShortenURLCommandHandlerMock_Handle_Call) RunAndReturn(run func(context1 context.Context, shortenURLCommand commands.ShortenURLCommand) (string, error)) *ShortenURLCommandHandlerMock_Handle_Call {
+func (_c *ShortenURLCommandHandlerMock_Handle_Call) RunAndReturn(run func(context1 context.Context, shortenURLCommand commands.ShortenURLCommand) (commands.ShortenURLResult, error)) *ShortenURLCommandHandlerMock_Handle_Call {
 	_c.Call.Return(run)
 	return _c
 }
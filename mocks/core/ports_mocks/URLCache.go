@@ -7,6 +7,7 @@ package ports_mocks
 import (
 	"context"
 
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -37,23 +38,82 @@ func (_m *URLCacheMock) EXPECT() *URLCacheMock_Expecter {
 	return &URLCacheMock_Expecter{mock: &_m.Mock}
 }
 
+// Delete provides a mock function for the type URLCacheMock
+func (_mock *URLCacheMock) Delete(ctx context.Context, key string) error {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// URLCacheMock_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type URLCacheMock_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *URLCacheMock_Expecter) Delete(ctx interface{}, key interface{}) *URLCacheMock_Delete_Call {
+	return &URLCacheMock_Delete_Call{Call: _e.mock.On("Delete", ctx, key)}
+}
+
+func (_c *URLCacheMock_Delete_Call) Run(run func(ctx context.Context, key string)) *URLCacheMock_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *URLCacheMock_Delete_Call) Return(err error) *URLCacheMock_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *URLCacheMock_Delete_Call) RunAndReturn(run func(ctx context.Context, key string) error) *URLCacheMock_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Get provides a mock function for the type URLCacheMock
-func (_mock *URLCacheMock) Get(ctx context.Context, key string) (string, error) {
+func (_mock *URLCacheMock) Get(ctx context.Context, key string) (*model.ShortenedURL, error) {
 	ret := _mock.Called(ctx, key)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Get")
 	}
 
-	var r0 string
+	var r0 *model.ShortenedURL
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.ShortenedURL, error)); ok {
 		return returnFunc(ctx, key)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.ShortenedURL); ok {
 		r0 = returnFunc(ctx, key)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShortenedURL)
+		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
 		r1 = returnFunc(ctx, key)
@@ -93,27 +153,27 @@ func (_c *URLCacheMock_Get_Call) Run(run func(ctx context.Context, key string))
 	return _c
 }
 
-func (_c *URLCacheMock_Get_Call) Return(s string, err error) *URLCacheMock_Get_Call {
-	_c.Call.Return(s, err)
+func (_c *URLCacheMock_Get_Call) Return(shortenedURL *model.ShortenedURL, err error) *URLCacheMock_Get_Call {
+	_c.Call.Return(shortenedURL, err)
 	return _c
 }
 
-func (_c *URLCacheMock_Get_Call) RunAndReturn(run func(ctx context.Context, key string) (string, error)) *URLCacheMock_Get_Call {
+func (_c *URLCacheMock_Get_Call) RunAndReturn(run func(ctx context.Context, key string) (*model.ShortenedURL, error)) *URLCacheMock_Get_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Set provides a mock function for the type URLCacheMock
-func (_mock *URLCacheMock) Set(ctx context.Context, key string, value string) error {
-	ret := _mock.Called(ctx, key, value)
+func (_mock *URLCacheMock) Set(ctx context.Context, key string, url *model.ShortenedURL) error {
+	ret := _mock.Called(ctx, key, url)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Set")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, key, value)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *model.ShortenedURL) error); ok {
+		r0 = returnFunc(ctx, key, url)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -128,12 +188,12 @@ type URLCacheMock_Set_Call struct {
 // Set is a helper method to define mock.On call
 //   - ctx context.Context
 //   - key string
-//   - value string
-func (_e *URLCacheMock_Expecter) Set(ctx interface{}, key interface{}, value interface{}) *URLCacheMock_Set_Call {
-	return &URLCacheMock_Set_Call{Call: _e.mock.On("Set", ctx, key, value)}
+//   - url *model.ShortenedURL
+func (_e *URLCacheMock_Expecter) Set(ctx interface{}, key interface{}, url interface{}) *URLCacheMock_Set_Call {
+	return &URLCacheMock_Set_Call{Call: _e.mock.On("Set", ctx, key, url)}
 }
 
-func (_c *URLCacheMock_Set_Call) Run(run func(ctx context.Context, key string, value string)) *URLCacheMock_Set_Call {
+func (_c *URLCacheMock_Set_Call) Run(run func(ctx context.Context, key string, url *model.ShortenedURL)) *URLCacheMock_Set_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -143,9 +203,9 @@ func (_c *URLCacheMock_Set_Call) Run(run func(ctx context.Context, key string, v
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 *model.ShortenedURL
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(*model.ShortenedURL)
 		}
 		run(
 			arg0,
@@ -161,7 +221,64 @@ func (_c *URLCacheMock_Set_Call) Return(err error) *URLCacheMock_Set_Call {
 	return _c
 }
 
-func (_c *URLCacheMock_Set_Call) RunAndReturn(run func(ctx context.Context, key string, value string) error) *URLCacheMock_Set_Call {
+func (_c *URLCacheMock_Set_Call) RunAndReturn(run func(ctx context.Context, key string, url *model.ShortenedURL) error) *URLCacheMock_Set_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMany provides a mock function for the type URLCacheMock
+func (_mock *URLCacheMock) SetMany(ctx context.Context, urls []*model.ShortenedURL) error {
+	ret := _mock.Called(ctx, urls)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMany")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*model.ShortenedURL) error); ok {
+		r0 = returnFunc(ctx, urls)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// URLCacheMock_SetMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMany'
+type URLCacheMock_SetMany_Call struct {
+	*mock.Call
+}
+
+// SetMany is a helper method to define mock.On call
+//   - ctx context.Context
+//   - urls []*model.ShortenedURL
+func (_e *URLCacheMock_Expecter) SetMany(ctx interface{}, urls interface{}) *URLCacheMock_SetMany_Call {
+	return &URLCacheMock_SetMany_Call{Call: _e.mock.On("SetMany", ctx, urls)}
+}
+
+func (_c *URLCacheMock_SetMany_Call) Run(run func(ctx context.Context, urls []*model.ShortenedURL)) *URLCacheMock_SetMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*model.ShortenedURL
+		if args[1] != nil {
+			arg1 = args[1].([]*model.ShortenedURL)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *URLCacheMock_SetMany_Call) Return(err error) *URLCacheMock_SetMany_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *URLCacheMock_SetMany_Call) RunAndReturn(run func(ctx context.Context, urls []*model.ShortenedURL) error) *URLCacheMock_SetMany_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -8,6 +8,7 @@ import (
 	"context"
 
 	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -38,9 +39,66 @@ func (_m *URLRepositoryMock) EXPECT() *URLRepositoryMock_Expecter {
 	return &URLRepositoryMock_Expecter{mock: &_m.Mock}
 }
 
+// Delete provides a mock function for the type URLRepositoryMock
+func (_mock *URLRepositoryMock) Delete(ctx context.Context, shortenedURL string) error {
+	ret := _mock.Called(ctx, shortenedURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, shortenedURL)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// URLRepositoryMock_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type URLRepositoryMock_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortenedURL string
+func (_e *URLRepositoryMock_Expecter) Delete(ctx interface{}, shortenedURL interface{}) *URLRepositoryMock_Delete_Call {
+	return &URLRepositoryMock_Delete_Call{Call: _e.mock.On("Delete", ctx, shortenedURL)}
+}
+
+func (_c *URLRepositoryMock_Delete_Call) Run(run func(ctx context.Context, shortenedURL string)) *URLRepositoryMock_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *URLRepositoryMock_Delete_Call) Return(err error) *URLRepositoryMock_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *URLRepositoryMock_Delete_Call) RunAndReturn(run func(ctx context.Context, shortenedURL string) error) *URLRepositoryMock_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByOriginalURL provides a mock function for the type URLRepositoryMock
-func (_mock *URLRepositoryMock) GetByOriginalURL(ctx context.Context, originalURL string) (*model.ShortenedURL, error) {
-	ret := _mock.Called(ctx, originalURL)
+func (_mock *URLRepositoryMock) GetByOriginalURL(ctx context.Context, originalURL string, ownerID *uuid.UUID) (*model.ShortenedURL, error) {
+	ret := _mock.Called(ctx, originalURL, ownerID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByOriginalURL")
@@ -48,18 +106,18 @@ func (_mock *URLRepositoryMock) GetByOriginalURL(ctx context.Context, originalUR
 
 	var r0 *model.ShortenedURL
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.ShortenedURL, error)); ok {
-		return returnFunc(ctx, originalURL)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *uuid.UUID) (*model.ShortenedURL, error)); ok {
+		return returnFunc(ctx, originalURL, ownerID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.ShortenedURL); ok {
-		r0 = returnFunc(ctx, originalURL)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *uuid.UUID) *model.ShortenedURL); ok {
+		r0 = returnFunc(ctx, originalURL, ownerID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.ShortenedURL)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, originalURL)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, originalURL, ownerID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -74,11 +132,12 @@ type URLRepositoryMock_GetByOriginalURL_Call struct {
 // GetByOriginalURL is a helper method to define mock.On call
 //   - ctx context.Context
 //   - originalURL string
-func (_e *URLRepositoryMock_Expecter) GetByOriginalURL(ctx interface{}, originalURL interface{}) *URLRepositoryMock_GetByOriginalURL_Call {
-	return &URLRepositoryMock_GetByOriginalURL_Call{Call: _e.mock.On("GetByOriginalURL", ctx, originalURL)}
+//   - ownerID *uuid.UUID
+func (_e *URLRepositoryMock_Expecter) GetByOriginalURL(ctx interface{}, originalURL interface{}, ownerID interface{}) *URLRepositoryMock_GetByOriginalURL_Call {
+	return &URLRepositoryMock_GetByOriginalURL_Call{Call: _e.mock.On("GetByOriginalURL", ctx, originalURL, ownerID)}
 }
 
-func (_c *URLRepositoryMock_GetByOriginalURL_Call) Run(run func(ctx context.Context, originalURL string)) *URLRepositoryMock_GetByOriginalURL_Call {
+func (_c *URLRepositoryMock_GetByOriginalURL_Call) Run(run func(ctx context.Context, originalURL string, ownerID *uuid.UUID)) *URLRepositoryMock_GetByOriginalURL_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -88,9 +147,14 @@ func (_c *URLRepositoryMock_GetByOriginalURL_Call) Run(run func(ctx context.Cont
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 *uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(*uuid.UUID)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -101,7 +165,7 @@ func (_c *URLRepositoryMock_GetByOriginalURL_Call) Return(shortenedURL *model.Sh
 	return _c
 }
 
-func (_c *URLRepositoryMock_GetByOriginalURL_Call) RunAndReturn(run func(ctx context.Context, originalURL string) (*model.ShortenedURL, error)) *URLRepositoryMock_GetByOriginalURL_Call {
+func (_c *URLRepositoryMock_GetByOriginalURL_Call) RunAndReturn(run func(ctx context.Context, originalURL string, ownerID *uuid.UUID) (*model.ShortenedURL, error)) *URLRepositoryMock_GetByOriginalURL_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -230,3 +294,128 @@ func (_c *URLRepositoryMock_Save_Call) RunAndReturn(run func(ctx context.Context
 	_c.Call.Return(run)
 	return _c
 }
+
+// SaveBatch provides a mock function for the type URLRepositoryMock
+func (_mock *URLRepositoryMock) SaveBatch(ctx context.Context, urls []*model.ShortenedURL) ([]error, error) {
+	ret := _mock.Called(ctx, urls)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveBatch")
+	}
+
+	var r0 []error
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*model.ShortenedURL) ([]error, error)); ok {
+		return returnFunc(ctx, urls)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*model.ShortenedURL) []error); ok {
+		r0 = returnFunc(ctx, urls)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]error)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []*model.ShortenedURL) error); ok {
+		r1 = returnFunc(ctx, urls)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// URLRepositoryMock_SaveBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveBatch'
+type URLRepositoryMock_SaveBatch_Call struct {
+	*mock.Call
+}
+
+// SaveBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - urls []*model.ShortenedURL
+func (_e *URLRepositoryMock_Expecter) SaveBatch(ctx interface{}, urls interface{}) *URLRepositoryMock_SaveBatch_Call {
+	return &URLRepositoryMock_SaveBatch_Call{Call: _e.mock.On("SaveBatch", ctx, urls)}
+}
+
+func (_c *URLRepositoryMock_SaveBatch_Call) Run(run func(ctx context.Context, urls []*model.ShortenedURL)) *URLRepositoryMock_SaveBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*model.ShortenedURL
+		if args[1] != nil {
+			arg1 = args[1].([]*model.ShortenedURL)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *URLRepositoryMock_SaveBatch_Call) Return(errors []error, err error) *URLRepositoryMock_SaveBatch_Call {
+	_c.Call.Return(errors, err)
+	return _c
+}
+
+func (_c *URLRepositoryMock_SaveBatch_Call) RunAndReturn(run func(ctx context.Context, urls []*model.ShortenedURL) ([]error, error)) *URLRepositoryMock_SaveBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type URLRepositoryMock
+func (_mock *URLRepositoryMock) Update(ctx context.Context, url *model.ShortenedURL) error {
+	ret := _mock.Called(ctx, url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.ShortenedURL) error); ok {
+		r0 = returnFunc(ctx, url)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// URLRepositoryMock_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type URLRepositoryMock_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - url *model.ShortenedURL
+func (_e *URLRepositoryMock_Expecter) Update(ctx interface{}, url interface{}) *URLRepositoryMock_Update_Call {
+	return &URLRepositoryMock_Update_Call{Call: _e.mock.On("Update", ctx, url)}
+}
+
+func (_c *URLRepositoryMock_Update_Call) Run(run func(ctx context.Context, url *model.ShortenedURL)) *URLRepositoryMock_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.ShortenedURL
+		if args[1] != nil {
+			arg1 = args[1].(*model.ShortenedURL)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *URLRepositoryMock_Update_Call) Return(err error) *URLRepositoryMock_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *URLRepositoryMock_Update_Call) RunAndReturn(run func(ctx context.Context, url *model.ShortenedURL) error) *URLRepositoryMock_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,65 @@
+package integration_test
+
+import (
+	"context"
+
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/commands"
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/queries"
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+)
+
+func (s *Suite) TestAPIKeys_CreateAuthenticateRevoke() {
+	ctx := context.Background()
+
+	createHandler, err := commands.NewCreateAPIKeyCommandHandler(s.l, s.apiKeyRepo)
+	s.Require().NoError(err)
+	authHandler, err := commands.NewAuthenticateCommandHandler(s.l, s.apiKeyRepo, "")
+	s.Require().NoError(err)
+	revokeHandler, err := commands.NewRevokeAPIKeyCommandHandler(s.l, s.apiKeyRepo)
+	s.Require().NoError(err)
+	listHandler, err := queries.NewListAPIKeysQueryHandler(s.l, s.pgxPool)
+	s.Require().NoError(err)
+
+	created, err := createHandler.Handle(ctx, commands.CreateAPIKeyCommand{
+		Name:   "ci",
+		Role:   model.RoleViewer,
+		Scopes: []string{model.ScopeLinksCreate},
+	})
+	s.Require().NoError(err)
+
+	// Only hash of the key is stored
+	var storedHash string
+	err = s.pgxPool.QueryRow(ctx, "SELECT hash FROM api_keys WHERE id = $1", created.APIKey.ID).Scan(&storedHash)
+	s.Require().NoError(err)
+	s.NotEqual(created.Key, storedHash)
+
+	principal, err := authHandler.Handle(ctx, commands.AuthenticateCommand{Key: created.Key})
+	s.Require().NoError(err)
+	s.Equal(created.APIKey.ID, principal.KeyID)
+	s.Equal(string(model.RoleViewer), principal.Role)
+	s.True(principal.HasScope(model.ScopeLinksReadStats))
+	s.True(principal.HasScope(model.ScopeLinksCreate))
+	s.False(principal.HasScope(model.ScopeLinksDelete))
+
+	// Authentication marks key used
+	stored, err := s.apiKeyRepo.GetByPrefix(ctx, created.APIKey.Prefix)
+	s.Require().NoError(err)
+	s.NotNil(stored.LastUsedAtUTC)
+
+	err = revokeHandler.Handle(ctx, commands.RevokeAPIKeyCommand{ID: created.APIKey.ID})
+	s.Require().NoError(err)
+
+	_, err = authHandler.Handle(ctx, commands.AuthenticateCommand{Key: created.Key})
+	s.Require().ErrorIs(err, commands.ErrInvalidAPIKey)
+
+	// Revoked keys are listed on demand only
+	resp, err := listHandler.Handle(ctx, queries.ListAPIKeysQuery{IncludeRevoked: false})
+	s.Require().NoError(err)
+	s.Empty(resp.Keys)
+
+	resp, err = listHandler.Handle(ctx, queries.ListAPIKeysQuery{IncludeRevoked: true})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Keys, 1)
+	s.True(resp.Keys[0].Revoked)
+	s.Equal(string(model.RoleViewer), resp.Keys[0].Role)
+}
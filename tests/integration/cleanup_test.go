@@ -0,0 +1,56 @@
+package integration_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/scheduler/tasks"
+)
+
+func (s *Suite) TestCleanupExpiredURLs_KeepsRecentlyExpired() {
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	for _, url := range []*model.ShortenedURL{
+		{ShortURL: "LONGGONE", ValidUntilUTC: ptr(now.Add(-48 * time.Hour))},
+		{ShortURL: "JUSTGONE", ValidUntilUTC: ptr(now.Add(-time.Hour))},
+		{ShortURL: "FOREVER", ValidUntilUTC: nil},
+	} {
+		url.OriginalURL = "https://example.com"
+		url.CreatedAtUTC = now.Add(-72 * time.Hour)
+		url.Status = model.URLStatusActive
+		url.RedirectCode = model.RedirectCodeFound
+		s.Require().NoError(s.urlRepo.Save(ctx, url))
+		s.Require().NoError(s.visitors.Add(ctx, url.ShortURL, "203.0.113.0", "curl/8.0"))
+		s.Require().NoError(s.cache.Set(ctx, url.ShortURL, url))
+	}
+
+	task := tasks.NewCleanupExpiredURLsTask(s.pgxPool, s.cache, s.visitors, 24*time.Hour)
+	s.Require().NoError(task.Execute(ctx))
+
+	// Url expired longer than retention ago is deleted along with its cache entry and visitors.
+	_, err := s.urlRepo.GetByShortenedURL(ctx, "LONGGONE")
+	s.Require().ErrorIs(err, errs.ErrObjectNotFound)
+
+	_, err = s.cache.Get(ctx, "LONGGONE")
+	s.Require().ErrorIs(err, errs.ErrObjectNotFound)
+
+	visitors, err := s.visitors.Count(ctx, "LONGGONE")
+	s.Require().NoError(err)
+	s.Zero(visitors)
+
+	for _, shortURL := range []string{"JUSTGONE", "FOREVER"} {
+		_, err = s.urlRepo.GetByShortenedURL(ctx, shortURL)
+		s.Require().NoError(err, shortURL)
+
+		cached, err := s.cache.Get(ctx, shortURL)
+		s.Require().NoError(err, shortURL)
+		s.NotNil(cached, shortURL)
+
+		visitors, err = s.visitors.Count(ctx, shortURL)
+		s.Require().NoError(err)
+		s.Equal(1, visitors, shortURL)
+	}
+}
@@ -0,0 +1,213 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/queries"
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+)
+
+func (s *Suite) TestRedirect_RecordsClickEvent() {
+	ctx := context.Background()
+
+	url, err := model.NewShortenedURL("http://example.com", "SOMEURL", nil)
+	s.Require().NoError(err)
+	s.Require().NoError(s.urlRepo.Save(ctx, url))
+
+	handler, err := queries.NewRedirectQueryHandler(
+		s.l, s.cache, s.counter, s.visitors, s.clicks, s.passwordAttempts, s.redirectMetrics, s.geo, s.pgxPool, "",
+	)
+	s.Require().NoError(err)
+
+	q, err := queries.NewRedirectQuery("SOMEURL", queries.Visitor{
+		Method:         http.MethodGet,
+		Referrer:       "https://News.example.org/some/article?id=1",
+		UserAgent:      "Mozilla/5.0 (X11; Linux x86_64) Firefox/128.0",
+		IP:             "203.0.113.77",
+		Accept:         "text/html",
+		AcceptLanguage: "en-US,en;q=0.9",
+	}, "")
+	s.Require().NoError(err)
+
+	// Both db and cache hits are recorded.
+	_, err = handler.Handle(ctx, q)
+	s.Require().NoError(err)
+	_, err = handler.Handle(ctx, q)
+	s.Require().NoError(err)
+
+	// Closing recorder flushes queued events.
+	s.Require().NoError(s.clicks.Close(ctx))
+
+	rows, err := s.pgxPool.Query(ctx, `
+	SELECT url_id, short_url, referrer_host, user_agent, ip_prefix, accept_language
+	FROM click_events`)
+	s.Require().NoError(err)
+	defer rows.Close()
+
+	var events []model.ClickEvent
+	for rows.Next() {
+		var e model.ClickEvent
+		s.Require().NoError(rows.Scan(
+			&e.URLID, &e.ShortURL, &e.ReferrerHost, &e.UserAgent, &e.IPPrefix, &e.AcceptLanguage,
+		))
+		events = append(events, e)
+	}
+	s.Require().NoError(rows.Err())
+
+	s.Require().Len(events, 2)
+	for _, e := range events {
+		s.Equal(url.ID, e.URLID)
+		s.Equal("SOMEURL", e.ShortURL)
+		s.Equal("news.example.org", e.ReferrerHost)
+		s.Equal("203.0.113.0/24", e.IPPrefix)
+		s.Equal("en-US,en;q=0.9", e.AcceptLanguage)
+	}
+}
+
+func (s *Suite) TestRedirect_CountsClicksOnFlush() {
+	ctx := context.Background()
+
+	err := s.urlRepo.Save(ctx, &model.ShortenedURL{
+		OriginalURL:  "http://example.com",
+		ShortURL:     "SOMEURL",
+		Clicks:       1,
+		CreatedAtUTC: time.Now().UTC(),
+		Status:       model.URLStatusActive,
+	})
+	s.Require().NoError(err)
+
+	handler, err := queries.NewRedirectQueryHandler(
+		s.l, s.cache, s.counter, s.visitors, s.clicks, s.passwordAttempts, s.redirectMetrics, s.geo, s.pgxPool, "",
+	)
+	s.Require().NoError(err)
+
+	q, err := queries.NewRedirectQuery("SOMEURL", queries.Visitor{UserAgent: "Firefox/128.0", Accept: "text/html"}, "")
+	s.Require().NoError(err)
+
+	// First redirect misses cache, the rest hit it.
+	for range 3 {
+		_, err = handler.Handle(ctx, q)
+		s.Require().NoError(err)
+	}
+
+	clicks := func() int {
+		var n int
+		s.Require().NoError(s.pgxPool.QueryRow(ctx, "SELECT clicks FROM urls WHERE short_url = 'SOMEURL'").Scan(&n))
+		return n
+	}
+
+	// Clicks aren't saved until flush.
+	s.Equal(1, clicks())
+
+	s.Require().NoError(s.counter.Flush(ctx))
+	s.Equal(4, clicks())
+
+	// Flushed clicks aren't added again.
+	s.Require().NoError(s.counter.Flush(ctx))
+	s.Equal(4, clicks())
+}
+
+func (s *Suite) TestRedirect_CountsUniqueVisitors() {
+	ctx := context.Background()
+
+	err := s.urlRepo.Save(ctx, &model.ShortenedURL{
+		OriginalURL:  "http://example.com",
+		ShortURL:     "SOMEURL",
+		CreatedAtUTC: time.Now().UTC(),
+		Status:       model.URLStatusActive,
+	})
+	s.Require().NoError(err)
+
+	redirectHandler, err := queries.NewRedirectQueryHandler(
+		s.l, s.cache, s.counter, s.visitors, s.clicks, s.passwordAttempts, s.redirectMetrics, s.geo, s.pgxPool, "",
+	)
+	s.Require().NoError(err)
+
+	visitors := []queries.Visitor{
+		{IP: "203.0.113.7", UserAgent: "Firefox/128.0", Accept: "text/html"},
+		// Same visitor again.
+		{IP: "203.0.113.7", UserAgent: "Firefox/128.0", Accept: "text/html"},
+		// Same ip, other browser.
+		{IP: "203.0.113.7", UserAgent: "Chrome/130.0", Accept: "text/html"},
+		{IP: "198.51.100.1", UserAgent: "Firefox/128.0", Accept: "text/html"},
+		// Bots are not counted.
+		{IP: "198.51.100.2", UserAgent: "Slackbot-LinkExpanding 1.0", Accept: "*/*"},
+	}
+	for _, v := range visitors {
+		q, qErr := queries.NewRedirectQuery("SOMEURL", v, "")
+		s.Require().NoError(qErr)
+
+		_, err = redirectHandler.Handle(ctx, q)
+		s.Require().NoError(err)
+	}
+
+	infoHandler, err := queries.NewGetURLInfoQueryHandler(s.l, s.visitors, s.pgxPool)
+	s.Require().NoError(err)
+
+	resp, err := infoHandler.Handle(ctx, queries.GetURLInfoQuery{Principal: adminPrincipal, ShortURL: "SOMEURL"})
+	s.Require().NoError(err)
+
+	s.Require().NotNil(resp.UniqueVisitors)
+	s.Equal(3, *resp.UniqueVisitors)
+}
+
+func (s *Suite) TestRedirect_CountsBotsSeparately() {
+	ctx := context.Background()
+
+	err := s.urlRepo.Save(ctx, &model.ShortenedURL{
+		OriginalURL:  "http://example.com",
+		ShortURL:     "SOMEURL",
+		CreatedAtUTC: time.Now().UTC(),
+		Status:       model.URLStatusActive,
+	})
+	s.Require().NoError(err)
+
+	handler, err := queries.NewRedirectQueryHandler(
+		s.l, s.cache, s.counter, s.visitors, s.clicks, s.passwordAttempts, s.redirectMetrics, s.geo, s.pgxPool, "",
+	)
+	s.Require().NoError(err)
+
+	visitors := []queries.Visitor{
+		{Method: http.MethodGet, UserAgent: "TelegramBot (like TwitterBot)", Accept: "*/*"},
+		{Method: http.MethodGet, UserAgent: "facebookexternalhit/1.1", Accept: "*/*"},
+		{Method: http.MethodHead, UserAgent: "Mozilla/5.0 Firefox/128.0", Accept: "text/html"},
+		{Method: http.MethodGet, UserAgent: "Mozilla/5.0 Firefox/128.0"},
+		{Method: http.MethodGet, UserAgent: "Mozilla/5.0 Firefox/128.0", Accept: "text/html"},
+	}
+	for _, v := range visitors {
+		q, qErr := queries.NewRedirectQuery("SOMEURL", v, "")
+		s.Require().NoError(qErr)
+
+		// Bots are still redirected.
+		resp, hErr := handler.Handle(ctx, q)
+		s.Require().NoError(hErr)
+		s.Equal("http://example.com", resp.OriginalURL)
+	}
+
+	s.Require().NoError(s.counter.Flush(ctx))
+	s.Require().NoError(s.clicks.Close(ctx))
+
+	var clicks, botClicks int
+	err = s.pgxPool.QueryRow(ctx, "SELECT clicks, bot_clicks FROM urls WHERE short_url = 'SOMEURL'").
+		Scan(&clicks, &botClicks)
+	s.Require().NoError(err)
+	s.Equal(1, clicks)
+	s.Equal(4, botClicks)
+
+	// Only human's click event is recorded.
+	var events int
+	s.Require().NoError(s.pgxPool.QueryRow(ctx, "SELECT COUNT(*) FROM click_events").Scan(&events))
+	s.Equal(1, events)
+
+	infoHandler, err := queries.NewGetURLInfoQueryHandler(s.l, s.visitors, s.pgxPool)
+	s.Require().NoError(err)
+
+	info, err := infoHandler.Handle(ctx, queries.GetURLInfoQuery{Principal: adminPrincipal, ShortURL: "SOMEURL"})
+	s.Require().NoError(err)
+	s.Equal(1, info.Clicks)
+	s.Equal(4, info.BotClicks)
+	s.Require().NotNil(info.UniqueVisitors)
+	s.Equal(1, *info.UniqueVisitors)
+}
@@ -2,8 +2,13 @@ package integration_test
 
 import (
 	"context"
+	"time"
 
+	"github.com/dzhordano/urlshortener/internal/adapters/outbound/pg/tokenseq"
 	"github.com/dzhordano/urlshortener/internal/core/application/usecases/commands"
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/queries"
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
 )
 
 func (s *Suite) TestShortenURLCommandHandler_Success() {
@@ -12,27 +17,303 @@ func (s *Suite) TestShortenURLCommandHandler_Success() {
 		OriginalURL: "http://example.com",
 	}
 
-	handler, err := commands.NewShortenURLCommandHandler(s.l, s.cache, s.urlRepo)
+	handler, err := commands.NewShortenURLCommandHandler(
+		s.l, s.cache, s.urlRepo, s.tokenGen, s.expirationPolicy, s.redirectCode,
+	)
 	s.Require().NoError(err)
 
 	resp, err := handler.Handle(ctx, req)
 	s.Require().NoError(err)
 
-	valueFromDB, err := s.urlRepo.GetByOriginalURL(ctx, req.OriginalURL)
+	valueFromDB, err := s.urlRepo.GetByOriginalURL(ctx, req.OriginalURL, nil)
 	s.Require().NoError(err)
 
 	valueFromCache, err := s.cache.Get(ctx, valueFromDB.ShortURL)
 	s.Require().NoError(err)
 
 	// Check if value is in db
-	s.Equal(resp, valueFromDB.ShortURL)
+	s.Equal(resp.ShortURL, valueFromDB.ShortURL)
 	// Check whether original url is saved (lulz)
 	s.Equal(req.OriginalURL, valueFromDB.OriginalURL)
 	// Check if cache did save shortened url value
-	s.Equal(req.OriginalURL, valueFromCache)
+	s.Require().NotNil(valueFromCache)
+	s.Equal(req.OriginalURL, valueFromCache.OriginalURL)
 	// Check if clicks are saved correctly
 	s.Equal(0, valueFromDB.Clicks)
 	// Check if time isn't a nil value
 	s.NotEmpty(valueFromDB.CreatedAtUTC)
 	s.NotEmpty(valueFromDB.ValidUntilUTC)
 }
+
+func (s *Suite) TestShortenURLCommandHandler_AliasTaken() {
+	ctx := context.Background()
+	req := commands.ShortenURLCommand{
+		OriginalURL: "http://example.com",
+		Alias:       "spring-sale",
+	}
+
+	handler, err := commands.NewShortenURLCommandHandler(
+		s.l, s.cache, s.urlRepo, s.tokenGen, s.expirationPolicy, s.redirectCode,
+	)
+	s.Require().NoError(err)
+
+	resp, err := handler.Handle(ctx, req)
+	s.Require().NoError(err)
+	s.Equal(req.Alias, resp.ShortURL)
+
+	// Same alias again must be rejected
+	_, err = handler.Handle(ctx, req)
+	s.Require().ErrorIs(err, errs.ErrObjectAlreadyExists)
+}
+
+func (s *Suite) TestShortenURLCommandHandler_SequenceTokens() {
+	ctx := context.Background()
+
+	tokenGen, err := tokenseq.NewGenerator(s.pgxPool, 8)
+	s.Require().NoError(err)
+
+	handler, err := commands.NewShortenURLCommandHandler(
+		s.l, s.cache, s.urlRepo, tokenGen, s.expirationPolicy, s.redirectCode,
+	)
+	s.Require().NoError(err)
+
+	first, err := handler.Handle(ctx, commands.ShortenURLCommand{OriginalURL: "http://example.com/1"})
+	s.Require().NoError(err)
+
+	second, err := handler.Handle(ctx, commands.ShortenURLCommand{OriginalURL: "http://example.com/2"})
+	s.Require().NoError(err)
+
+	// Sequence tokens are fixed length and never repeat
+	s.Len(first.ShortURL, 8)
+	s.Len(second.ShortURL, 8)
+	s.NotEqual(first.ShortURL, second.ShortURL)
+}
+
+func (s *Suite) TestShortenURLCommandHandler_NeverExpires() {
+	ctx := context.Background()
+	req := commands.ShortenURLCommand{
+		OriginalURL: "http://example.com",
+		Expiration:  commands.Expiration{Never: true},
+	}
+
+	handler, err := commands.NewShortenURLCommandHandler(
+		s.l, s.cache, s.urlRepo, s.tokenGen, s.expirationPolicy, s.redirectCode,
+	)
+	s.Require().NoError(err)
+
+	resp, err := handler.Handle(ctx, req)
+	s.Require().NoError(err)
+
+	valueFromDB, err := s.urlRepo.GetByShortenedURL(ctx, resp.ShortURL)
+	s.Require().NoError(err)
+
+	// Never expiring url has no valid until
+	s.Nil(valueFromDB.ValidUntilUTC)
+}
+
+func (s *Suite) TestShortenURLCommandHandler_ReuseExisting() {
+	ctx := context.Background()
+	req := commands.ShortenURLCommand{
+		OriginalURL:   "http://example.com",
+		ReuseExisting: true,
+	}
+
+	handler, err := commands.NewShortenURLCommandHandler(
+		s.l, s.cache, s.urlRepo, s.tokenGen, s.expirationPolicy, s.redirectCode,
+	)
+	s.Require().NoError(err)
+
+	first, err := handler.Handle(ctx, req)
+	s.Require().NoError(err)
+	s.True(first.Created)
+
+	second, err := handler.Handle(ctx, req)
+	s.Require().NoError(err)
+
+	// Second request reuses link created by the first one
+	s.False(second.Created)
+	s.Equal(first.ShortURL, second.ShortURL)
+
+	// Without reuse new link is created
+	req.ReuseExisting = false
+	third, err := handler.Handle(ctx, req)
+	s.Require().NoError(err)
+	s.True(third.Created)
+	s.NotEqual(first.ShortURL, third.ShortURL)
+}
+
+func (s *Suite) TestShortenURLsBatchCommandHandler_PerItemResults() {
+	ctx := context.Background()
+	cmd := commands.ShortenURLsBatchCommand{Items: []commands.ShortenURLCommand{
+		{OriginalURL: "http://example.com/a"},
+		{OriginalURL: "http://example.com/b", Alias: "batch-alias"},
+		{OriginalURL: "http://example.com/c", Alias: "batch-alias"},
+	}}
+
+	handler, err := commands.NewShortenURLsBatchCommandHandler(
+		s.l, s.cache, s.urlRepo, s.tokenGen, s.expirationPolicy, s.redirectCode,
+	)
+	s.Require().NoError(err)
+
+	results, err := handler.Handle(ctx, cmd)
+	s.Require().NoError(err)
+	s.Require().Len(results, 3)
+
+	s.Require().NoError(results[0].Err)
+	s.Require().NoError(results[1].Err)
+	s.Equal("batch-alias", results[1].ShortURL)
+	// Second item with the same alias in batch must fail on its own
+	s.Require().ErrorIs(results[2].Err, errs.ErrObjectAlreadyExists)
+
+	for _, res := range results[:2] {
+		valueFromDB, err := s.urlRepo.GetByShortenedURL(ctx, res.ShortURL)
+		s.Require().NoError(err)
+		s.Equal(res.ShortURL, valueFromDB.ShortURL)
+
+		// Cache is populated for saved urls
+		valueFromCache, err := s.cache.Get(ctx, res.ShortURL)
+		s.Require().NoError(err)
+		s.Require().NotNil(valueFromCache)
+		s.Equal(valueFromDB.OriginalURL, valueFromCache.OriginalURL)
+	}
+
+	// Alias still leads to the first url
+	valueFromDB, err := s.urlRepo.GetByShortenedURL(ctx, "batch-alias")
+	s.Require().NoError(err)
+	s.Equal("http://example.com/b", valueFromDB.OriginalURL)
+}
+
+func (s *Suite) TestUpdateURLCommandHandler_Disable() {
+	ctx := context.Background()
+
+	shortenHandler, err := commands.NewShortenURLCommandHandler(
+		s.l, s.cache, s.urlRepo, s.tokenGen, s.expirationPolicy, s.redirectCode,
+	)
+	s.Require().NoError(err)
+
+	resp, err := shortenHandler.Handle(ctx, commands.ShortenURLCommand{OriginalURL: "http://example.com"})
+	s.Require().NoError(err)
+
+	redirectHandler, err := queries.NewRedirectQueryHandler(
+		s.l, s.cache, s.counter, s.visitors, s.clicks, s.passwordAttempts, s.redirectMetrics, s.geo, s.pgxPool, "",
+	)
+	s.Require().NoError(err)
+
+	// Cache url before update
+	_, err = redirectHandler.Handle(ctx, queries.RedirectQuery{ShortURL: resp.ShortURL})
+	s.Require().NoError(err)
+
+	updateHandler, err := commands.NewUpdateURLCommandHandler(s.l, s.cache, s.urlRepo, s.expirationPolicy)
+	s.Require().NoError(err)
+
+	newURL := "http://example.com/new"
+	disabled := model.URLStatusDisabled
+	err = updateHandler.Handle(ctx, commands.UpdateURLCommand{
+		Principal:   adminPrincipal,
+		ShortURL:    resp.ShortURL,
+		OriginalURL: &newURL,
+		Status:      &disabled,
+	})
+	s.Require().NoError(err)
+
+	valueFromDB, err := s.urlRepo.GetByShortenedURL(ctx, resp.ShortURL)
+	s.Require().NoError(err)
+	s.Equal(newURL, valueFromDB.OriginalURL)
+	s.Equal(model.URLStatusDisabled, valueFromDB.Status)
+
+	// Disabled url doesn't redirect right away
+	_, err = redirectHandler.Handle(ctx, queries.RedirectQuery{ShortURL: resp.ShortURL})
+	s.Require().ErrorIs(err, errs.ErrObjectNotFound)
+}
+
+func (s *Suite) TestUpdateURLCommandHandler_RedirectCode() {
+	ctx := context.Background()
+
+	shortenHandler, err := commands.NewShortenURLCommandHandler(
+		s.l, s.cache, s.urlRepo, s.tokenGen, s.expirationPolicy, s.redirectCode,
+	)
+	s.Require().NoError(err)
+
+	resp, err := shortenHandler.Handle(ctx, commands.ShortenURLCommand{OriginalURL: "http://example.com"})
+	s.Require().NoError(err)
+
+	redirectHandler, err := queries.NewRedirectQueryHandler(
+		s.l, s.cache, s.counter, s.visitors, s.clicks, s.passwordAttempts, s.redirectMetrics, s.geo, s.pgxPool, "",
+	)
+	s.Require().NoError(err)
+
+	// Url created without redirect code gets server default, both from cache and db.
+	redirect, err := redirectHandler.Handle(ctx, queries.RedirectQuery{ShortURL: resp.ShortURL})
+	s.Require().NoError(err)
+	s.Equal(s.redirectCode, redirect.RedirectCode)
+
+	s.Require().NoError(s.cache.Delete(ctx, resp.ShortURL))
+	redirect, err = redirectHandler.Handle(ctx, queries.RedirectQuery{ShortURL: resp.ShortURL})
+	s.Require().NoError(err)
+	s.Equal(s.redirectCode, redirect.RedirectCode)
+
+	updateHandler, err := commands.NewUpdateURLCommandHandler(s.l, s.cache, s.urlRepo, s.expirationPolicy)
+	s.Require().NoError(err)
+
+	code := model.RedirectCodePermanentRedirect
+	err = updateHandler.Handle(ctx, commands.UpdateURLCommand{
+		Principal:    adminPrincipal,
+		ShortURL:     resp.ShortURL,
+		RedirectCode: &code,
+	})
+	s.Require().NoError(err)
+
+	redirect, err = redirectHandler.Handle(ctx, queries.RedirectQuery{ShortURL: resp.ShortURL})
+	s.Require().NoError(err)
+	s.Equal(model.RedirectCodePermanentRedirect, redirect.RedirectCode)
+}
+
+func (s *Suite) TestDeleteURLCommandHandler_SoftDelete() {
+	ctx := context.Background()
+
+	shortenHandler, err := commands.NewShortenURLCommandHandler(
+		s.l, s.cache, s.urlRepo, s.tokenGen, s.expirationPolicy, s.redirectCode,
+	)
+	s.Require().NoError(err)
+
+	resp, err := shortenHandler.Handle(ctx, commands.ShortenURLCommand{OriginalURL: "http://example.com"})
+	s.Require().NoError(err)
+	s.Require().NoError(s.visitors.Add(ctx, resp.ShortURL, "203.0.113.0", "curl/8.0"))
+
+	deleteHandler, err := commands.NewDeleteURLCommandHandler(s.l, s.cache, s.urlRepo, s.visitors)
+	s.Require().NoError(err)
+
+	err = deleteHandler.Handle(ctx, commands.DeleteURLCommand{Principal: adminPrincipal, ShortURL: resp.ShortURL})
+	s.Require().NoError(err)
+
+	// Cached url is invalidated
+	_, err = s.cache.Get(ctx, resp.ShortURL)
+	s.Require().ErrorIs(err, errs.ErrObjectNotFound)
+
+	// Visitors are forgotten
+	visitors, err := s.visitors.Count(ctx, resp.ShortURL)
+	s.Require().NoError(err)
+	s.Zero(visitors)
+
+	// Deleted url is no longer accessible
+	_, err = s.urlRepo.GetByShortenedURL(ctx, resp.ShortURL)
+	s.Require().ErrorIs(err, errs.ErrObjectNotFound)
+
+	redirectHandler, err := queries.NewRedirectQueryHandler(
+		s.l, s.cache, s.counter, s.visitors, s.clicks, s.passwordAttempts, s.redirectMetrics, s.geo, s.pgxPool, "",
+	)
+	s.Require().NoError(err)
+
+	_, err = redirectHandler.Handle(ctx, queries.RedirectQuery{ShortURL: resp.ShortURL})
+	s.Require().ErrorIs(err, errs.ErrObjectNotFound)
+
+	// But row is kept
+	var deletedAt *time.Time
+	err = s.pgxPool.QueryRow(ctx, "SELECT deleted_at FROM urls WHERE short_url = $1", resp.ShortURL).Scan(&deletedAt)
+	s.Require().NoError(err)
+	s.NotNil(deletedAt)
+
+	// Deleting twice is not found
+	err = deleteHandler.Handle(ctx, commands.DeleteURLCommand{Principal: adminPrincipal, ShortURL: resp.ShortURL})
+	s.Require().ErrorIs(err, errs.ErrObjectNotFound)
+}
@@ -0,0 +1,135 @@
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/queries"
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+func (s *Suite) TestListURLsQueryHandler_Pagination() {
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	// Save urls created one minute apart, the newest one is the last.
+	for i := range 5 {
+		err := s.urlRepo.Save(ctx, &model.ShortenedURL{
+			OriginalURL:   fmt.Sprintf("http://example%d.com", i),
+			ShortURL:      fmt.Sprintf("SOMEURL%d", i),
+			Clicks:        i,
+			CreatedAtUTC:  now.Add(time.Duration(i-5) * time.Minute),
+			ValidUntilUTC: ptr(now.Add(time.Hour)),
+			Status:        model.URLStatusActive,
+		})
+		s.Require().NoError(err)
+	}
+
+	handler, err := queries.NewListURLsQueryHandler(s.l, s.pgxPool)
+	s.Require().NoError(err)
+
+	q, err := queries.NewListURLsQuery(adminPrincipal, queries.ListURLsFilter{}, "", "", 2, "")
+	s.Require().NoError(err)
+
+	var shortURLs []string
+	for {
+		resp, err := handler.Handle(ctx, q)
+		s.Require().NoError(err)
+		s.LessOrEqual(len(resp.URLs), 2)
+
+		for _, url := range resp.URLs {
+			shortURLs = append(shortURLs, url.ShortURL)
+		}
+
+		if resp.NextCursor == "" {
+			break
+		}
+		q.Cursor = resp.NextCursor
+	}
+
+	// All urls are listed newest first without repeats
+	s.Equal([]string{"SOMEURL4", "SOMEURL3", "SOMEURL2", "SOMEURL1", "SOMEURL0"}, shortURLs)
+
+	// Cursor can't be used with another sort.
+	first, err := queries.NewListURLsQuery(adminPrincipal, queries.ListURLsFilter{}, "", "", 2, "")
+	s.Require().NoError(err)
+
+	resp, err := handler.Handle(ctx, first)
+	s.Require().NoError(err)
+	s.Require().NotEmpty(resp.NextCursor)
+
+	_, err = queries.NewListURLsQuery(adminPrincipal, queries.ListURLsFilter{}, "clicks", "", 2, resp.NextCursor)
+	s.Require().ErrorIs(err, errs.ErrValueIsInvalid)
+
+	_, err = queries.NewListURLsQuery(adminPrincipal, queries.ListURLsFilter{}, "", "asc", 2, resp.NextCursor)
+	s.Require().ErrorIs(err, errs.ErrValueIsInvalid)
+}
+
+func (s *Suite) TestListURLsQueryHandler_Filters() {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	owner := uuid.New()
+
+	urls := []*model.ShortenedURL{
+		{
+			OriginalURL:   "https://shop.example.com/sale",
+			ShortURL:      "ACTIVE0",
+			Clicks:        10,
+			CreatedAtUTC:  now,
+			ValidUntilUTC: ptr(now.Add(time.Hour)),
+			Status:        model.URLStatusActive,
+			Tags:          []string{"promo"},
+		},
+		{
+			OriginalURL:   "https://example.org",
+			ShortURL:      "EXPIRED",
+			CreatedAtUTC:  now.Add(-2 * time.Hour),
+			ValidUntilUTC: ptr(now.Add(-time.Hour)),
+			Status:        model.URLStatusActive,
+		},
+		{
+			OriginalURL:  "https://blog.example.org/post",
+			ShortURL:     "DISABLED",
+			Clicks:       20,
+			CreatedAtUTC: now,
+			Status:       model.URLStatusDisabled,
+			Tags:         []string{"promo"},
+			OwnerID:      &owner,
+		},
+	}
+	for _, url := range urls {
+		s.Require().NoError(s.urlRepo.Save(ctx, url))
+	}
+
+	handler, err := queries.NewListURLsQueryHandler(s.l, s.pgxPool)
+	s.Require().NoError(err)
+
+	list := func(filter queries.ListURLsFilter, sortBy string) []string {
+		q, err := queries.NewListURLsQuery(adminPrincipal, filter, sortBy, "", 0, "")
+		s.Require().NoError(err)
+
+		resp, err := handler.Handle(ctx, q)
+		s.Require().NoError(err)
+
+		var shortURLs []string
+		for _, url := range resp.URLs {
+			shortURLs = append(shortURLs, url.ShortURL)
+		}
+
+		return shortURLs
+	}
+
+	s.Equal([]string{"ACTIVE0"}, list(queries.ListURLsFilter{State: queries.URLStateActive}, ""))
+	s.Equal([]string{"EXPIRED"}, list(queries.ListURLsFilter{State: queries.URLStateExpired}, ""))
+	s.Equal([]string{"DISABLED"}, list(queries.ListURLsFilter{State: queries.URLStateDisabled}, ""))
+	s.Equal([]string{"DISABLED", "EXPIRED"}, list(queries.ListURLsFilter{Host: "example.org"}, "clicks"))
+	s.Equal([]string{"DISABLED", "ACTIVE0"}, list(queries.ListURLsFilter{Tag: "promo"}, "clicks"))
+	s.Equal(
+		[]string{"EXPIRED"},
+		list(queries.ListURLsFilter{CreatedTo: ptr(now.Add(-time.Hour))}, ""),
+	)
+	s.Equal([]string{"DISABLED"}, list(queries.ListURLsFilter{OwnerID: &owner}, ""))
+}
@@ -0,0 +1,82 @@
+package integration_test
+
+import (
+	"context"
+
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/commands"
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/queries"
+	"github.com/dzhordano/urlshortener/internal/pkg/auth"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+func (s *Suite) TestOwnership_ScopesLinksToOwner() {
+	ctx := context.Background()
+	owner := auth.Principal{KeyID: uuid.New(), Name: "owner"}
+	other := auth.Principal{KeyID: uuid.New(), Name: "other"}
+
+	shortenHandler, err := commands.NewShortenURLCommandHandler(
+		s.l, s.cache, s.urlRepo, s.tokenGen, s.expirationPolicy, s.redirectCode,
+	)
+	s.Require().NoError(err)
+
+	owned, err := shortenHandler.Handle(ctx, commands.ShortenURLCommand{
+		OriginalURL:   "http://example.com",
+		ReuseExisting: true,
+		OwnerID:       &owner.KeyID,
+	})
+	s.Require().NoError(err)
+
+	// Reuse doesn't hand out someone else's link.
+	othersOwn, err := shortenHandler.Handle(ctx, commands.ShortenURLCommand{
+		OriginalURL:   "http://example.com",
+		ReuseExisting: true,
+		OwnerID:       &other.KeyID,
+	})
+	s.Require().NoError(err)
+	s.True(othersOwn.Created)
+	s.NotEqual(owned.ShortURL, othersOwn.ShortURL)
+
+	infoHandler, err := queries.NewGetURLInfoQueryHandler(s.l, s.visitors, s.pgxPool)
+	s.Require().NoError(err)
+
+	_, err = infoHandler.Handle(ctx, queries.GetURLInfoQuery{Principal: owner, ShortURL: owned.ShortURL})
+	s.Require().NoError(err)
+
+	_, err = infoHandler.Handle(ctx, queries.GetURLInfoQuery{Principal: other, ShortURL: owned.ShortURL})
+	s.Require().ErrorIs(err, errs.ErrObjectNotFound)
+
+	_, err = infoHandler.Handle(ctx, queries.GetURLInfoQuery{Principal: adminPrincipal, ShortURL: owned.ShortURL})
+	s.Require().NoError(err)
+
+	listHandler, err := queries.NewListURLsQueryHandler(s.l, s.pgxPool)
+	s.Require().NoError(err)
+
+	list := func(principal auth.Principal) []string {
+		q, err := queries.NewListURLsQuery(principal, queries.ListURLsFilter{}, "", "", 0, "")
+		s.Require().NoError(err)
+
+		resp, err := listHandler.Handle(ctx, q)
+		s.Require().NoError(err)
+
+		var shortURLs []string
+		for _, url := range resp.URLs {
+			shortURLs = append(shortURLs, url.ShortURL)
+		}
+
+		return shortURLs
+	}
+
+	s.Equal([]string{owned.ShortURL}, list(owner))
+	s.Equal([]string{othersOwn.ShortURL}, list(other))
+	s.ElementsMatch([]string{owned.ShortURL, othersOwn.ShortURL}, list(adminPrincipal))
+
+	deleteHandler, err := commands.NewDeleteURLCommandHandler(s.l, s.cache, s.urlRepo, s.visitors)
+	s.Require().NoError(err)
+
+	err = deleteHandler.Handle(ctx, commands.DeleteURLCommand{Principal: other, ShortURL: owned.ShortURL})
+	s.Require().ErrorIs(err, errs.ErrObjectNotFound)
+
+	err = deleteHandler.Handle(ctx, commands.DeleteURLCommand{Principal: owner, ShortURL: owned.ShortURL})
+	s.Require().NoError(err)
+}
@@ -2,17 +2,27 @@ package integration_test
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/dzhordano/urlshortener/internal/adapters/outbound/metrics/redirectmetrics"
 	"github.com/dzhordano/urlshortener/internal/core/application/usecases/queries"
 	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/auth"
 	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 func (s *Suite) TestGetURLInfoQueryHandler_Found() {
 	ctx := context.Background()
 	query := queries.GetURLInfoQuery{
-		ShortURL: "SOMEURL",
+		Principal: adminPrincipal,
+		ShortURL:  "SOMEURL",
 	}
 
 	// Save shortened url before retrieval
@@ -20,13 +30,14 @@ func (s *Suite) TestGetURLInfoQueryHandler_Found() {
 		OriginalURL:   "http://example.com",
 		ShortURL:      "SOMEURL",
 		Clicks:        1,
+		Status:        model.URLStatusActive,
 		CreatedAtUTC:  time.Now().UTC(),
-		ValidUntilUTC: time.Now().UTC(),
+		ValidUntilUTC: ptr(time.Now().UTC().Add(10 * time.Minute)),
 	}
 	err := s.urlRepo.Save(ctx, shortenedURL)
 	s.Require().NoError(err)
 
-	handler, err := queries.NewGetURLInfoQueryHandler(s.l, s.pgxPool)
+	handler, err := queries.NewGetURLInfoQueryHandler(s.l, s.visitors, s.pgxPool)
 	s.Require().NoError(err)
 
 	resp, err := handler.Handle(ctx, query)
@@ -45,6 +56,7 @@ func (s *Suite) TestGetURLInfoQueryHandler_Found() {
 	s.Equal(resp.CreatedAtUTC.Day(), valueFromDB.CreatedAtUTC.Day())
 	s.Equal(resp.CreatedAtUTC.Minute(), valueFromDB.CreatedAtUTC.Minute())
 	s.Equal(resp.CreatedAtUTC.Second(), valueFromDB.CreatedAtUTC.Second())
+	s.Require().NotNil(resp.ValidUntilUTC)
 	s.Equal(resp.ValidUntilUTC.Second(), valueFromDB.ValidUntilUTC.Second())
 
 	// No need to check cache since no cache is used in this method
@@ -53,10 +65,11 @@ func (s *Suite) TestGetURLInfoQueryHandler_Found() {
 func (s *Suite) TestGetURLInfoQueryHandler_NotFound() {
 	ctx := context.Background()
 	query := queries.GetURLInfoQuery{
-		ShortURL: "SOMEURL",
+		Principal: adminPrincipal,
+		ShortURL:  "SOMEURL",
 	}
 
-	handler, err := queries.NewGetURLInfoQueryHandler(s.l, s.pgxPool)
+	handler, err := queries.NewGetURLInfoQueryHandler(s.l, s.visitors, s.pgxPool)
 	s.Require().NoError(err)
 
 	resp, handlerErr := handler.Handle(ctx, query)
@@ -80,13 +93,16 @@ func (s *Suite) TestRedirect_Found() {
 		OriginalURL:   "http://example.com",
 		ShortURL:      "SOMEURL",
 		Clicks:        1,
+		Status:        model.URLStatusActive,
 		CreatedAtUTC:  time.Now().UTC(),
-		ValidUntilUTC: time.Now().UTC().Add(10 * time.Minute),
+		ValidUntilUTC: ptr(time.Now().UTC().Add(10 * time.Minute)),
 	}
 	err := s.urlRepo.Save(ctx, shortenedURL)
 	s.Require().NoError(err)
 
-	handler, err := queries.NewRedirectQueryHandler(s.l, s.cache, s.pgxPool)
+	handler, err := queries.NewRedirectQueryHandler(
+		s.l, s.cache, s.counter, s.visitors, s.clicks, s.passwordAttempts, s.redirectMetrics, s.geo, s.pgxPool, "",
+	)
 	s.Require().NoError(err)
 
 	resp, err := handler.Handle(ctx, query)
@@ -102,7 +118,8 @@ func (s *Suite) TestRedirect_Found() {
 	s.Equal(resp.OriginalURL, valueFromDB.OriginalURL)
 
 	// Since cache is used, check if value is being saved
-	s.Equal(resp.OriginalURL, valueFromCache)
+	s.Require().NotNil(valueFromCache)
+	s.Equal(resp.OriginalURL, valueFromCache.OriginalURL)
 }
 
 func (s *Suite) TestRedirect_NotFound() {
@@ -111,7 +128,9 @@ func (s *Suite) TestRedirect_NotFound() {
 		ShortURL: "SOMEURL",
 	}
 
-	handler, err := queries.NewRedirectQueryHandler(s.l, s.cache, s.pgxPool)
+	handler, err := queries.NewRedirectQueryHandler(
+		s.l, s.cache, s.counter, s.visitors, s.clicks, s.passwordAttempts, s.redirectMetrics, s.geo, s.pgxPool, "",
+	)
 	s.Require().NoError(err)
 
 	resp, handlerErr := handler.Handle(ctx, query)
@@ -126,6 +145,599 @@ func (s *Suite) TestRedirect_NotFound() {
 	s.Require().ErrorIs(handlerErr, errs.ErrObjectNotFound)
 	s.Empty(resp)
 	s.Nil(valueFromDB)
-	// Check empty value since request for non-existing values are cached too
-	s.Empty(valueFromCache)
+	// Check nil value since request for non-existing values are cached too
+	s.Nil(valueFromCache)
+}
+
+func (s *Suite) TestRedirect_Expired() {
+	ctx := context.Background()
+	query := queries.RedirectQuery{
+		ShortURL: "SOMEURL",
+	}
+
+	// Expired url still lies in db until cleanup task removes it
+	shortenedURL := &model.ShortenedURL{
+		OriginalURL:   "http://example.com",
+		ShortURL:      "SOMEURL",
+		CreatedAtUTC:  time.Now().UTC().Add(-time.Hour),
+		Status:        model.URLStatusActive,
+		ValidUntilUTC: ptr(time.Now().UTC().Add(-time.Minute)),
+	}
+	err := s.urlRepo.Save(ctx, shortenedURL)
+	s.Require().NoError(err)
+
+	handler, err := queries.NewRedirectQueryHandler(
+		s.l, s.cache, s.counter, s.visitors, s.clicks, s.passwordAttempts, s.redirectMetrics, s.geo, s.pgxPool, "",
+	)
+	s.Require().NoError(err)
+
+	_, err = handler.Handle(ctx, query)
+	s.Require().ErrorIs(err, errs.ErrObjectNotFound)
+}
+
+func (s *Suite) TestRedirect_NeverExpires() {
+	ctx := context.Background()
+	query := queries.RedirectQuery{
+		ShortURL: "SOMEURL",
+	}
+
+	shortenedURL := &model.ShortenedURL{
+		OriginalURL:  "http://example.com",
+		ShortURL:     "SOMEURL",
+		CreatedAtUTC: time.Now().UTC(),
+		Status:       model.URLStatusActive,
+	}
+	err := s.urlRepo.Save(ctx, shortenedURL)
+	s.Require().NoError(err)
+
+	handler, err := queries.NewRedirectQueryHandler(
+		s.l, s.cache, s.counter, s.visitors, s.clicks, s.passwordAttempts, s.redirectMetrics, s.geo, s.pgxPool, "",
+	)
+	s.Require().NoError(err)
+
+	resp, err := handler.Handle(ctx, query)
+	s.Require().NoError(err)
+	s.Equal(shortenedURL.OriginalURL, resp.OriginalURL)
+}
+
+func (s *Suite) TestRedirect_Preview() {
+	ctx := context.Background()
+
+	preview := &model.LinkPreview{
+		Title:       "Spring sale",
+		Description: "Up to 50% off",
+		ImageURL:    "https://cdn.example.com/sale.png",
+	}
+	err := s.urlRepo.Save(ctx, &model.ShortenedURL{
+		OriginalURL:  "http://example.com",
+		ShortURL:     "SOMEURL",
+		CreatedAtUTC: time.Now().UTC(),
+		Status:       model.URLStatusActive,
+		Preview:      preview,
+	})
+	s.Require().NoError(err)
+
+	handler, err := queries.NewRedirectQueryHandler(
+		s.l, s.cache, s.counter, s.visitors, s.clicks, s.passwordAttempts, s.redirectMetrics, s.geo, s.pgxPool, "",
+	)
+	s.Require().NoError(err)
+
+	bot := queries.Visitor{Method: http.MethodGet, UserAgent: "Slackbot-LinkExpanding 1.0", Accept: "*/*"}
+	human := queries.Visitor{Method: http.MethodGet, UserAgent: "Mozilla/5.0 Firefox/128.0", Accept: "text/html"}
+
+	// First bot's redirect reads url from db, second one from cache.
+	for range 2 {
+		resp, hErr := handler.Handle(ctx, queries.RedirectQuery{ShortURL: "SOMEURL", Visitor: bot})
+		s.Require().NoError(hErr)
+		s.Equal("http://example.com", resp.OriginalURL)
+		s.Equal(preview, resp.Preview)
+	}
+
+	resp, err := handler.Handle(ctx, queries.RedirectQuery{ShortURL: "SOMEURL", Visitor: human})
+	s.Require().NoError(err)
+	s.Equal("http://example.com", resp.OriginalURL)
+	s.Nil(resp.Preview)
+
+	infoHandler, err := queries.NewGetURLInfoQueryHandler(s.l, s.visitors, s.pgxPool)
+	s.Require().NoError(err)
+
+	info, err := infoHandler.Handle(ctx, queries.GetURLInfoQuery{Principal: adminPrincipal, ShortURL: "SOMEURL"})
+	s.Require().NoError(err)
+	s.Equal(preview, info.Preview)
+}
+
+func (s *Suite) TestPreviewURL_DoesNotCountClick() {
+	ctx := context.Background()
+
+	validUntil := time.Now().UTC().Add(time.Hour).Truncate(time.Microsecond)
+	err := s.urlRepo.Save(ctx, &model.ShortenedURL{
+		OriginalURL:   "http://example.com",
+		ShortURL:      "SOMEURL",
+		CreatedAtUTC:  time.Now().UTC(),
+		ValidUntilUTC: &validUntil,
+		Status:        model.URLStatusActive,
+	})
+	s.Require().NoError(err)
+
+	handler, err := queries.NewPreviewURLQueryHandler(s.l, s.cache, s.pgxPool)
+	s.Require().NoError(err)
+
+	// First preview reads url from db, second one from cache.
+	for range 2 {
+		resp, hErr := handler.Handle(ctx, queries.PreviewURLQuery{ShortURL: "SOMEURL"})
+		s.Require().NoError(hErr)
+		s.Equal("http://example.com", resp.OriginalURL)
+		s.Require().NotNil(resp.ValidUntilUTC)
+		s.True(validUntil.Equal(*resp.ValidUntilUTC))
+		s.Equal(model.SafetyVerdictCaution, resp.Safety.Verdict)
+		s.Equal([]model.SafetyWarning{model.SafetyWarningInsecureScheme}, resp.Safety.Warnings)
+	}
+
+	s.Require().NoError(s.counter.Flush(ctx))
+	s.Require().NoError(s.clicks.Close(ctx))
+
+	var clicks, events int
+	err = s.pgxPool.QueryRow(ctx, "SELECT clicks FROM urls WHERE short_url = 'SOMEURL'").Scan(&clicks)
+	s.Require().NoError(err)
+	s.Zero(clicks)
+	s.Require().NoError(s.pgxPool.QueryRow(ctx, "SELECT COUNT(*) FROM click_events").Scan(&events))
+	s.Zero(events)
+
+	_, err = handler.Handle(ctx, queries.PreviewURLQuery{ShortURL: "NOTFOUND"})
+	s.Require().ErrorIs(err, errs.ErrObjectNotFound)
+}
+
+func (s *Suite) TestRedirect_PasswordProtected() {
+	ctx := context.Background()
+
+	hash, err := model.HashLinkPassword("s3cret!")
+	s.Require().NoError(err)
+
+	err = s.urlRepo.Save(ctx, &model.ShortenedURL{
+		OriginalURL:  "https://example.com",
+		ShortURL:     "SOMEURL",
+		CreatedAtUTC: time.Now().UTC(),
+		Status:       model.URLStatusActive,
+		PasswordHash: hash,
+	})
+	s.Require().NoError(err)
+
+	handler, err := queries.NewRedirectQueryHandler(
+		s.l, s.cache, s.counter, s.visitors, s.clicks, s.passwordAttempts, s.redirectMetrics, s.geo, s.pgxPool, "",
+	)
+	s.Require().NoError(err)
+
+	visitor := queries.Visitor{UserAgent: "Firefox/128.0", Accept: "text/html"}
+	redirect := func(password string) (queries.RedirectResponse, error) {
+		return handler.Handle(ctx, queries.RedirectQuery{ShortURL: "SOMEURL", Visitor: visitor, Password: password})
+	}
+
+	// The first redirect reads url from db, the next ones from cache, which must keep checking password.
+	_, err = redirect("")
+	s.Require().ErrorIs(err, queries.ErrPasswordRequired)
+
+	cached, err := s.cache.Get(ctx, "SOMEURL")
+	s.Require().NoError(err)
+	s.Require().NotNil(cached)
+	s.True(cached.IsPasswordProtected())
+
+	_, err = redirect("wrong password")
+	s.Require().ErrorIs(err, queries.ErrPasswordInvalid)
+
+	resp, err := redirect("s3cret!")
+	s.Require().NoError(err)
+	s.Equal("https://example.com", resp.OriginalURL)
+	s.True(resp.PasswordProtected)
+
+	for range passwordMaxAttempts - 1 {
+		_, err = redirect("wrong password")
+		s.Require().ErrorIs(err, queries.ErrPasswordInvalid)
+	}
+
+	// Too many wrong passwords block even the right one.
+	_, err = redirect("s3cret!")
+	s.Require().ErrorIs(err, queries.ErrTooManyPasswordAttempts)
+
+	// Only the successful redirect is a click.
+	s.Require().NoError(s.counter.Flush(ctx))
+	var clicks int
+	err = s.pgxPool.QueryRow(ctx, "SELECT clicks FROM urls WHERE short_url = 'SOMEURL'").Scan(&clicks)
+	s.Require().NoError(err)
+	s.Equal(1, clicks)
+
+	// Neither preview nor reuse reveal destination.
+	previewHandler, err := queries.NewPreviewURLQueryHandler(s.l, s.cache, s.pgxPool)
+	s.Require().NoError(err)
+
+	preview, err := previewHandler.Handle(ctx, queries.PreviewURLQuery{ShortURL: "SOMEURL"})
+	s.Require().NoError(err)
+	s.True(preview.PasswordProtected)
+	s.Empty(preview.OriginalURL)
+	s.Equal(model.SafetyVerdictUnknown, preview.Safety.Verdict)
+
+	_, err = s.urlRepo.GetByOriginalURL(ctx, "https://example.com", nil)
+	s.Require().ErrorIs(err, errs.ErrObjectNotFound)
+}
+
+func (s *Suite) TestRedirect_MaxClicks() {
+	ctx := context.Background()
+
+	err := s.urlRepo.Save(ctx, &model.ShortenedURL{
+		OriginalURL:  "https://example.com",
+		ShortURL:     "SOMEURL",
+		CreatedAtUTC: time.Now().UTC(),
+		Status:       model.URLStatusActive,
+		MaxClicks:    ptr(2),
+	})
+	s.Require().NoError(err)
+
+	handler, err := queries.NewRedirectQueryHandler(
+		s.l, s.cache, s.counter, s.visitors, s.clicks, s.passwordAttempts, s.redirectMetrics, s.geo, s.pgxPool, "",
+	)
+	s.Require().NoError(err)
+
+	human := queries.RedirectQuery{
+		ShortURL: "SOMEURL",
+		Visitor:  queries.Visitor{UserAgent: "Firefox/128.0", Accept: "text/html"},
+	}
+	bot := queries.RedirectQuery{ShortURL: "SOMEURL", Visitor: queries.Visitor{UserAgent: "Slackbot 1.0"}}
+
+	// Preview isn't counted as click, so it doesn't reveal destination.
+	previewHandler, err := queries.NewPreviewURLQueryHandler(s.l, s.cache, s.pgxPool)
+	s.Require().NoError(err)
+
+	preview, err := previewHandler.Handle(ctx, queries.PreviewURLQuery{ShortURL: "SOMEURL"})
+	s.Require().NoError(err)
+	s.True(preview.ClickLimited)
+	s.Empty(preview.OriginalURL)
+	s.Equal(model.SafetyVerdictUnknown, preview.Safety.Verdict)
+
+	// Bots don't use url up, so they don't get its destination either.
+	resp, err := handler.Handle(ctx, bot)
+	s.Require().NoError(err)
+	s.Empty(resp.OriginalURL)
+	s.True(resp.ClickLimited)
+
+	// Url is cached by bot's redirect, clicks of cached url are counted against the limit too.
+	for range 2 {
+		resp, err = handler.Handle(ctx, human)
+		s.Require().NoError(err)
+		s.Equal("https://example.com", resp.OriginalURL)
+	}
+
+	// Url reaching its limit is evicted from cache.
+	_, err = s.cache.Get(ctx, "SOMEURL")
+	s.Require().ErrorIs(err, errs.ErrObjectNotFound)
+
+	for _, q := range []queries.RedirectQuery{human, bot} {
+		_, err = handler.Handle(ctx, q)
+		s.Require().ErrorIs(err, queries.ErrClickLimitReached)
+	}
+
+	var clicks int
+	err = s.pgxPool.QueryRow(ctx, "SELECT clicks FROM urls WHERE short_url = 'SOMEURL'").Scan(&clicks)
+	s.Require().NoError(err)
+	s.Equal(2, clicks)
+}
+
+func (s *Suite) TestRedirect_MaxClicksConcurrent() {
+	ctx := context.Background()
+
+	err := s.urlRepo.Save(ctx, &model.ShortenedURL{
+		OriginalURL:  "https://example.com",
+		ShortURL:     "SOMEURL",
+		CreatedAtUTC: time.Now().UTC(),
+		Status:       model.URLStatusActive,
+		MaxClicks:    ptr(5),
+	})
+	s.Require().NoError(err)
+
+	handler, err := queries.NewRedirectQueryHandler(
+		s.l, s.cache, s.counter, s.visitors, s.clicks, s.passwordAttempts, s.redirectMetrics, s.geo, s.pgxPool, "",
+	)
+	s.Require().NoError(err)
+
+	var (
+		wg                 sync.WaitGroup
+		redirected, usedUp atomic.Int32
+	)
+	for range 20 {
+		wg.Go(func() {
+			_, hErr := handler.Handle(ctx, queries.RedirectQuery{
+				ShortURL: "SOMEURL",
+				Visitor:  queries.Visitor{UserAgent: "Firefox/128.0", Accept: "text/html"},
+			})
+			switch {
+			case hErr == nil:
+				redirected.Add(1)
+			case errors.Is(hErr, queries.ErrClickLimitReached):
+				usedUp.Add(1)
+			}
+		})
+	}
+	wg.Wait()
+
+	s.Equal(int32(5), redirected.Load())
+	s.Equal(int32(15), usedUp.Load())
+}
+
+func (s *Suite) TestRedirect_Pending() {
+	ctx := context.Background()
+
+	validFrom := time.Now().Add(time.Hour).UTC()
+	for _, url := range []*model.ShortenedURL{
+		{ShortURL: "PENDING", FallbackURL: ""},
+		{ShortURL: "FALLBACK", FallbackURL: "https://example.com/soon"},
+	} {
+		url.OriginalURL = "https://example.com"
+		url.CreatedAtUTC = time.Now().UTC()
+		url.Status = model.URLStatusActive
+		url.RedirectCode = model.RedirectCodeMovedPermanently
+		url.ValidFromUTC = &validFrom
+		s.Require().NoError(s.urlRepo.Save(ctx, url))
+	}
+
+	handler, err := queries.NewRedirectQueryHandler(
+		s.l, s.cache, s.counter, s.visitors, s.clicks, s.passwordAttempts, s.redirectMetrics, s.geo, s.pgxPool, "",
+	)
+	s.Require().NoError(err)
+
+	human := queries.Visitor{UserAgent: "Firefox/128.0", Accept: "text/html"}
+
+	// The second redirect reads url from cache, it's still pending there.
+	for range 2 {
+		_, err = handler.Handle(ctx, queries.RedirectQuery{ShortURL: "PENDING", Visitor: human})
+		s.Require().ErrorIs(err, queries.ErrURLPending)
+
+		var resp queries.RedirectResponse
+		resp, err = handler.Handle(ctx, queries.RedirectQuery{ShortURL: "FALLBACK", Visitor: human})
+		s.Require().NoError(err)
+		s.Equal("https://example.com/soon", resp.OriginalURL)
+		s.Equal(model.RedirectCodeFound, resp.RedirectCode)
+	}
+
+	cached, err := s.cache.Get(ctx, "PENDING")
+	s.Require().NoError(err)
+	s.Require().NotNil(cached.ValidFromUTC)
+	s.WithinDuration(validFrom, *cached.ValidFromUTC, time.Millisecond)
+
+	// Pending urls aren't reused.
+	_, err = s.urlRepo.GetByOriginalURL(ctx, "https://example.com", nil)
+	s.Require().ErrorIs(err, errs.ErrObjectNotFound)
+
+	infoHandler, err := queries.NewGetURLInfoQueryHandler(s.l, s.visitors, s.pgxPool)
+	s.Require().NoError(err)
+
+	q, err := queries.NewGetURLInfoQuery(adminPrincipal, "FALLBACK")
+	s.Require().NoError(err)
+
+	info, err := infoHandler.Handle(ctx, q)
+	s.Require().NoError(err)
+	s.True(info.Pending)
+	s.Equal("https://example.com/soon", info.FallbackURL)
 }
+
+func (s *Suite) TestRedirect_Fallback() {
+	ctx := context.Background()
+
+	for _, url := range []*model.ShortenedURL{
+		{ShortURL: "EXPIRED", Status: model.URLStatusActive, ValidUntilUTC: ptr(time.Now().UTC().Add(-time.Minute))},
+		{ShortURL: "DISABLED", Status: model.URLStatusDisabled, FallbackURL: "https://example.com/disabled"},
+		{ShortURL: "USEDUP", Status: model.URLStatusActive, MaxClicks: ptr(1)},
+		{ShortURL: "PENDING", Status: model.URLStatusActive, ValidFromUTC: ptr(time.Now().UTC().Add(time.Hour))},
+	} {
+		url.OriginalURL = "https://example.com"
+		url.CreatedAtUTC = time.Now().UTC().Add(-time.Hour)
+		url.RedirectCode = model.RedirectCodeMovedPermanently
+		s.Require().NoError(s.urlRepo.Save(ctx, url))
+	}
+
+	reg := prometheus.NewRegistry()
+	metrics, err := redirectmetrics.NewMetrics(reg)
+	s.Require().NoError(err)
+
+	handler, err := queries.NewRedirectQueryHandler(
+		s.l, s.cache, s.counter, s.visitors, s.clicks, s.passwordAttempts, metrics, s.geo, s.pgxPool,
+		"https://example.com/gone",
+	)
+	s.Require().NoError(err)
+
+	human := queries.Visitor{UserAgent: "Firefox/128.0", Accept: "text/html"}
+	follow := func(shortURL string) (queries.RedirectResponse, error) {
+		return handler.Handle(ctx, queries.RedirectQuery{ShortURL: shortURL, Visitor: human})
+	}
+
+	resp, err := follow("USEDUP")
+	s.Require().NoError(err)
+	s.Equal("https://example.com", resp.OriginalURL)
+
+	for shortURL, expected := range map[string]string{
+		"EXPIRED":  "https://example.com/gone",
+		"DISABLED": "https://example.com/disabled",
+		"USEDUP":   "https://example.com/gone",
+	} {
+		resp, err = follow(shortURL)
+		s.Require().NoError(err, shortURL)
+		s.Equal(expected, resp.OriginalURL, shortURL)
+		s.Equal(model.RedirectCodeFound, resp.RedirectCode, shortURL)
+	}
+
+	// Default fallback url is for urls that are gone, not for the ones yet to come.
+	_, err = follow("PENDING")
+	s.Require().ErrorIs(err, queries.ErrURLPending)
+
+	// Missing url has nothing to fall back from.
+	_, err = follow("MISSING")
+	s.Require().ErrorIs(err, errs.ErrObjectNotFound)
+
+	err = testutil.GatherAndCompare(reg, strings.NewReader(`
+# HELP urlshortener_fallback_redirects_total Amount of redirects to fallback url by reason url couldn't be followed.
+# TYPE urlshortener_fallback_redirects_total counter
+urlshortener_fallback_redirects_total{reason="click_limit_reached"} 1
+urlshortener_fallback_redirects_total{reason="disabled"} 1
+urlshortener_fallback_redirects_total{reason="expired"} 1
+urlshortener_fallback_redirects_total{reason="pending"} 0
+`))
+	s.Require().NoError(err)
+}
+
+func (s *Suite) TestRedirect_Targeting() {
+	ctx := context.Background()
+
+	url, err := model.NewShortenedURL("https://example.com/app", "SOMEURL", nil)
+	s.Require().NoError(err)
+	url.TargetingRules = []model.TargetingRule{
+		{OS: "iOS", TargetURL: "https://apps.apple.com/app/id1"},
+		{OS: "Android", Device: model.DeviceMobile, TargetURL: "https://play.google.com/store/apps/details?id=app"},
+	}
+	s.Require().NoError(s.urlRepo.Save(ctx, url))
+
+	handler, err := queries.NewRedirectQueryHandler(
+		s.l, s.cache, s.counter, s.visitors, s.clicks, s.passwordAttempts, s.redirectMetrics, s.geo, s.pgxPool, "",
+	)
+	s.Require().NoError(err)
+
+	tt := []struct {
+		visitor  queries.Visitor
+		expected string
+	}{
+		{
+			visitor: queries.Visitor{
+				UserAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) Mobile/15E148 Safari/604.1",
+				Accept:    "text/html",
+			},
+			expected: "https://apps.apple.com/app/id1",
+		},
+		{
+			// Reduced user agent tells platform in client hints only.
+			visitor: queries.Visitor{
+				UserAgent:    "Mozilla/5.0 (X11; Linux x86_64) Chrome/130.0.0.0 Safari/537.36",
+				Accept:       "text/html",
+				PlatformHint: `"Android"`,
+				MobileHint:   "?1",
+			},
+			expected: "https://play.google.com/store/apps/details?id=app",
+		},
+		{
+			// Android tablet matches no rule.
+			visitor: queries.Visitor{
+				UserAgent: "Mozilla/5.0 (Linux; Android 13; SM-X700) Chrome/130.0.0.0 Safari/537.36",
+				Accept:    "text/html",
+			},
+			expected: "https://example.com/app",
+		},
+		{
+			visitor: queries.Visitor{
+				UserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) Firefox/128.0",
+				Accept:    "text/html",
+			},
+			expected: "https://example.com/app",
+		},
+	}
+
+	// The second round reads url from cache.
+	for range 2 {
+		for _, tc := range tt {
+			resp, err := handler.Handle(ctx, queries.RedirectQuery{ShortURL: "SOMEURL", Visitor: tc.visitor})
+			s.Require().NoError(err)
+			s.Equal(tc.expected, resp.OriginalURL, tc.visitor.UserAgent)
+			s.True(resp.Targeted)
+		}
+	}
+
+	infoHandler, err := queries.NewGetURLInfoQueryHandler(s.l, s.visitors, s.pgxPool)
+	s.Require().NoError(err)
+
+	q, err := queries.NewGetURLInfoQuery(adminPrincipal, "SOMEURL")
+	s.Require().NoError(err)
+
+	info, err := infoHandler.Handle(ctx, q)
+	s.Require().NoError(err)
+	s.Equal(url.TargetingRules, info.TargetingRules)
+}
+
+func (s *Suite) TestRedirect_GeoTargeting() {
+	ctx := context.Background()
+
+	url, err := model.NewShortenedURL("https://shop.example.com", "SOMEURL", nil)
+	s.Require().NoError(err)
+	url.TargetingRules = []model.TargetingRule{
+		{Country: "FR", Device: model.DeviceMobile, TargetURL: "https://m.shop.example.fr"},
+		{Country: "FR", TargetURL: "https://shop.example.fr"},
+		{Country: "DE", TargetURL: "https://shop.example.de"},
+	}
+	s.Require().NoError(s.urlRepo.Save(ctx, url))
+
+	geo := staticGeoLocator{"203.0.113.7": "DE", "198.51.100.1": "FR"}
+	handler, err := queries.NewRedirectQueryHandler(
+		s.l, s.cache, s.counter, s.visitors, s.clicks, s.passwordAttempts, s.redirectMetrics, geo, s.pgxPool, "",
+	)
+	s.Require().NoError(err)
+
+	const (
+		desktop = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) Firefox/128.0"
+		iphone  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) Mobile/15E148 Safari/604.1"
+	)
+
+	tt := []struct {
+		ip        string
+		userAgent string
+		expected  string
+	}{
+		{ip: "203.0.113.7", userAgent: desktop, expected: "https://shop.example.de"},
+		{ip: "203.0.113.7", userAgent: iphone, expected: "https://shop.example.de"},
+		{ip: "198.51.100.1", userAgent: iphone, expected: "https://m.shop.example.fr"},
+		{ip: "198.51.100.1", userAgent: desktop, expected: "https://shop.example.fr"},
+		// Visitor of unknown country matches no rule with country.
+		{ip: "192.0.2.1", userAgent: iphone, expected: "https://shop.example.com"},
+	}
+
+	// The second round reads url from cache.
+	for range 2 {
+		for _, tc := range tt {
+			resp, err := handler.Handle(ctx, queries.RedirectQuery{
+				ShortURL: "SOMEURL",
+				Visitor:  queries.Visitor{UserAgent: tc.userAgent, IP: tc.ip, Accept: "text/html"},
+			})
+			s.Require().NoError(err)
+			s.Equal(tc.expected, resp.OriginalURL, tc.ip)
+			s.True(resp.GeoTargeted)
+		}
+	}
+
+	// Closing recorder flushes queued events.
+	s.Require().NoError(s.clicks.Close(ctx))
+
+	var countries []string
+	err = s.pgxPool.QueryRow(ctx, `SELECT array_agg(country ORDER BY country) FROM click_events`).Scan(&countries)
+	s.Require().NoError(err)
+	s.Equal([]string{"", "", "DE", "DE", "DE", "DE", "FR", "FR", "FR", "FR"}, countries)
+
+	infoHandler, err := queries.NewGetURLInfoQueryHandler(s.l, s.visitors, s.pgxPool)
+	s.Require().NoError(err)
+
+	q, err := queries.NewGetURLInfoQuery(adminPrincipal, "SOMEURL")
+	s.Require().NoError(err)
+
+	info, err := infoHandler.Handle(ctx, q)
+	s.Require().NoError(err)
+	s.Equal(url.TargetingRules, info.TargetingRules)
+}
+
+// staticGeoLocator locates ips by its keys, other ips are of unknown country.
+type staticGeoLocator map[string]string
+
+func (l staticGeoLocator) Country(_ context.Context, ip string) (string, error) {
+	return l[ip], nil
+}
+
+func (l staticGeoLocator) Close(_ context.Context) error {
+	return nil
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+//nolint:gochecknoglobals // Test fixture.
+var adminPrincipal = auth.Principal{Name: "admin", Role: string(model.RoleAdmin), Scopes: model.RoleAdmin.Scopes()}
@@ -0,0 +1,106 @@
+package integration_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/queries"
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
+	"github.com/dzhordano/urlshortener/internal/pkg/auth"
+	"github.com/dzhordano/urlshortener/internal/pkg/errs"
+	"github.com/dzhordano/urlshortener/internal/pkg/scheduler/tasks"
+	"github.com/google/uuid"
+)
+
+func (s *Suite) TestGetURLStats_ReadsRollups() {
+	ctx := context.Background()
+
+	url, err := model.NewShortenedURL("http://example.com", "SOMEURL", nil)
+	s.Require().NoError(err)
+	s.Require().NoError(s.urlRepo.Save(ctx, url))
+
+	const (
+		firefox = "Mozilla/5.0 (X11; Linux x86_64; rv:128.0) Gecko/20100101 Firefox/128.0"
+		iphone  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 " +
+			"(KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1"
+	)
+
+	day := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	id := url.ID
+	events := []model.ClickEvent{
+		model.NewClickEvent(id, "SOMEURL", day.Add(10*time.Hour), "https://news.example.org/a", firefox, "203.0.113.7", ""),
+		// Same visitor (ip prefix and user agent) again.
+		model.NewClickEvent(id, "SOMEURL", day.Add(10*time.Hour+time.Minute), "", firefox, "203.0.113.8", ""),
+		model.NewClickEvent(
+			id, "SOMEURL", day.Add(12*time.Hour), "https://news.example.org/b", iphone, "198.51.100.1", "",
+		),
+		// Next day.
+		model.NewClickEvent(id, "SOMEURL", day.Add(30*time.Hour), "https://t.co/x", iphone, "198.51.100.1", ""),
+		// Other url.
+		model.NewClickEvent(uuid.New(), "OTHERURL", day.Add(10*time.Hour), "", firefox, "203.0.113.7", ""),
+		// Purged url which held the same short url before.
+		model.NewClickEvent(
+			uuid.New(), "SOMEURL", day.Add(11*time.Hour), "https://old.example.org", firefox, "192.0.2.1", "",
+		),
+	}
+	for _, e := range events {
+		s.Require().NoError(s.clicks.Record(ctx, e))
+	}
+	s.Require().NoError(s.clicks.Close(ctx))
+
+	task := tasks.NewRollupClickEventsTask(s.pgxPool)
+	s.Require().NoError(task.Execute(ctx))
+	// Events already rolled up aren't counted twice.
+	s.Require().NoError(task.Execute(ctx))
+
+	// Visitor hashes and salts of past days are replaced by visitor counts.
+	var hashes, salts int
+	s.Require().NoError(s.pgxPool.QueryRow(ctx, `
+	SELECT (SELECT COUNT(*) FROM click_rollups_visitors), (SELECT COUNT(*) FROM click_rollups_salts)`,
+	).Scan(&hashes, &salts))
+	s.Zero(hashes)
+	s.Zero(salts)
+
+	handler, err := queries.NewGetURLStatsQueryHandler(s.l, s.pgxPool)
+	s.Require().NoError(err)
+
+	from, to := day, day.Add(48*time.Hour)
+	q, err := queries.NewGetURLStatsQuery(adminPrincipal, "SOMEURL", &from, &to, "day")
+	s.Require().NoError(err)
+
+	resp, err := handler.Handle(ctx, q)
+	s.Require().NoError(err)
+
+	s.Equal(4, resp.Clicks)
+	s.Equal([]queries.ClicksBucket{
+		{StartUTC: day, Clicks: 3},
+		{StartUTC: day.AddDate(0, 0, 1), Clicks: 1},
+	}, resp.Buckets)
+	s.Equal([]queries.StatsEntry{{Value: "news.example.org", Clicks: 2}, {Value: "t.co", Clicks: 1}}, resp.TopReferrers)
+	s.Empty(resp.TopCountries)
+	s.Equal([]queries.StatsEntry{{Value: "desktop", Clicks: 2}, {Value: "mobile", Clicks: 2}}, resp.Devices)
+	s.Equal([]queries.StatsEntry{{Value: "Firefox", Clicks: 2}, {Value: "Safari", Clicks: 2}}, resp.Browsers)
+	s.ElementsMatch([]queries.StatsEntry{{Value: "Linux", Clicks: 2}, {Value: "iOS", Clicks: 2}}, resp.OSs)
+	// Visitor coming back next day is counted again.
+	s.Equal(3, resp.UniqueVisitors)
+
+	// Hourly buckets of the first day.
+	to = day.Add(13 * time.Hour)
+	q, err = queries.NewGetURLStatsQuery(adminPrincipal, "SOMEURL", &from, &to, "hour")
+	s.Require().NoError(err)
+
+	resp, err = handler.Handle(ctx, q)
+	s.Require().NoError(err)
+
+	s.Equal(3, resp.Clicks)
+	s.Len(resp.Buckets, 13)
+	s.Equal(2, resp.Buckets[10].Clicks)
+	s.Equal(1, resp.Buckets[12].Clicks)
+
+	// Someone else's url is not found.
+	q, err = queries.NewGetURLStatsQuery(auth.Principal{KeyID: uuid.New(), Name: "other"}, "SOMEURL", &from, &to, "day")
+	s.Require().NoError(err)
+
+	_, err = handler.Handle(ctx, q)
+	s.Require().ErrorIs(err, errs.ErrObjectNotFound)
+}
@@ -6,14 +6,25 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dzhordano/urlshortener/internal/adapters/outbound/geoip"
+	"github.com/dzhordano/urlshortener/internal/adapters/outbound/metrics/redirectmetrics"
+	"github.com/dzhordano/urlshortener/internal/adapters/outbound/pg/apikeyrepo"
+	"github.com/dzhordano/urlshortener/internal/adapters/outbound/pg/clickcounter"
+	"github.com/dzhordano/urlshortener/internal/adapters/outbound/pg/clickrecorder"
 	"github.com/dzhordano/urlshortener/internal/adapters/outbound/pg/urlrepo"
+	"github.com/dzhordano/urlshortener/internal/adapters/outbound/redis/passwordattempts"
 	"github.com/dzhordano/urlshortener/internal/adapters/outbound/redis/urlcache"
+	"github.com/dzhordano/urlshortener/internal/adapters/outbound/redis/visitorcounter"
+	"github.com/dzhordano/urlshortener/internal/adapters/outbound/tokengen"
+	"github.com/dzhordano/urlshortener/internal/core/application/usecases/commands"
+	"github.com/dzhordano/urlshortener/internal/core/domain/model"
 	"github.com/dzhordano/urlshortener/internal/core/ports"
 	"github.com/dzhordano/urlshortener/internal/pkg/logger"
 	"github.com/dzhordano/urlshortener/migrations"
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/lib/pq"
 	"github.com/pressly/goose/v3"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/suite"
 	"github.com/testcontainers/testcontainers-go"
@@ -21,6 +32,8 @@ import (
 	tcredis "github.com/testcontainers/testcontainers-go/modules/redis"
 )
 
+const passwordMaxAttempts = 3
+
 //nolint:embeddedstructfieldcheck // suite.Suite needs to be embedded.
 type Suite struct {
 	suite.Suite
@@ -32,8 +45,24 @@ type Suite struct {
 	pgxPool *pgxpool.Pool
 	redisDB *redis.Client
 
-	urlRepo ports.URLRepository
-	cache   ports.URLCache
+	urlRepo    ports.URLRepository
+	apiKeyRepo ports.APIKeyRepository
+	cache      ports.URLCache
+	visitors   ports.VisitorCounter
+	tokenGen   ports.TokenGenerator
+	// passwordAttempts allows passwordMaxAttempts failed password attempts per url.
+	passwordAttempts ports.PasswordAttemptLimiter
+	// clicks is recreated for every test, so closing it flushes events recorded by the test.
+	clicks ports.ClickRecorder
+	// counter is recreated for every test, its metrics are registered in test's own registry.
+	counter ports.ClickCounter
+	// redirectMetrics are recreated for every test the same way counter is.
+	redirectMetrics ports.RedirectMetrics
+	// geo locates no visitors, tests needing countries use staticGeoLocator.
+	geo ports.GeoLocator
+
+	expirationPolicy commands.ExpirationPolicy
+	redirectCode     model.RedirectCode
 }
 
 func (s *Suite) SetupSuite() {
@@ -111,9 +140,21 @@ func (s *Suite) SetupSuite() {
 	urlRepo, err := urlrepo.NewRepository(pool)
 	s.Require().NoError(err)
 
+	apiKeyRepo, err := apikeyrepo.NewRepository(pool)
+	s.Require().NoError(err)
+
 	c, err := urlcache.NewRedisCache(rdb, 5*time.Second)
 	s.Require().NoError(err)
 
+	visitors, err := visitorcounter.NewRedisVisitorCounter(rdb)
+	s.Require().NoError(err)
+
+	passwordAttempts, err := passwordattempts.NewRedisPasswordAttemptLimiter(rdb, passwordMaxAttempts, time.Minute)
+	s.Require().NoError(err)
+
+	tokenGen, err := tokengen.NewRandomGenerator(8)
+	s.Require().NoError(err)
+
 	// Set suite values
 	s.pgContainer = postgresContainer
 	s.redisContainer = redisContainer
@@ -121,7 +162,17 @@ func (s *Suite) SetupSuite() {
 	s.pgxPool = pool
 	s.redisDB = rdb
 	s.urlRepo = urlRepo
+	s.apiKeyRepo = apiKeyRepo
 	s.cache = c
+	s.visitors = visitors
+	s.passwordAttempts = passwordAttempts
+	s.tokenGen = tokenGen
+	s.geo = geoip.NewNoopLocator()
+	s.expirationPolicy = commands.ExpirationPolicy{
+		DefaultTTL: 24 * time.Hour,
+		MaxTTL:     7 * 24 * time.Hour,
+	}
+	s.redirectCode = model.RedirectCodeFound
 }
 
 func (s *Suite) TearDownSuite() {
@@ -144,12 +195,31 @@ func (s *Suite) TearDownSuite() {
 }
 
 func (s *Suite) SetupTest() {
-	// Empty...
+	clicks, err := clickrecorder.NewRecorder(s.l, s.pgxPool, clickrecorder.Config{
+		QueueSize:     100,
+		BatchSize:     10,
+		FlushInterval: 50 * time.Millisecond,
+	})
+	s.Require().NoError(err)
+	s.clicks = clicks
+
+	counter, err := clickcounter.NewCounter(s.l, s.pgxPool, prometheus.NewRegistry())
+	s.Require().NoError(err)
+	s.counter = counter
+
+	redirectMetrics, err := redirectmetrics.NewMetrics(prometheus.NewRegistry())
+	s.Require().NoError(err)
+	s.redirectMetrics = redirectMetrics
 }
 
 func (s *Suite) TearDownTest() {
+	s.NoError(s.clicks.Close(context.Background()))
+
 	// Truncate all tables
-	_, err := s.pgxPool.Exec(context.Background(), "TRUNCATE TABLE urls")
+	_, err := s.pgxPool.Exec(context.Background(), `
+	TRUNCATE TABLE urls, url_targeting_rules, api_keys, click_events,
+		click_rollups_hourly, click_rollups_dimensions, click_rollups_visitors,
+		click_rollups_visitor_counts, click_rollups_salts`)
 	s.NoError(err)
 
 	// Clear redis cache